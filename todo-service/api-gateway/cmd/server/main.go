@@ -9,23 +9,89 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/sahidhossen/todo/api-gateway/internal/auth"
 	"github.com/sahidhossen/todo/api-gateway/internal/config"
 	"github.com/sahidhossen/todo/api-gateway/internal/handlers"
+	"github.com/sahidhossen/todo/api-gateway/internal/metrics"
+	"github.com/sahidhossen/todo/api-gateway/internal/middleware"
 	"github.com/sahidhossen/todo/api-gateway/internal/server"
 	"github.com/sahidhossen/todo/api-gateway/internal/services"
+	"github.com/sahidhossen/todo/api-gateway/internal/tlsconfig"
+	"github.com/sahidhossen/todo/api-gateway/internal/tracing"
+	"github.com/sahidhossen/todo/proto/ctxlog"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
+// metricsPath serves the Prometheus metrics, without authentication.
+const metricsPath = "/metrics"
+
 func main() {
-	// Setup structured logger
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+	// Setup structured logger, tagging records with the request ID and trace
+	// of their context
+	logger := slog.New(ctxlog.NewHandler(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: slog.LevelDebug,
-	}))
+	})))
 	slog.SetDefault(logger)
 
 	cfg := config.LoadConfig()
 
-	// Initiate gRPC client for the storage service
-	taskClient, err := services.NewGRPCClient(cfg.GRPCHost, logger)
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Exporter:    cfg.TracesExporter,
+		File:        cfg.TracesFile,
+		ServiceName: cfg.ServiceName,
+	})
+	if err != nil {
+		logger.Error("Failed to set up tracing", "error", err)
+		os.Exit(1)
+	}
+
+	verifier, err := auth.NewVerifier(auth.Config{
+		Secret:        cfg.JWTSecret,
+		PublicKeyFile: cfg.JWTPublicKeyFile,
+		JWKSFile:      cfg.JWKSFile,
+		Issuer:        cfg.JWTIssuer,
+		Audience:      cfg.JWTAudience,
+		TokenTTL:      cfg.JWTTokenTTL,
+	})
+	if err != nil {
+		logger.Error("Failed to load JWT keys", "error", err)
+		os.Exit(1)
+	}
+
+	// Connect over TLS when it is configured
+	var creds credentials.TransportCredentials
+	tlsConfig := tlsconfig.ClientConfig{
+		CAFile:     cfg.GRPCTLSCAFile,
+		CertFile:   cfg.GRPCTLSCertFile,
+		KeyFile:    cfg.GRPCTLSKeyFile,
+		ServerName: cfg.GRPCTLSServerName,
+	}
+	if tlsConfig.Enabled() {
+		creds, err = tlsconfig.NewClientCredentials(tlsConfig, logger)
+		if err != nil {
+			logger.Error("Failed to load TLS certificates", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// Metrics cover HTTP requests and the gRPC calls they make, served at /metrics
+	gatewayMetrics := metrics.New()
+
+	// Initiate gRPC client for the storage service. Calls continue the trace
+	// of their request, sending its traceparent in the metadata
+	dialOpts := []grpc.DialOption{
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithChainUnaryInterceptor(gatewayMetrics.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(gatewayMetrics.StreamClientInterceptor()),
+	}
+	// The storage service believes the forwarded user ID only with the
+	// gateway secret or a client certificate
+	if cfg.GatewaySecret != "" {
+		dialOpts = append(dialOpts, services.GatewaySecret(cfg.GatewaySecret))
+	}
+	taskClient, err := services.NewGRPCClient(cfg.GRPCHost, creds, logger, dialOpts...)
 	if err != nil {
 		logger.Error("Faield to connect gRPC service", "error", err)
 		os.Exit(1)
@@ -39,16 +105,26 @@ func main() {
 
 	//Initialize HTTP handlers with the gRPC client
 	handler := handlers.New(taskClient, logger)
+	if verifier.CanIssue() {
+		handler.WithTokenIssuer(verifier)
+	}
 
 	//Setup Gorilla Mux router
-	router := server.NewRouter(logger)
+	router := server.NewRouter(logger, gatewayMetrics)
+	router.Use(middleware.AuthMiddleware(auth.NewAuthenticator(verifier, taskClient), middleware.AuthOptions{
+		PublicPaths:     append([]string{metricsPath}, handlers.PublicPaths...),
+		QueryTokenPaths: handlers.StreamPaths,
+	}, logger))
 	handler.RegisterRoutes(router)
+	router.Handle(metricsPath, gatewayMetrics.Handler()).Methods(http.MethodGet)
 
 	// Register Global Fallback for OPTIONS and 404s
 	router.PathPrefix("/").HandlerFunc(handlers.NotFoundHandler)
 
 	//Create and start HTTP server
 	httpService := server.NewHTTPService(cfg.Port, router, logger)
+	httpService.RegisterOnShutdown(handler.Drain)
+	httpService.RegisterOnShutdown(handler.CloseStreams)
 
 	// Graceful shutdown channel
 	quit := make(chan os.Signal, 1)
@@ -75,6 +151,11 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Flush the spans of the last requests
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Error("Failed to flush traces", "error", err)
+	}
+
 	logger.Info("API Gateway gracefully stopped.")
 
 }
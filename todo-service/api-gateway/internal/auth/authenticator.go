@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sahidhossen/todo/proto/task_service"
+)
+
+// ApiTokenPrefix starts the personal API tokens the storage service issues.
+// Bearer tokens without it are taken to be JWTs.
+const ApiTokenPrefix = "todo_pat_"
+
+// ErrInvalidToken is returned by Authenticate for tokens that are malformed,
+// badly signed, expired, revoked or unknown.
+var ErrInvalidToken = errors.New("invalid token")
+
+// ApiTokenVerifier resolves personal API tokens to their user and scopes.
+type ApiTokenVerifier interface {
+	VerifyApiToken(ctx context.Context, token string) (*pb.VerifyApiTokenResponse, error)
+}
+
+// Authenticator accepts both JWTs and personal API tokens as bearer tokens.
+type Authenticator struct {
+	jwt       *Verifier
+	apiTokens ApiTokenVerifier
+}
+
+// NewAuthenticator returns an Authenticator checking JWTs with jwt and API
+// tokens with apiTokens.
+func NewAuthenticator(jwt *Verifier, apiTokens ApiTokenVerifier) *Authenticator {
+	return &Authenticator{jwt: jwt, apiTokens: apiTokens}
+}
+
+// Authenticate returns the user a bearer token acts for and the scopes it
+// grants. The error wraps ErrInvalidToken when the token is refused; other
+// errors mean it could not be checked.
+func (a *Authenticator) Authenticate(ctx context.Context, token string) (string, []string, error) {
+	if strings.HasPrefix(token, ApiTokenPrefix) {
+		resp, err := a.apiTokens.VerifyApiToken(ctx, token)
+		if status.Code(err) == codes.Unauthenticated {
+			return "", nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		return resp.UserId, resp.Scopes, nil
+	}
+
+	subject, err := a.jwt.Verify(token)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	return subject, AllScopes, nil
+}
@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sahidhossen/todo/api-gateway/mocks"
+	pb "github.com/sahidhossen/todo/proto/task_service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthenticator(t *testing.T) {
+	verifier, err := NewVerifier(Config{Secret: "s3cret", TokenTTL: time.Hour})
+	require.NoError(t, err)
+	apiTokens := new(mocks.MockTaskService)
+	a := NewAuthenticator(verifier, apiTokens)
+
+	apiTokens.On("VerifyApiToken", mock.Anything, "todo_pat_good").
+		Return(&pb.VerifyApiTokenResponse{UserId: "user-2", Scopes: []string{ScopeRead}}, nil)
+	apiTokens.On("VerifyApiToken", mock.Anything, "todo_pat_revoked").
+		Return(nil, status.Error(codes.Unauthenticated, "invalid or expired API token"))
+	apiTokens.On("VerifyApiToken", mock.Anything, "todo_pat_unchecked").
+		Return(nil, status.Error(codes.Unavailable, "connection refused"))
+
+	jwt, _, err := verifier.Issue("user-1", time.Now())
+	require.NoError(t, err)
+	userID, scopes, err := a.Authenticate(context.Background(), jwt)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", userID)
+	assert.Equal(t, AllScopes, scopes)
+
+	userID, scopes, err = a.Authenticate(context.Background(), "todo_pat_good")
+	require.NoError(t, err)
+	assert.Equal(t, "user-2", userID)
+	assert.Equal(t, []string{ScopeRead}, scopes)
+
+	_, _, err = a.Authenticate(context.Background(), "todo_pat_revoked")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	_, _, err = a.Authenticate(context.Background(), "not-a-jwt")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	_, _, err = a.Authenticate(context.Background(), "todo_pat_unchecked")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestHasScope(t *testing.T) {
+	ctx := WithScopes(context.Background(), []string{ScopeWrite})
+	assert.True(t, HasScope(ctx, ScopeRead))
+	assert.True(t, HasScope(ctx, ScopeWrite))
+	assert.False(t, HasScope(ctx, ScopeAdmin))
+	assert.False(t, HasScope(ctx, "unknown"))
+	assert.False(t, HasScope(context.Background(), ScopeRead))
+}
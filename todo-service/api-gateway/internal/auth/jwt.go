@@ -0,0 +1,162 @@
+// Package auth verifies the JSON Web Tokens clients send to the gateway and
+// issues tokens to users who log in.
+package auth
+
+import (
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrNoKeys is returned by NewVerifier when no key is configured, as every
+// token would then be refused.
+var ErrNoKeys = errors.New("no JWT secret, public key or JWKS file configured")
+
+// ErrIssuingDisabled is returned by Issue when no HS256 secret is configured.
+var ErrIssuingDisabled = errors.New("token issuing needs a JWT secret")
+
+// leeway is the clock skew allowed when checking exp, nbf and iat.
+const leeway = 30 * time.Second
+
+// Config holds the keys and claims tokens are checked against.
+type Config struct {
+	// Secret is the HS256 key. Tokens the gateway issues are signed with it.
+	Secret string
+	// PublicKeyFile is a PEM file holding an RS256 public key.
+	PublicKeyFile string
+	// JWKSFile is a local JSON Web Key Set holding RS256 public keys.
+	JWKSFile string
+	// Issuer and Audience, when set, must match the iss and aud claims.
+	Issuer   string
+	Audience string
+	// TokenTTL is how long issued tokens are valid.
+	TokenTTL time.Duration
+}
+
+// Verifier checks HS256 and RS256 tokens and issues HS256 ones.
+type Verifier struct {
+	secret   []byte
+	rsaKeys  map[string]*rsa.PublicKey // by key ID; the PEM key has none
+	issuer   string
+	audience string
+	ttl      time.Duration
+	parser   *jwt.Parser
+}
+
+// NewVerifier loads the keys named by cfg.
+func NewVerifier(cfg Config) (*Verifier, error) {
+	v := &Verifier{
+		rsaKeys:  make(map[string]*rsa.PublicKey),
+		issuer:   cfg.Issuer,
+		audience: cfg.Audience,
+		ttl:      cfg.TokenTTL,
+	}
+	if cfg.Secret != "" {
+		v.secret = []byte(cfg.Secret)
+	}
+	if cfg.PublicKeyFile != "" {
+		data, err := os.ReadFile(cfg.PublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWT public key: %w", err)
+		}
+		key, err := jwt.ParseRSAPublicKeyFromPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JWT public key %s: %w", cfg.PublicKeyFile, err)
+		}
+		v.rsaKeys[""] = key
+	}
+	if cfg.JWKSFile != "" {
+		keys, err := loadJWKS(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		for kid, key := range keys {
+			v.rsaKeys[kid] = key
+		}
+	}
+	if v.secret == nil && len(v.rsaKeys) == 0 {
+		return nil, ErrNoKeys
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(leeway),
+	}
+	if v.issuer != "" {
+		opts = append(opts, jwt.WithIssuer(v.issuer))
+	}
+	if v.audience != "" {
+		opts = append(opts, jwt.WithAudience(v.audience))
+	}
+	v.parser = jwt.NewParser(opts...)
+	return v, nil
+}
+
+// Verify checks the signature and claims of token and returns its subject.
+func (v *Verifier) Verify(token string) (string, error) {
+	var claims jwt.RegisteredClaims
+	if _, err := v.parser.ParseWithClaims(token, &claims, v.key); err != nil {
+		return "", err
+	}
+	if claims.Subject == "" {
+		return "", errors.New("token has no subject")
+	}
+	return claims.Subject, nil
+}
+
+// key returns the key token must be signed with.
+func (v *Verifier) key(token *jwt.Token) (any, error) {
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		if v.secret == nil {
+			return nil, errors.New("HS256 tokens are not accepted")
+		}
+		return v.secret, nil
+	case jwt.SigningMethodRS256.Alg():
+		kid, _ := token.Header["kid"].(string)
+		if key, ok := v.rsaKeys[kid]; ok {
+			return key, nil
+		}
+		// A token without a key ID is checked against the only key there is.
+		if kid == "" && len(v.rsaKeys) == 1 {
+			for _, key := range v.rsaKeys {
+				return key, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown key ID %q", kid)
+	}
+	return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+}
+
+// Issue returns an HS256 token for subject, valid for the configured TTL from
+// now, and its expiry.
+func (v *Verifier) Issue(subject string, now time.Time) (string, time.Time, error) {
+	if v.secret == nil {
+		return "", time.Time{}, ErrIssuingDisabled
+	}
+	expires := now.Add(v.ttl)
+	claims := jwt.RegisteredClaims{
+		Subject:   subject,
+		Issuer:    v.issuer,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expires),
+	}
+	if v.audience != "" {
+		claims.Audience = jwt.ClaimStrings{v.audience}
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(v.secret)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign token: %w", err)
+	}
+	return token, expires, nil
+}
+
+// CanIssue reports whether Issue can sign tokens.
+func (v *Verifier) CanIssue() bool {
+	return v.secret != nil
+}
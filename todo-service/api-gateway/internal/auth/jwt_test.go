@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims jwt.RegisteredClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func writeFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func validClaims() jwt.RegisteredClaims {
+	return jwt.RegisteredClaims{
+		Subject:   "user-1",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+	}
+}
+
+func TestVerifier_HS256RoundTrip(t *testing.T) {
+	v, err := NewVerifier(Config{Secret: "s3cret", Issuer: "todo", TokenTTL: time.Hour})
+	require.NoError(t, err)
+
+	token, expires, err := v.Issue("user-1", time.Now())
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expires, time.Second)
+
+	subject, err := v.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", subject)
+
+	other, err := NewVerifier(Config{Secret: "other", Issuer: "todo"})
+	require.NoError(t, err)
+	_, err = other.Verify(token)
+	assert.Error(t, err, "wrong secret")
+}
+
+func TestVerifier_RejectsBadTokens(t *testing.T) {
+	v, err := NewVerifier(Config{Secret: "s3cret", Issuer: "todo"})
+	require.NoError(t, err)
+
+	sign := func(method jwt.SigningMethod, key any, claims jwt.RegisteredClaims) string {
+		signed, err := jwt.NewWithClaims(method, claims).SignedString(key)
+		require.NoError(t, err)
+		return signed
+	}
+	expired := validClaims()
+	expired.Issuer = "todo"
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+	noExpiry := jwt.RegisteredClaims{Subject: "user-1", Issuer: "todo"}
+	wrongIssuer := validClaims()
+	wrongIssuer.Issuer = "someone-else"
+	noSubject := validClaims()
+	noSubject.Issuer, noSubject.Subject = "todo", ""
+
+	for name, token := range map[string]string{
+		"garbage":      "not.a.token",
+		"expired":      sign(jwt.SigningMethodHS256, []byte("s3cret"), expired),
+		"no expiry":    sign(jwt.SigningMethodHS256, []byte("s3cret"), noExpiry),
+		"wrong issuer": sign(jwt.SigningMethodHS256, []byte("s3cret"), wrongIssuer),
+		"no subject":   sign(jwt.SigningMethodHS256, []byte("s3cret"), noSubject),
+		"alg none":     sign(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, validClaims()),
+		"HS512":        sign(jwt.SigningMethodHS512, []byte("s3cret"), validClaims()),
+	} {
+		_, err := v.Verify(token)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestVerifier_RS256FromJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	jwks, err := json.Marshal(map[string]any{"keys": []map[string]string{
+		{"kty": "EC", "kid": "ec-key", "crv": "P-256"},
+		{
+			"kty": "RSA", "kid": "key-1", "use": "sig", "alg": "RS256",
+			"n": base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		},
+	}})
+	require.NoError(t, err)
+
+	v, err := NewVerifier(Config{JWKSFile: writeFile(t, "jwks.json", jwks)})
+	require.NoError(t, err)
+	assert.False(t, v.CanIssue())
+
+	subject, err := v.Verify(signRS256(t, key, "key-1", validClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", subject)
+
+	_, err = v.Verify(signRS256(t, key, "key-2", validClaims()))
+	assert.Error(t, err, "unknown key ID")
+
+	// Without a secret, HS256 tokens are refused even when signed with the
+	// RSA modulus as the HMAC key.
+	hs, err := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims()).SignedString(key.N.Bytes())
+	require.NoError(t, err)
+	_, err = v.Verify(hs)
+	assert.Error(t, err)
+}
+
+func TestVerifier_RS256FromPEM(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	path := writeFile(t, "key.pem", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	v, err := NewVerifier(Config{PublicKeyFile: path})
+	require.NoError(t, err)
+
+	subject, err := v.Verify(signRS256(t, key, "", validClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", subject)
+}
+
+func TestNewVerifier_NoKeys(t *testing.T) {
+	_, err := NewVerifier(Config{})
+	assert.ErrorIs(t, err, ErrNoKeys)
+
+	_, err = NewVerifier(Config{JWKSFile: writeFile(t, "jwks.json", []byte(`{"keys": []}`))})
+	assert.Error(t, err)
+}
@@ -0,0 +1,38 @@
+package auth
+
+import "context"
+
+// Scopes limit what a request may do. Each scope includes the ones before it:
+// write also allows reading, and admin also allows writing and managing API
+// tokens. Users signed in with a JWT hold every scope.
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+	ScopeAdmin = "admin"
+)
+
+// AllScopes are the scopes of JWT-authenticated requests.
+var AllScopes = []string{ScopeRead, ScopeWrite, ScopeAdmin}
+
+var scopeRanks = map[string]int{ScopeRead: 1, ScopeWrite: 2, ScopeAdmin: 3}
+
+type scopesKey struct{}
+
+// WithScopes returns a copy of ctx carrying the scopes the request was
+// granted.
+func WithScopes(ctx context.Context, scopes []string) context.Context {
+	return context.WithValue(ctx, scopesKey{}, scopes)
+}
+
+// HasScope reports whether the scopes ctx carries include scope. A context
+// without scopes has none.
+func HasScope(ctx context.Context, scope string) bool {
+	need := scopeRanks[scope]
+	granted, _ := ctx.Value(scopesKey{}).([]string)
+	for _, g := range granted {
+		if need > 0 && scopeRanks[g] >= need {
+			return true
+		}
+	}
+	return false
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"log"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -10,6 +11,35 @@ import (
 type Config struct {
 	Port     string
 	GRPCHost string
+
+	// JWT verification. At least one of JWTSecret (HS256), JWTPublicKeyFile
+	// (an RS256 PEM key) and JWKSFile (a local RS256 key set) must be set.
+	JWTSecret        string
+	JWTPublicKeyFile string
+	JWKSFile         string
+	JWTIssuer        string
+	JWTAudience      string
+	JWTTokenTTL      time.Duration // lifetime of the HS256 tokens issued on login
+
+	// TLS to the storage service, used when any of these is set.
+	// GRPCTLSCertFile and GRPCTLSKeyFile are the client certificate for
+	// mutual TLS. The files are reloaded when they change.
+	GRPCTLSCAFile     string
+	GRPCTLSCertFile   string
+	GRPCTLSKeyFile    string
+	GRPCTLSServerName string
+
+	// GatewaySecret is sent with every call to the storage service, which
+	// only believes the forwarded user ID from callers with this secret or
+	// a client certificate (mutual TLS).
+	GatewaySecret string
+
+	// TracesExporter is where OpenTelemetry spans go: "otlp", "stdout",
+	// "file" (appending to TracesFile) or "none". The OTLP endpoint is set
+	// with the standard OTEL_EXPORTER_OTLP_* variables.
+	TracesExporter string
+	TracesFile     string
+	ServiceName    string
 }
 
 func LoadConfig() *Config {
@@ -22,6 +52,24 @@ func LoadConfig() *Config {
 	return &Config{
 		Port:     getEnv("PORT", "8383"),
 		GRPCHost: getEnv("GRPC_HOST", "localhost:50051"),
+
+		JWTSecret:        getEnv("JWT_SECRET", ""),
+		JWTPublicKeyFile: getEnv("JWT_PUBLIC_KEY_FILE", ""),
+		JWKSFile:         getEnv("JWT_JWKS_FILE", ""),
+		JWTIssuer:        getEnv("JWT_ISSUER", ""),
+		JWTAudience:      getEnv("JWT_AUDIENCE", ""),
+		JWTTokenTTL:      getEnvDuration("JWT_TOKEN_TTL", time.Hour),
+
+		GRPCTLSCAFile:     getEnv("GRPC_TLS_CA_FILE", ""),
+		GRPCTLSCertFile:   getEnv("GRPC_TLS_CERT_FILE", ""),
+		GRPCTLSKeyFile:    getEnv("GRPC_TLS_KEY_FILE", ""),
+		GRPCTLSServerName: getEnv("GRPC_TLS_SERVER_NAME", ""),
+
+		GatewaySecret: getEnv("GATEWAY_SECRET", ""),
+
+		TracesExporter: getEnv("TRACES_EXPORTER", "none"),
+		TracesFile:     getEnv("TRACES_FILE", "traces.json"),
+		ServiceName:    getEnv("OTEL_SERVICE_NAME", "api-gateway"),
 	}
 }
 
@@ -31,3 +79,16 @@ func getEnv(key string, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Invalid duration %q for %s, using default %s", value, key, defaultValue)
+		return defaultValue
+	}
+	return d
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/sahidhossen/todo/api-gateway/internal/httputil"
+	pb "github.com/sahidhossen/todo/proto/task_service"
+)
+
+// apiTokenRequest is the JSON body of POST /tokens.
+type apiTokenRequest struct {
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	ExpiresAt string   `json:"expires_at"` // optional RFC 3339 timestamp or YYYY-MM-DD date
+}
+
+// CreateApiToken handles creating a personal API token. The response carries
+// the token's secret, which is shown only this once.
+func (h *Handler) CreateApiToken(w http.ResponseWriter, r *http.Request) {
+	var body apiTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		httputil.HandleError(w, r, h.logger, err, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(body.Name) == "" {
+		httputil.HandleError(w, r, h.logger, nil, "Name cannot be empty", http.StatusBadRequest)
+		return
+	}
+	if len(body.Scopes) == 0 {
+		httputil.HandleError(w, r, h.logger, nil, "At least one scope is required", http.StatusBadRequest)
+		return
+	}
+	req := &pb.CreateApiTokenRequest{Name: body.Name, Scopes: body.Scopes}
+	if body.ExpiresAt != "" {
+		expiresAt, err := parseTime("expires_at", body.ExpiresAt)
+		if err != nil {
+			httputil.HandleError(w, r, h.logger, err, err.Error(), http.StatusBadRequest)
+			return
+		}
+		req.ExpiresAt = expiresAt
+	}
+
+	ctx, cancel := httputil.WithTimeout(r)
+	defer cancel()
+
+	resp, err := h.taskClient.CreateApiToken(ctx, req)
+	if err != nil {
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to create API token")
+		return
+	}
+
+	httputil.HandleSuccess(w, r, h.logger, resp, http.StatusCreated)
+	h.logger.InfoContext(r.Context(), "API token created via API", "id", resp.ApiToken.GetId(), "scopes", resp.ApiToken.GetScopes())
+}
+
+// ListApiTokens handles listing the caller's API tokens, without their secrets.
+func (h *Handler) ListApiTokens(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := httputil.WithTimeout(r)
+	defer cancel()
+
+	tokens, err := h.taskClient.ListApiTokens(ctx)
+	if err != nil {
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to retrieve API tokens")
+		return
+	}
+	if tokens == nil {
+		tokens = []*pb.ApiToken{}
+	}
+
+	httputil.HandleSuccess(w, r, h.logger, tokens, http.StatusOK)
+	h.logger.InfoContext(r.Context(), "Listed API tokens via API", "count", len(tokens))
+}
+
+// RevokeApiToken handles revoking one of the caller's API tokens.
+func (h *Handler) RevokeApiToken(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	ctx, cancel := httputil.WithTimeout(r)
+	defer cancel()
+
+	if err := h.taskClient.RevokeApiToken(ctx, id); err != nil {
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to revoke API token")
+		return
+	}
+
+	httputil.HandleNoContent(w, r, h.logger)
+	h.logger.InfoContext(r.Context(), "API token revoked via API", "id", id)
+}
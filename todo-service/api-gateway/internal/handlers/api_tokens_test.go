@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/sahidhossen/todo/api-gateway/internal/auth"
+	"github.com/sahidhossen/todo/api-gateway/mocks"
+	pb "github.com/sahidhossen/todo/proto/task_service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateApiToken_Success(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	mockTaskClient.On("CreateApiToken", mock.AnythingOfType("*context.timerCtx"), mock.MatchedBy(func(req *pb.CreateApiTokenRequest) bool {
+		return req.Name == "CI" && len(req.Scopes) == 1 && req.ExpiresAt.AsTime().Format("2006-01-02") == "2030-01-01"
+	})).Return(&pb.CreateApiTokenResponse{ApiToken: &pb.ApiToken{Id: "token1", Name: "CI"}, Token: "todo_pat_secret"}, nil).Once()
+
+	req := newTestRequest(http.MethodPost, "/tokens", apiTokenRequest{Name: "CI", Scopes: []string{"read"}, ExpiresAt: "2030-01-01"})
+	rr := httptest.NewRecorder()
+
+	handler.CreateApiToken(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var resp pb.CreateApiTokenResponse
+	assert.NoError(t, decodeResponse(rr, &resp))
+	assert.Equal(t, "todo_pat_secret", resp.Token)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestCreateApiToken_InvalidBody(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	for name, body := range map[string]apiTokenRequest{
+		"no name":    {Scopes: []string{"read"}},
+		"no scopes":  {Name: "CI"},
+		"bad expiry": {Name: "CI", Scopes: []string{"read"}, ExpiresAt: "soon"},
+	} {
+		rr := httptest.NewRecorder()
+		handler.CreateApiToken(rr, newTestRequest(http.MethodPost, "/tokens", body))
+		assert.Equal(t, http.StatusBadRequest, rr.Code, name)
+	}
+	mockTaskClient.AssertNotCalled(t, "CreateApiToken", mock.Anything, mock.Anything)
+}
+
+func TestRegisterRoutes_EnforcesScopes(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+	router := mux.NewRouter()
+	handler.RegisterRoutes(router)
+
+	mockTaskClient.On("ListLabels", mock.Anything).Return([]*pb.Label{}, nil)
+	mockTaskClient.On("DeleteLabel", mock.Anything, "label1").Return(nil)
+	mockTaskClient.On("ListApiTokens", mock.Anything).Return([]*pb.ApiToken{}, nil)
+
+	for _, tc := range []struct {
+		method, path string
+		scopes       []string
+		want         int
+	}{
+		{http.MethodGet, "/labels", nil, http.StatusForbidden},
+		{http.MethodGet, "/labels", []string{auth.ScopeRead}, http.StatusOK},
+		{http.MethodDelete, "/labels/label1", []string{auth.ScopeRead}, http.StatusForbidden},
+		{http.MethodDelete, "/labels/label1", []string{auth.ScopeWrite}, http.StatusNoContent},
+		{http.MethodGet, "/labels", []string{auth.ScopeWrite}, http.StatusOK},
+		{http.MethodGet, "/tokens", []string{auth.ScopeWrite}, http.StatusForbidden},
+		{http.MethodGet, "/tokens", []string{auth.ScopeAdmin}, http.StatusOK},
+	} {
+		req := newTestRequest(tc.method, tc.path, nil)
+		req = req.WithContext(auth.WithScopes(req.Context(), tc.scopes))
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, tc.want, rr.Code, "%s %s with %v", tc.method, tc.path, tc.scopes)
+	}
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sahidhossen/todo/api-gateway/internal/httputil"
+	pb "github.com/sahidhossen/todo/proto/task_service"
+)
+
+// credentialsRequest is the JSON body of POST /auth/register and /auth/login.
+type credentialsRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// loginResponse is the JSON body of a successful POST /auth/login. Token is
+// left out when the gateway does not issue tokens.
+type loginResponse struct {
+	User      *pb.User   `json:"user"`
+	Token     string     `json:"token,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// decodeCredentials reads the credentials in the request body, replying with
+// 400 and returning false when they are missing.
+func (h *Handler) decodeCredentials(w http.ResponseWriter, r *http.Request) (credentialsRequest, bool) {
+	var req credentialsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.HandleError(w, r, h.logger, err, "Invalid request body", http.StatusBadRequest)
+		return req, false
+	}
+	if strings.TrimSpace(req.Email) == "" || req.Password == "" {
+		httputil.HandleError(w, r, h.logger, nil, "Email and password are required", http.StatusBadRequest)
+		return req, false
+	}
+	return req, true
+}
+
+// Register handles creating a user account.
+func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
+	req, ok := h.decodeCredentials(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := httputil.WithTimeout(r)
+	defer cancel()
+
+	user, err := h.taskClient.Register(ctx, req.Email, req.Password)
+	if err != nil {
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to register user")
+		return
+	}
+
+	httputil.HandleSuccess(w, r, h.logger, user, http.StatusCreated)
+	h.logger.InfoContext(r.Context(), "User registered via API", "id", user.Id)
+}
+
+// Login handles checking a user's email and password, and returns an access
+// token for the user when token issuing is enabled.
+func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
+	req, ok := h.decodeCredentials(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := httputil.WithTimeout(r)
+	defer cancel()
+
+	user, err := h.taskClient.Login(ctx, req.Email, req.Password)
+	if err != nil {
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to log in")
+		return
+	}
+
+	resp := loginResponse{User: user}
+	if h.tokens != nil {
+		token, expires, err := h.tokens.Issue(user.Id, time.Now())
+		if err != nil {
+			httputil.HandleError(w, r, h.logger, err, "Failed to issue access token", http.StatusInternalServerError)
+			return
+		}
+		resp.Token, resp.ExpiresAt = token, &expires
+	}
+
+	httputil.HandleSuccess(w, r, h.logger, resp, http.StatusOK)
+	h.logger.InfoContext(r.Context(), "User logged in via API", "id", user.Id)
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/sahidhossen/todo/api-gateway/mocks"
+	pb "github.com/sahidhossen/todo/proto/task_service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRegister_Success(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	mockTaskClient.On("Register", mock.AnythingOfType("*context.timerCtx"), "ada@example.com", "correct horse").
+		Return(&pb.User{Id: "user-1", Email: "ada@example.com"}, nil).Once()
+
+	req := newTestRequest(http.MethodPost, "/auth/register", credentialsRequest{Email: "ada@example.com", Password: "correct horse"})
+	rr := httptest.NewRecorder()
+
+	handler.Register(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var user pb.User
+	assert.NoError(t, decodeResponse(rr, &user))
+	assert.Equal(t, "user-1", user.Id)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestRegister_MissingPassword(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	req := newTestRequest(http.MethodPost, "/auth/register", credentialsRequest{Email: "ada@example.com"})
+	rr := httptest.NewRecorder()
+
+	handler.Register(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockTaskClient.AssertNotCalled(t, "Register", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLogin_InvalidCredentials(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	mockTaskClient.On("Login", mock.AnythingOfType("*context.timerCtx"), "ada@example.com", "wrong horse").
+		Return(nil, status.Error(codes.Unauthenticated, "invalid email or password")).Once()
+
+	req := newTestRequest(http.MethodPost, "/auth/login", credentialsRequest{Email: "ada@example.com", Password: "wrong horse"})
+	rr := httptest.NewRecorder()
+
+	handler.Login(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	mockTaskClient.AssertExpectations(t)
+}
+
+// fakeIssuer issues the token "token-for-<subject>".
+type fakeIssuer struct{}
+
+func (fakeIssuer) Issue(subject string, now time.Time) (string, time.Time, error) {
+	return "token-for-" + subject, now.Add(time.Hour), nil
+}
+
+func TestLogin_IssuesToken(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := New(mockTaskClient, slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))).
+		WithTokenIssuer(fakeIssuer{})
+
+	mockTaskClient.On("Login", mock.AnythingOfType("*context.timerCtx"), "ada@example.com", "correct horse").
+		Return(&pb.User{Id: "user-1", Email: "ada@example.com"}, nil).Once()
+
+	req := newTestRequest(http.MethodPost, "/auth/login", credentialsRequest{Email: "ada@example.com", Password: "correct horse"})
+	rr := httptest.NewRecorder()
+
+	handler.Login(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var resp struct {
+		User      pb.User   `json:"user"`
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	assert.NoError(t, decodeResponse(rr, &resp))
+	assert.Equal(t, "user-1", resp.User.Id)
+	assert.Equal(t, "token-for-user-1", resp.Token)
+	assert.False(t, resp.ExpiresAt.IsZero())
+	mockTaskClient.AssertExpectations(t)
+}
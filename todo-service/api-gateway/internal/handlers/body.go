@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/sahidhossen/todo/api-gateway/internal/httputil"
+	pb "github.com/sahidhossen/todo/proto/task_service"
+)
+
+// createTaskBody is the JSON body of task creation requests.
+type createTaskBody struct {
+	Title        string          `json:"title"`
+	Description  string          `json:"description"`
+	DueAt        string          `json:"due_at"`
+	AllDay       bool            `json:"all_day"`
+	Priority     json.RawMessage `json:"priority"`
+	ProjectID    string          `json:"project_id"`
+	ParentID     string          `json:"parent_id"`
+	AutoComplete bool            `json:"auto_complete"`
+	Recurrence   string          `json:"recurrence"`
+}
+
+// request validates the body and builds the CreateTaskRequest for it. A
+// non-empty parentID overrides the parent_id field.
+func (b *createTaskBody) request(parentID string) (*pb.CreateTaskRequest, error) {
+	if b.Title == "" {
+		return nil, httputil.InvalidField("title", "Title cannot be empty")
+	}
+
+	priority, err := parsePriority(b.Priority)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &pb.CreateTaskRequest{
+		Title:        b.Title,
+		Description:  b.Description,
+		AllDay:       b.AllDay,
+		Priority:     priority,
+		ProjectId:    b.ProjectID,
+		ParentId:     b.ParentID,
+		AutoComplete: b.AutoComplete,
+		Recurrence:   b.Recurrence,
+	}
+	if parentID != "" {
+		req.ParentId = parentID
+	}
+	if b.DueAt != "" {
+		dueAt, err := parseTime("due_at", b.DueAt)
+		if err != nil {
+			return nil, err
+		}
+		req.DueAt = dueAt
+	}
+	return req, nil
+}
+
+// updateTaskBody is the JSON body of partial task updates. Fields missing
+// from the body are left unchanged.
+type updateTaskBody struct {
+	Title       *string `json:"title"`
+	Description *string `json:"description"`
+	Completed   *bool   `json:"completed"`
+	// DueAt distinguishes a missing field (nil) from null, which clears the due date.
+	DueAt  json.RawMessage `json:"due_at"`
+	AllDay *bool           `json:"all_day"`
+	// Priority is a number or a name; see parsePriority.
+	Priority json.RawMessage `json:"priority"`
+	// ProjectID moves the task to another project; an empty string removes it from its project.
+	ProjectID *string `json:"project_id"`
+	// ParentID moves the task under another task; an empty string makes it a top-level task.
+	ParentID     *string `json:"parent_id"`
+	AutoComplete *bool   `json:"auto_complete"`
+	// Recurrence changes the rule of this occurrence only; an empty string stops it recurring.
+	Recurrence *string `json:"recurrence"`
+}
+
+// changes validates the body and returns the new values of task id together
+// with the update mask paths of the fields present in the body.
+func (b *updateTaskBody) changes(id string) (*pb.Task, []string, error) {
+	task := &pb.Task{Id: id}
+	var paths []string
+	if b.Title != nil {
+		if *b.Title == "" {
+			return nil, nil, httputil.InvalidField("title", "Title cannot be empty")
+		}
+		task.Title = *b.Title
+		paths = append(paths, "title")
+	}
+	if b.Description != nil {
+		task.Description = *b.Description
+		paths = append(paths, "description")
+	}
+	if b.Completed != nil {
+		task.Completed = *b.Completed
+		paths = append(paths, "completed")
+	}
+	if b.DueAt != nil {
+		var dueAt *string
+		if err := json.Unmarshal(b.DueAt, &dueAt); err != nil {
+			return nil, nil, httputil.InvalidField("due_at", "due_at must be a string or null")
+		}
+		if dueAt != nil {
+			ts, err := parseTime("due_at", *dueAt)
+			if err != nil {
+				return nil, nil, err
+			}
+			task.DueAt = ts
+		}
+		paths = append(paths, "due_at")
+	}
+	if b.AllDay != nil {
+		task.AllDay = *b.AllDay
+		paths = append(paths, "all_day")
+	}
+	if b.Priority != nil {
+		priority, err := parsePriority(b.Priority)
+		if err != nil {
+			return nil, nil, err
+		}
+		task.Priority = priority
+		paths = append(paths, "priority")
+	}
+	if b.ProjectID != nil {
+		task.ProjectId = *b.ProjectID
+		paths = append(paths, "project_id")
+	}
+	if b.ParentID != nil {
+		task.ParentId = *b.ParentID
+		paths = append(paths, "parent_id")
+	}
+	if b.AutoComplete != nil {
+		task.AutoComplete = *b.AutoComplete
+		paths = append(paths, "auto_complete")
+	}
+	if b.Recurrence != nil {
+		task.Recurrence = *b.Recurrence
+		paths = append(paths, "recurrence")
+	}
+	if len(paths) == 0 {
+		return nil, nil, errors.New("Request body must set at least one of title, description, completed, due_at, all_day, priority, project_id, parent_id, auto_complete or recurrence")
+	}
+	return task, paths, nil
+}
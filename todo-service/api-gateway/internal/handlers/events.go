@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/sahidhossen/todo/api-gateway/internal/httputil"
+	"github.com/sahidhossen/todo/api-gateway/internal/services"
+	pb "github.com/sahidhossen/todo/proto/task_service"
+)
+
+// defaultHeartbeat is how often an idle event stream gets a comment line, so
+// that proxies and clients do not take it for a dead connection.
+const defaultHeartbeat = 15 * time.Second
+
+// eventRetry is the reconnection delay suggested to EventSource clients.
+const eventRetry = 3 * time.Second
+
+// taskEvent is the data of a task event on the event stream.
+type taskEvent struct {
+	Task *pb.Task               `json:"task"`
+	Time *timestamppb.Timestamp `json:"time"`
+}
+
+// errResumeExpired reports that the events after Last-Event-ID are no longer available.
+var errResumeExpired = errors.New("resume point expired")
+
+// StreamEvents handles GET /events, relaying task events from the storage
+// service as Server-Sent Events. Each event has the task's new state as data,
+// its sequence number as id and created, updated, completed or deleted as
+// event name. Clients resume with the Last-Event-ID header, or the
+// last_event_id query parameter on first connect. When the events after it
+// are gone a reset event is sent: the client should reload its tasks, and
+// the stream carries on with new events.
+func (h *Handler) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+	var after uint64
+	if lastEventID != "" {
+		var err error
+		after, err = strconv.ParseUint(lastEventID, 10, 64)
+		if err != nil {
+			httputil.HandleError(w, r, h.logger, err, "Last-Event-ID must be an event sequence number", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if h.isClosing() {
+		httputil.HandleError(w, r, h.logger, nil, "Server is shutting down", http.StatusServiceUnavailable)
+		return
+	}
+
+	// The server's WriteTimeout would cut the stream off; lift it for this response.
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.WarnContext(r.Context(), "Failed to clear write deadline for event stream", "error", err)
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	stream, err := h.taskClient.WatchTasks(ctx, after)
+	if err != nil {
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to watch tasks")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", eventRetry.Milliseconds())
+	if err := rc.Flush(); err != nil {
+		h.logger.ErrorContext(r.Context(), "Event stream cannot be flushed", "error", err)
+		return
+	}
+	h.logger.InfoContext(r.Context(), "Event stream opened via API", "after_sequence", after)
+
+	err = h.relayEvents(ctx, w, rc, stream)
+	if errors.Is(err, errResumeExpired) {
+		// Start over from the live events; the client reloads on reset.
+		fmt.Fprint(w, "event: reset\ndata: {}\n\n")
+		stream, err = h.taskClient.WatchTasks(ctx, 0)
+		if err == nil {
+			err = h.relayEvents(ctx, w, rc, stream)
+		}
+	}
+	h.logger.InfoContext(r.Context(), "Event stream closed via API", "reason", err)
+}
+
+// relayEvents writes the events received from stream to w until the stream
+// fails, ctx is done, writing fails or the handler is closing.
+func (h *Handler) relayEvents(ctx context.Context, w http.ResponseWriter, rc *http.ResponseController, stream services.TaskEventStream) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events := make(chan *pb.TaskEvent)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	interval := h.heartbeat
+	if interval <= 0 {
+		interval = defaultHeartbeat
+	}
+	heartbeat := time.NewTicker(interval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event := <-events:
+			data, err := json.Marshal(taskEvent{Task: event.Task, Time: event.Time})
+			if err != nil {
+				return fmt.Errorf("encode event: %w", err)
+			}
+			fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Sequence, eventName(event.Type), data)
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		case err := <-recvErr:
+			if status.Code(err) == codes.OutOfRange {
+				return errResumeExpired
+			}
+			return err
+		case <-h.closing:
+			return errors.New("server shutting down")
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if err := rc.Flush(); err != nil {
+			return fmt.Errorf("flush: %w", err)
+		}
+	}
+}
+
+// eventName returns the name of an event type on the event stream and the
+// WebSocket: created, updated, completed or deleted.
+func eventName(typ pb.TaskEvent_Type) string {
+	return strings.ToLower(strings.TrimPrefix(typ.String(), "TYPE_"))
+}
+
+// CloseStreams ends open event streams and WebSocket connections, which
+// would otherwise keep the server's graceful shutdown waiting, and waits for
+// the WebSocket connections to be torn down or ctx to be done. Clients
+// reconnect on their own. New streams are refused once it has been called.
+func (h *Handler) CloseStreams(ctx context.Context) error {
+	h.closeOnce.Do(func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if h.closing != nil {
+			close(h.closing)
+		}
+	})
+
+	done := make(chan struct{})
+	go func() {
+		h.sockets.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// isClosing reports whether CloseStreams has been called.
+func (h *Handler) isClosing() bool {
+	select {
+	case <-h.closing:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sahidhossen/todo/api-gateway/mocks"
+	pb "github.com/sahidhossen/todo/proto/task_service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeEventStream is a WatchTasks stream that returns the queued events and
+// then err, or blocks until closed when err is nil.
+type fakeEventStream struct {
+	grpc.ClientStream
+	events []*pb.TaskEvent
+	err    error
+	done   chan struct{}
+}
+
+func newFakeEventStream(err error, events ...*pb.TaskEvent) *fakeEventStream {
+	return &fakeEventStream{events: events, err: err, done: make(chan struct{})}
+}
+
+func (s *fakeEventStream) Recv() (*pb.TaskEvent, error) {
+	if len(s.events) > 0 {
+		event := s.events[0]
+		s.events = s.events[1:]
+		return event, nil
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	<-s.done
+	return nil, io.EOF
+}
+
+func newEventHandler(mockTaskClient *mocks.MockTaskService) *Handler {
+	return New(mockTaskClient, slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})))
+}
+
+// readEventLines reads lines from body until n non-empty lines were read.
+func readEventLines(t *testing.T, body *bufio.Reader, n int) []string {
+	t.Helper()
+	var lines []string
+	for len(lines) < n {
+		line, err := body.ReadString('\n')
+		require.NoError(t, err)
+		if line = strings.TrimSuffix(line, "\n"); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func TestStreamEvents_RelaysFromLastEventID(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := newEventHandler(mockTaskClient)
+
+	stream := newFakeEventStream(nil, &pb.TaskEvent{Sequence: 43, Type: pb.TaskEvent_TYPE_COMPLETED, Task: &pb.Task{Id: "task1", Completed: true}})
+	defer close(stream.done)
+	mockTaskClient.On("WatchTasks", mock.Anything, uint64(42)).Return(stream, nil).Once()
+
+	srv := httptest.NewServer(http.HandlerFunc(handler.StreamEvents))
+	defer srv.Close()
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	req.Header.Set("Last-Event-ID", "42")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	lines := readEventLines(t, bufio.NewReader(resp.Body), 4)
+	assert.Equal(t, "retry: 3000", lines[0])
+	assert.Equal(t, "id: 43", lines[1])
+	assert.Equal(t, "event: completed", lines[2])
+	assert.Contains(t, lines[3], `"id":"task1"`)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestStreamEvents_ResetsExpiredResume(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := newEventHandler(mockTaskClient)
+
+	live := newFakeEventStream(nil, &pb.TaskEvent{Sequence: 900, Type: pb.TaskEvent_TYPE_CREATED, Task: &pb.Task{Id: "task2"}})
+	defer close(live.done)
+	mockTaskClient.On("WatchTasks", mock.Anything, uint64(7)).
+		Return(newFakeEventStream(status.Error(codes.OutOfRange, "expired")), nil).Once()
+	mockTaskClient.On("WatchTasks", mock.Anything, uint64(0)).Return(live, nil).Once()
+
+	srv := httptest.NewServer(http.HandlerFunc(handler.StreamEvents))
+	defer srv.Close()
+	resp, err := http.Get(srv.URL + "?last_event_id=7")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	lines := readEventLines(t, bufio.NewReader(resp.Body), 6)
+	assert.Equal(t, "event: reset", lines[1])
+	assert.Equal(t, "id: 900", lines[3])
+	assert.Equal(t, "event: created", lines[4])
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestStreamEvents_InvalidLastEventID(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := newEventHandler(mockTaskClient)
+
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	req.Header.Set("Last-Event-ID", "abc")
+	rr := httptest.NewRecorder()
+
+	handler.StreamEvents(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockTaskClient.AssertNotCalled(t, "WatchTasks", mock.Anything, mock.Anything)
+}
+
+func TestStreamEvents_HeartbeatAndClose(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := newEventHandler(mockTaskClient)
+	handler.heartbeat = 10 * time.Millisecond
+
+	stream := newFakeEventStream(nil)
+	defer close(stream.done)
+	mockTaskClient.On("WatchTasks", mock.Anything, uint64(0)).Return(stream, nil).Once()
+
+	srv := httptest.NewServer(http.HandlerFunc(handler.StreamEvents))
+	defer srv.Close()
+	resp, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body := bufio.NewReader(resp.Body)
+	lines := readEventLines(t, body, 2)
+	assert.Equal(t, ": heartbeat", lines[1])
+
+	assert.NoError(t, handler.CloseStreams(context.Background()))
+	assert.NoError(t, handler.CloseStreams(context.Background()), "closing twice is harmless")
+	_, err = io.ReadAll(body)
+	assert.NoError(t, err, "the stream ends cleanly")
+}
@@ -2,19 +2,35 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/gorilla/mux"
 
+	"github.com/sahidhossen/todo/api-gateway/internal/auth"
 	"github.com/sahidhossen/todo/api-gateway/internal/httputil"
 	"github.com/sahidhossen/todo/api-gateway/internal/services"
+	pb "github.com/sahidhossen/todo/proto/task_service"
 )
 
 // Handler manages HTTP requests by interacting with the gRPC task service.
 type Handler struct {
 	taskClient services.TaskService
 	logger     *slog.Logger
+	tokens     TokenIssuer // signs the tokens Login returns; nil when issuing is disabled
+
+	heartbeat time.Duration // interval of event stream heartbeats, defaultHeartbeat when zero
+	readyTTL  time.Duration // how long Readyz reuses a storage health check, defaultReadyTTL when zero
+	readiness readiness
+	draining  atomic.Bool   // set by Drain
+	closing   chan struct{} // closed by CloseStreams
+	closeOnce sync.Once
+	mu        sync.Mutex     // guards adding to sockets against CloseStreams
+	sockets   sync.WaitGroup // open WebSocket connections
 }
 
 // New creates a new Handler.
@@ -25,78 +41,211 @@ func New(taskClient services.TaskService, logger *slog.Logger) *Handler {
 	return &Handler{
 		taskClient: taskClient,
 		logger:     logger,
+		closing:    make(chan struct{}),
 	}
 }
 
-// RegisterRoutes sets up all the API routes for the application.
+// TokenIssuer signs access tokens for users who log in.
+type TokenIssuer interface {
+	Issue(subject string, now time.Time) (string, time.Time, error)
+}
+
+// WithTokenIssuer makes Login return an access token signed by issuer.
+func (h *Handler) WithTokenIssuer(issuer TokenIssuer) *Handler {
+	h.tokens = issuer
+	return h
+}
+
+// PublicPaths are the routes served without authentication.
+var PublicPaths = []string{"/auth/register", "/auth/login", "/healthz", "/readyz"}
+
+// StreamPaths are the routes whose clients may pass their access token in the
+// access_token query parameter, as browsers cannot set headers on them.
+var StreamPaths = []string{"/events", "/ws"}
+
+// RegisterRoutes sets up all the API routes for the application. Apart from
+// PublicPaths, each route needs the scope it is wrapped in.
 func (h *Handler) RegisterRoutes(r *mux.Router) {
-	r.HandleFunc("/tasks", h.CreateTask).Methods("POST")
-	r.HandleFunc("/tasks", h.ListTasks).Methods("GET")
-	r.HandleFunc("/tasks/{id}", h.GetTask).Methods("GET")
-	r.HandleFunc("/tasks/{id}/toggle-task-complete", h.ToggleTaskCompletion).Methods("PATCH")
-	r.HandleFunc("/stats", h.GetTaskStats).Methods("GET")
+	read, write, admin := h.requireScope(auth.ScopeRead), h.requireScope(auth.ScopeWrite), h.requireScope(auth.ScopeAdmin)
+
+	r.HandleFunc("/auth/register", h.Register).Methods("POST")
+	r.HandleFunc("/auth/login", h.Login).Methods("POST")
+	r.HandleFunc("/healthz", h.Healthz).Methods("GET")
+	r.HandleFunc("/readyz", h.Readyz).Methods("GET")
+	r.Handle("/tasks", write(h.CreateTask)).Methods("POST")
+	r.Handle("/tasks", read(h.ListTasks)).Methods("GET")
+	r.Handle("/tasks/search", read(h.SearchTasks)).Methods("GET") // before /tasks/{id} so "search" is not taken as an ID
+	r.Handle("/tasks/{id}", read(h.GetTask)).Methods("GET")
+	r.Handle("/tasks/{id}", write(h.UpdateTask)).Methods("PATCH")
+	r.Handle("/tasks/{id}", write(h.DeleteTask)).Methods("DELETE")
+	r.Handle("/tasks/{id}/restore", write(h.RestoreTask)).Methods("POST")
+	r.Handle("/tasks/{id}/toggle-task-complete", write(h.ToggleTaskCompletion)).Methods("PATCH")
+	r.Handle("/tasks/{id}/complete", write(h.CompleteTask)).Methods("POST")
+	r.Handle("/tasks/{id}/reopen", write(h.ReopenTask)).Methods("POST")
+	r.Handle("/tasks/{id}/labels", write(h.AddTaskLabels)).Methods("POST")
+	r.Handle("/tasks/{id}/labels", write(h.RemoveTaskLabels)).Methods("DELETE")
+	r.Handle("/tasks/{id}/subtasks", write(h.CreateSubtask)).Methods("POST")
+	r.Handle("/tasks/{id}/subtasks", read(h.ListSubtasks)).Methods("GET")
+	r.Handle("/series/{id}", write(h.UpdateTaskSeries)).Methods("PATCH")
+	r.Handle("/trash", read(h.ListTrash)).Methods("GET")
+	r.Handle("/trash/{id}", write(h.PurgeTask)).Methods("DELETE")
+	r.Handle("/stats", read(h.GetTaskStats)).Methods("GET")
+	r.Handle("/events", read(h.StreamEvents)).Methods("GET")
+	r.Handle("/ws", read(h.ServeWebSocket)).Methods("GET")
+	r.Handle("/labels", write(h.CreateLabel)).Methods("POST")
+	r.Handle("/labels", read(h.ListLabels)).Methods("GET")
+	r.Handle("/labels/{id}", write(h.RenameLabel)).Methods("PATCH")
+	r.Handle("/labels/{id}", write(h.DeleteLabel)).Methods("DELETE")
+	r.Handle("/projects", write(h.CreateProject)).Methods("POST")
+	r.Handle("/projects", read(h.ListProjects)).Methods("GET")
+	r.Handle("/projects/{id}", read(h.GetProject)).Methods("GET")
+	r.Handle("/projects/{id}", write(h.UpdateProject)).Methods("PATCH")
+	r.Handle("/projects/{id}", write(h.DeleteProject)).Methods("DELETE")
+	r.Handle("/projects/{id}/tasks", read(h.ListProjectTasks)).Methods("GET")
+	r.Handle("/projects/{id}/stats", read(h.GetProjectStats)).Methods("GET")
+	r.Handle("/tokens", admin(h.CreateApiToken)).Methods("POST")
+	r.Handle("/tokens", admin(h.ListApiTokens)).Methods("GET")
+	r.Handle("/tokens/{id}", admin(h.RevokeApiToken)).Methods("DELETE")
+}
+
+// requireScope returns a wrapper refusing requests without scope with 403.
+func (h *Handler) requireScope(scope string) func(http.HandlerFunc) http.Handler {
+	return func(next http.HandlerFunc) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !auth.HasScope(r.Context(), scope) {
+				httputil.HandleError(w, r, h.logger, nil, fmt.Sprintf("This request needs the %s scope", scope), http.StatusForbidden)
+				return
+			}
+			next(w, r)
+		})
+	}
 }
 
 // CreateTask handles the creation of a new task.
 func (h *Handler) CreateTask(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		Title       string `json:"title"`
-		Description string `json:"description"`
-	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	h.createTask(w, r, "")
+}
+
+// createTask creates a task from the request body. A non-empty parentID
+// overrides the parent_id field of the body.
+func (h *Handler) createTask(w http.ResponseWriter, r *http.Request, parentID string) {
+	var body createTaskBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		httputil.HandleError(w, r, h.logger, err, "Invalid request body", http.StatusBadRequest)
 		return
 	}
-
-	if req.Title == "" {
-		httputil.HandleError(w, r, h.logger, nil, "Title cannot be empty", http.StatusBadRequest)
+	createReq, err := body.request(parentID)
+	if err != nil {
+		httputil.HandleError(w, r, h.logger, err, err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	ctx, cancel := httputil.WithTimeout(r)
 	defer cancel()
 
-	task, err := h.taskClient.CreateTask(ctx, req.Title, req.Description)
+	task, err := h.taskClient.CreateTask(ctx, createReq)
 	if err != nil {
 		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to create task")
 		return
 	}
 
 	httputil.HandleSuccess(w, r, h.logger, task, http.StatusCreated)
-	h.logger.Info("Task created via API", "id", task.Id, "title", task.Title)
+	h.logger.InfoContext(r.Context(), "Task created via API", "id", task.Id, "title", task.Title, "parent_id", task.ParentId)
 }
 
-// ListTasks handles listing all tasks.
+// ListTasks handles listing one page of tasks. Pagination, filters and sort
+// order are read from the query string.
 func (h *Handler) ListTasks(w http.ResponseWriter, r *http.Request) {
+	listReq, err := parseListTasksQuery(r.URL.Query())
+	if err != nil {
+		httputil.HandleError(w, r, h.logger, err, err.Error(), http.StatusBadRequest)
+		return
+	}
+	h.listTasks(w, r, listReq)
+}
+
+// listTasks writes one page of the tasks matching listReq.
+func (h *Handler) listTasks(w http.ResponseWriter, r *http.Request, listReq *pb.ListTasksRequest) {
 	ctx, cancel := httputil.WithTimeout(r)
 	defer cancel()
 
-	tasks, err := h.taskClient.ListTasks(ctx)
+	resp, err := h.taskClient.ListTasks(ctx, listReq)
 	if err != nil {
-		httputil.HandleError(w, r, h.logger, nil, "Failed to retrieve tasks", http.StatusInternalServerError)
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to retrieve tasks")
 		return
 	}
 
-	httputil.HandleSuccess(w, r, h.logger, tasks, http.StatusOK)
-	h.logger.Info("Listed tasks via API", "count", len(tasks))
+	page := listTasksResponse{
+		Tasks:         resp.Tasks,
+		NextPageToken: resp.NextPageToken,
+		TotalSize:     resp.TotalSize,
+	}
+	if page.Tasks == nil {
+		page.Tasks = []*pb.Task{}
+	}
+
+	httputil.HandleSuccess(w, r, h.logger, page, http.StatusOK)
+	h.logger.InfoContext(r.Context(), "Listed tasks via API", "count", len(page.Tasks), "total", page.TotalSize)
+}
+
+// SearchTasks handles full-text search over task titles and descriptions.
+// It follows the same pagination contract as ListTasks.
+func (h *Handler) SearchTasks(w http.ResponseWriter, r *http.Request) {
+	searchReq, err := parseSearchTasksQuery(r.URL.Query())
+	if err != nil {
+		httputil.HandleError(w, r, h.logger, err, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := httputil.WithTimeout(r)
+	defer cancel()
+
+	resp, err := h.taskClient.SearchTasks(ctx, searchReq)
+	if err != nil {
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to search tasks")
+		return
+	}
+
+	page := searchTasksResponse{
+		Results:       resp.Results,
+		NextPageToken: resp.NextPageToken,
+		TotalSize:     resp.TotalSize,
+	}
+	if page.Results == nil {
+		page.Results = []*pb.SearchResult{}
+	}
+
+	httputil.HandleSuccess(w, r, h.logger, page, http.StatusOK)
+	h.logger.InfoContext(r.Context(), "Searched tasks via API", "count", len(page.Results), "total", page.TotalSize)
 }
 
-// GetTask handles retrieving a single task by ID.
+// GetTask handles retrieving a single task by ID. With ?include_children=true
+// the task's subtasks are returned as a tree under children.
 func (h *Handler) GetTask(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
 
+	includeChildren, err := parseBool(r.URL.Query(), "include_children")
+	if err != nil {
+		httputil.HandleError(w, r, h.logger, err, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	ctx, cancel := httputil.WithTimeout(r)
 	defer cancel()
 
-	task, err := h.taskClient.GetTask(ctx, id)
+	getTask := h.taskClient.GetTask
+	if includeChildren {
+		getTask = h.taskClient.GetTaskTree
+	}
+	task, err := getTask(ctx, id)
 	if err != nil {
 		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to retrieve task")
 		return
 	}
 
 	httputil.HandleSuccess(w, r, h.logger, task, http.StatusOK)
-	h.logger.Info("Task retrieved via API", "id", task.Id)
+	h.logger.InfoContext(r.Context(), "Task retrieved via API", "id", task.Id)
 }
 
 // ToggleTaskCompletion handles marking a task as completed.
@@ -114,20 +263,167 @@ func (h *Handler) ToggleTaskCompletion(w http.ResponseWriter, r *http.Request) {
 	}
 
 	httputil.HandleSuccess(w, r, h.logger, task, http.StatusOK)
-	h.logger.Info("Task completed via API", "id", task.Id)
+	h.logger.InfoContext(r.Context(), "Task completed via API", "id", task.Id)
+}
+
+// CompleteTask handles marking a task as completed. Repeating the request is safe.
+func (h *Handler) CompleteTask(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	ctx, cancel := httputil.WithTimeout(r)
+	defer cancel()
+
+	task, err := h.taskClient.CompleteTask(ctx, id)
+	if err != nil {
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to complete task")
+		return
+	}
+
+	httputil.HandleSuccess(w, r, h.logger, task, http.StatusOK)
+	h.logger.InfoContext(r.Context(), "Task completed via API", "id", task.Id)
+}
+
+// ReopenTask handles marking a task as not completed. Repeating the request is safe.
+func (h *Handler) ReopenTask(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	ctx, cancel := httputil.WithTimeout(r)
+	defer cancel()
+
+	task, err := h.taskClient.ReopenTask(ctx, id)
+	if err != nil {
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to reopen task")
+		return
+	}
+
+	httputil.HandleSuccess(w, r, h.logger, task, http.StatusOK)
+	h.logger.InfoContext(r.Context(), "Task reopened via API", "id", task.Id)
 }
 
-// GetTaskStats handles retrieving task statistics.
+// UpdateTask handles partial updates of a task. Only the fields present in the
+// request body are sent in the update mask, so omitted fields keep their values.
+func (h *Handler) UpdateTask(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	var body updateTaskBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		httputil.HandleError(w, r, h.logger, err, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	task, paths, err := body.changes(id)
+	if err != nil {
+		httputil.HandleError(w, r, h.logger, err, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := httputil.WithTimeout(r)
+	defer cancel()
+
+	updated, err := h.taskClient.UpdateTask(ctx, task, paths)
+	if err != nil {
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to update task")
+		return
+	}
+
+	httputil.HandleSuccess(w, r, h.logger, updated, http.StatusOK)
+	h.logger.InfoContext(r.Context(), "Task updated via API", "id", updated.Id, "fields", paths)
+}
+
+// DeleteTask handles moving a task to the trash.
+func (h *Handler) DeleteTask(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	ctx, cancel := httputil.WithTimeout(r)
+	defer cancel()
+
+	task, err := h.taskClient.DeleteTask(ctx, id)
+	if err != nil {
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to delete task")
+		return
+	}
+
+	httputil.HandleSuccess(w, r, h.logger, task, http.StatusOK)
+	h.logger.InfoContext(r.Context(), "Task moved to trash via API", "id", task.Id)
+}
+
+// RestoreTask handles moving a task out of the trash.
+func (h *Handler) RestoreTask(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	ctx, cancel := httputil.WithTimeout(r)
+	defer cancel()
+
+	task, err := h.taskClient.RestoreTask(ctx, id)
+	if err != nil {
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to restore task")
+		return
+	}
+
+	httputil.HandleSuccess(w, r, h.logger, task, http.StatusOK)
+	h.logger.InfoContext(r.Context(), "Task restored via API", "id", task.Id)
+}
+
+// ListTrash handles listing the tasks in the trash.
+func (h *Handler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := httputil.WithTimeout(r)
+	defer cancel()
+
+	tasks, err := h.taskClient.ListTrash(ctx)
+	if err != nil {
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to retrieve trash")
+		return
+	}
+
+	httputil.HandleSuccess(w, r, h.logger, tasks, http.StatusOK)
+	h.logger.InfoContext(r.Context(), "Listed trash via API", "count", len(tasks))
+}
+
+// PurgeTask handles permanently removing a task from the trash.
+func (h *Handler) PurgeTask(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	ctx, cancel := httputil.WithTimeout(r)
+	defer cancel()
+
+	if err := h.taskClient.PurgeTask(ctx, id); err != nil {
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to purge task")
+		return
+	}
+
+	httputil.HandleNoContent(w, r, h.logger)
+	h.logger.InfoContext(r.Context(), "Task purged via API", "id", id)
+}
+
+// GetTaskStats handles retrieving task statistics, optionally for the project
+// named by the project_id query parameter. With ?leaves_only=true only tasks
+// without subtasks are counted.
 func (h *Handler) GetTaskStats(w http.ResponseWriter, r *http.Request) {
+	h.taskStats(w, r, r.URL.Query().Get("project_id"))
+}
+
+// taskStats writes the statistics of all tasks, or of one project's tasks when projectID is set.
+func (h *Handler) taskStats(w http.ResponseWriter, r *http.Request, projectID string) {
+	leavesOnly, err := parseBool(r.URL.Query(), "leaves_only")
+	if err != nil {
+		httputil.HandleError(w, r, h.logger, err, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	ctx, cancel := httputil.WithTimeout(r)
 	defer cancel()
 
-	stats, err := h.taskClient.GetTaskStats(ctx) // Call the gRPC client method
+	stats, err := h.taskClient.GetTaskStats(ctx, &pb.GetTaskStatsRequest{ProjectId: projectID, LeavesOnly: leavesOnly})
 	if err != nil {
 		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to retrieve task statistics")
 		return
 	}
 
 	httputil.HandleSuccess(w, r, h.logger, stats, http.StatusOK)
-	h.logger.Info("Task stats retrieved via API", "total", stats.TotalTasks, "completed", stats.CompletedTasks)
+	h.logger.InfoContext(r.Context(), "Task stats retrieved via API", "project_id", projectID, "total", stats.TotalTasks, "completed", stats.CompletedTasks)
 }
@@ -12,10 +12,16 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gorilla/mux"
+	"github.com/sahidhossen/todo/api-gateway/internal/auth"
+	"github.com/sahidhossen/todo/api-gateway/internal/httputil"
 	"github.com/sahidhossen/todo/api-gateway/mocks"
 	pb "github.com/sahidhossen/todo/proto/task_service"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func mockWithTimeout(r *http.Request) (context.Context, context.CancelFunc) {
@@ -59,7 +65,7 @@ func TestCreateTask_Success(t *testing.T) {
 	}
 	expectedTask := &pb.Task{Id: "task1", Title: "New Task", Description: "Task description", Completed: false}
 
-	mockTaskClient.On("CreateTask", mock.AnythingOfType("*context.timerCtx"), reqBody.Title, reqBody.Description).
+	mockTaskClient.On("CreateTask", mock.AnythingOfType("*context.timerCtx"), &pb.CreateTaskRequest{Title: reqBody.Title, Description: reqBody.Description}).
 		Return(expectedTask, nil).Once()
 
 	req := newTestRequest(http.MethodPost, "/tasks", reqBody)
@@ -80,3 +86,308 @@ func TestCreateTask_Success(t *testing.T) {
 
 	mockTaskClient.AssertExpectations(t) // Verify all mocked methods were called
 }
+
+func TestUpdateTask_SendsOnlyProvidedFields(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	expectedTask := &pb.Task{Id: "task1", Title: "Fixed title", Description: "Unchanged"}
+	mockTaskClient.On("UpdateTask", mock.AnythingOfType("*context.timerCtx"), &pb.Task{Id: "task1", Title: "Fixed title"}, []string{"title"}).
+		Return(expectedTask, nil).Once()
+
+	req := newTestRequest(http.MethodPatch, "/tasks/task1", map[string]string{"title": "Fixed title"})
+	req = mux.SetURLVars(req, map[string]string{"id": "task1"})
+	rr := httptest.NewRecorder()
+
+	handler.UpdateTask(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var actualTask pb.Task
+	assert.NoError(t, decodeResponse(rr, &actualTask))
+	assert.Equal(t, expectedTask.Title, actualTask.Title)
+	assert.Equal(t, expectedTask.Description, actualTask.Description)
+
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestUpdateTask_EmptyBody(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	req := newTestRequest(http.MethodPatch, "/tasks/task1", map[string]string{})
+	req = mux.SetURLVars(req, map[string]string{"id": "task1"})
+	rr := httptest.NewRecorder()
+
+	handler.UpdateTask(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockTaskClient.AssertNotCalled(t, "UpdateTask", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateTask_WithAllDayDueDate(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	due := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	mockTaskClient.On("CreateTask", mock.AnythingOfType("*context.timerCtx"), mock.MatchedBy(func(req *pb.CreateTaskRequest) bool {
+		return req.Title == "Pay rent" && req.AllDay && req.DueAt.AsTime().Equal(due)
+	})).Return(&pb.Task{Id: "task1", Title: "Pay rent", DueAt: timestamppb.New(due), AllDay: true}, nil).Once()
+
+	req := newTestRequest(http.MethodPost, "/tasks", map[string]any{"title": "Pay rent", "due_at": "2025-07-01", "all_day": true})
+	rr := httptest.NewRecorder()
+
+	handler.CreateTask(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestCreateTask_InvalidDueDate(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	req := newTestRequest(http.MethodPost, "/tasks", map[string]any{"title": "Pay rent", "due_at": "next tuesday"})
+	rr := httptest.NewRecorder()
+
+	handler.CreateTask(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockTaskClient.AssertNotCalled(t, "CreateTask", mock.Anything, mock.Anything)
+}
+
+func TestCreateTask_Priority(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	mockTaskClient.On("CreateTask", mock.AnythingOfType("*context.timerCtx"), &pb.CreateTaskRequest{Title: "Ship it", Priority: pb.Priority_PRIORITY_HIGH}).
+		Return(&pb.Task{Id: "task1", Title: "Ship it", Priority: pb.Priority_PRIORITY_HIGH}, nil).Once()
+
+	req := newTestRequest(http.MethodPost, "/tasks", map[string]any{"title": "Ship it", "priority": "High"})
+	rr := httptest.NewRecorder()
+
+	handler.CreateTask(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	mockTaskClient.AssertExpectations(t)
+}
+
+// Responses carry the priority as a number, which requests accept back.
+func TestCreateTask_PriorityNumber(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	mockTaskClient.On("CreateTask", mock.AnythingOfType("*context.timerCtx"), &pb.CreateTaskRequest{Title: "Ship it", Priority: pb.Priority_PRIORITY_HIGH}).
+		Return(&pb.Task{Id: "task1", Title: "Ship it", Priority: pb.Priority_PRIORITY_HIGH}, nil).Once()
+
+	req := newTestRequest(http.MethodPost, "/tasks", map[string]any{"title": "Ship it", "priority": 3})
+	rr := httptest.NewRecorder()
+
+	handler.CreateTask(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var body map[string]any
+	assert.NoError(t, decodeResponse(rr, &body))
+	assert.Equal(t, float64(3), body["priority"])
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestCreateTask_UnknownPriority(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	for _, priority := range []any{"critical", 7, 2.5, true} {
+		req := newTestRequest(http.MethodPost, "/tasks", map[string]any{"title": "Ship it", "priority": priority})
+		rr := httptest.NewRecorder()
+
+		handler.CreateTask(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code, "priority %v", priority)
+		var body httputil.ErrorResponse
+		assert.NoError(t, decodeResponse(rr, &body))
+		assert.Contains(t, body.Message, "priority must be one of")
+		assert.Equal(t, httputil.ProblemContentType, rr.Header().Get("Content-Type"))
+		if assert.Len(t, body.Errors, 1) {
+			assert.Equal(t, "priority", body.Errors[0].Field, "the invalid field is named")
+		}
+	}
+	mockTaskClient.AssertNotCalled(t, "CreateTask", mock.Anything, mock.Anything)
+}
+
+func TestUpdateTask_NullDueDateClearsIt(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	mockTaskClient.On("UpdateTask", mock.AnythingOfType("*context.timerCtx"), &pb.Task{Id: "task1"}, []string{"due_at"}).
+		Return(&pb.Task{Id: "task1", Title: "Pay rent"}, nil).Once()
+
+	req := newTestRequest(http.MethodPatch, "/tasks/task1", map[string]any{"due_at": nil})
+	req = mux.SetURLVars(req, map[string]string{"id": "task1"})
+	rr := httptest.NewRecorder()
+
+	handler.UpdateTask(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestPurgeTask_NoContent(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	mockTaskClient.On("PurgeTask", mock.AnythingOfType("*context.timerCtx"), "task1").Return(nil).Once()
+
+	req := newTestRequest(http.MethodDelete, "/trash/task1", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "task1"})
+	rr := httptest.NewRecorder()
+
+	handler.PurgeTask(rr, req)
+
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Empty(t, rr.Body.String())
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestPurgeTask_NotInTrash(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	mockTaskClient.On("PurgeTask", mock.AnythingOfType("*context.timerCtx"), "task1").
+		Return(status.Error(codes.NotFound, "task with ID task1 not found in trash")).Once()
+
+	req := newTestRequest(http.MethodDelete, "/trash/task1", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "task1"})
+	rr := httptest.NewRecorder()
+
+	handler.PurgeTask(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestListTasks_ForwardsQueryParameters(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	completed := false
+	expectedReq := &pb.ListTasksRequest{
+		PageSize:     10,
+		PageToken:    "tok",
+		Completed:    &completed,
+		CreatedAfter: timestamppb.New(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)),
+		OrderBy:      "title",
+	}
+	mockTaskClient.On("ListTasks", mock.AnythingOfType("*context.timerCtx"), expectedReq).
+		Return(&pb.ListTasksResponse{Tasks: []*pb.Task{{Id: "1"}}, NextPageToken: "next", TotalSize: 11}, nil).Once()
+
+	req := newTestRequest(http.MethodGet, "/tasks?page_size=10&page_token=tok&completed=false&created_after=2025-07-01T00:00:00Z&order_by=title", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ListTasks(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var page listTasksResponse
+	assert.NoError(t, decodeResponse(rr, &page))
+	assert.Len(t, page.Tasks, 1)
+	assert.Equal(t, "next", page.NextPageToken)
+	assert.Equal(t, int32(11), page.TotalSize)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestListTasks_InvalidQuery(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	for _, query := range []string{"page_size=-1", "completed=maybe", "updated_before=yesterday"} {
+		req := newTestRequest(http.MethodGet, "/tasks?"+query, nil)
+		rr := httptest.NewRecorder()
+
+		handler.ListTasks(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+	}
+	mockTaskClient.AssertNotCalled(t, "ListTasks", mock.Anything, mock.Anything)
+}
+
+func TestSearchTasks_RouteTakesPrecedenceOverTaskID(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+	router := mux.NewRouter()
+	handler.RegisterRoutes(router)
+
+	mockTaskClient.On("SearchTasks", mock.Anything, &pb.SearchTasksRequest{Query: "milk", PageSize: 5}).
+		Return(&pb.SearchTasksResponse{
+			Results:   []*pb.SearchResult{{Task: &pb.Task{Id: "1"}, TitleHighlight: "Buy <mark>milk</mark>"}},
+			TotalSize: 1,
+		}, nil).Once()
+
+	req := newTestRequest(http.MethodGet, "/tasks/search?q=milk&page_size=5", nil)
+	req = req.WithContext(auth.WithScopes(req.Context(), []string{auth.ScopeRead}))
+	rr := httptest.NewRecorder()
+
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var page searchTasksResponse
+	assert.NoError(t, decodeResponse(rr, &page))
+	assert.Len(t, page.Results, 1)
+	assert.Equal(t, int32(1), page.TotalSize)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestSearchTasks_MissingQuery(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	req := newTestRequest(http.MethodGet, "/tasks/search?q=", nil)
+	rr := httptest.NewRecorder()
+
+	handler.SearchTasks(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockTaskClient.AssertNotCalled(t, "SearchTasks", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sahidhossen/todo/api-gateway/internal/httputil"
+)
+
+// defaultReadyTTL is how long Readyz reuses the result of a storage health
+// check, so that frequent probes do not each make a call.
+const defaultReadyTTL = 2 * time.Second
+
+// readyTimeout bounds the storage health check behind Readyz.
+const readyTimeout = 2 * time.Second
+
+var errShuttingDown = errors.New("API gateway is shutting down")
+
+// healthResponse is the body of successful health probes.
+type healthResponse struct {
+	Status string `json:"status"`
+}
+
+// readiness is the result of the last storage health check.
+type readiness struct {
+	mu        sync.Mutex // held during a check, so concurrent probes share it
+	checkedAt time.Time
+	err       error
+}
+
+// Healthz reports that the process is alive. It checks nothing else, so that
+// an orchestrator restarts the gateway only when it stops answering.
+func (h *Handler) Healthz(w http.ResponseWriter, r *http.Request) {
+	httputil.HandleSuccess(w, r, h.logger, healthResponse{Status: "ok"}, http.StatusOK)
+}
+
+// Readyz reports whether the gateway can serve requests: the storage service
+// answers its gRPC health check as SERVING. It fails at once after Drain.
+func (h *Handler) Readyz(w http.ResponseWriter, r *http.Request) {
+	if h.draining.Load() {
+		httputil.HandleError(w, r, h.logger, errShuttingDown, "Shutting down", http.StatusServiceUnavailable)
+		return
+	}
+	if err := h.checkStorage(r.Context()); err != nil {
+		httputil.HandleError(w, r, h.logger, err, "Storage service unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	httputil.HandleSuccess(w, r, h.logger, healthResponse{Status: "ready"}, http.StatusOK)
+}
+
+// checkStorage returns the result of the storage health check, reusing the
+// last one for readyTTL.
+func (h *Handler) checkStorage(ctx context.Context) error {
+	ttl := h.readyTTL
+	if ttl <= 0 {
+		ttl = defaultReadyTTL
+	}
+
+	h.readiness.mu.Lock()
+	defer h.readiness.mu.Unlock()
+	if !h.readiness.checkedAt.IsZero() && time.Since(h.readiness.checkedAt) < ttl {
+		return h.readiness.err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readyTimeout)
+	defer cancel()
+	h.readiness.err = h.taskClient.CheckHealth(ctx)
+	h.readiness.checkedAt = time.Now()
+	return h.readiness.err
+}
+
+// Drain makes Readyz fail from now on, so that load balancers stop sending
+// requests while the server shuts down. It is registered with
+// server.HTTPService.RegisterOnShutdown ahead of the other hooks.
+func (h *Handler) Drain(ctx context.Context) error {
+	h.draining.Store(true)
+	return nil
+}
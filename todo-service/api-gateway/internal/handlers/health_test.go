@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sahidhossen/todo/api-gateway/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHealthz(t *testing.T) {
+	handler := &Handler{taskClient: new(mocks.MockTaskService), logger: slog.New(slog.DiscardHandler)}
+
+	rr := httptest.NewRecorder()
+	handler.Healthz(rr, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rr.Body.String())
+}
+
+func TestReadyz_CachesStorageCheck(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{taskClient: mockTaskClient, logger: slog.New(slog.DiscardHandler)}
+	mockTaskClient.On("CheckHealth", mock.Anything).Return(nil).Once()
+
+	for range 3 {
+		rr := httptest.NewRecorder()
+		handler.Readyz(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	}
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestReadyz_StorageUnavailable(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{taskClient: mockTaskClient, logger: slog.New(slog.DiscardHandler)}
+	mockTaskClient.On("CheckHealth", mock.Anything).Return(errors.New("storage service is NOT_SERVING")).Once()
+
+	rr := httptest.NewRecorder()
+	handler.Readyz(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestReadyz_FailsFastWhenDraining(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{taskClient: mockTaskClient, logger: slog.New(slog.DiscardHandler)}
+
+	assert.NoError(t, handler.Drain(context.Background()))
+	rr := httptest.NewRecorder()
+	handler.Readyz(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	mockTaskClient.AssertNotCalled(t, "CheckHealth", mock.Anything)
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/sahidhossen/todo/api-gateway/internal/httputil"
+	pb "github.com/sahidhossen/todo/proto/task_service"
+)
+
+// labelRequest is the JSON body of POST /labels and PATCH /labels/{id}.
+type labelRequest struct {
+	Name string `json:"name"`
+}
+
+// taskLabelsRequest is the JSON body of POST /tasks/{id}/labels.
+type taskLabelsRequest struct {
+	Labels []string `json:"labels"`
+}
+
+// CreateLabel handles the creation of a new label.
+func (h *Handler) CreateLabel(w http.ResponseWriter, r *http.Request) {
+	var req labelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.HandleError(w, r, h.logger, err, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		httputil.HandleError(w, r, h.logger, nil, "Name cannot be empty", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := httputil.WithTimeout(r)
+	defer cancel()
+
+	label, err := h.taskClient.CreateLabel(ctx, req.Name)
+	if err != nil {
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to create label")
+		return
+	}
+
+	httputil.HandleSuccess(w, r, h.logger, label, http.StatusCreated)
+	h.logger.InfoContext(r.Context(), "Label created via API", "id", label.Id, "name", label.Name)
+}
+
+// ListLabels handles listing all labels.
+func (h *Handler) ListLabels(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := httputil.WithTimeout(r)
+	defer cancel()
+
+	labels, err := h.taskClient.ListLabels(ctx)
+	if err != nil {
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to retrieve labels")
+		return
+	}
+	if labels == nil {
+		labels = []*pb.Label{}
+	}
+
+	httputil.HandleSuccess(w, r, h.logger, labels, http.StatusOK)
+	h.logger.InfoContext(r.Context(), "Listed labels via API", "count", len(labels))
+}
+
+// RenameLabel handles renaming a label.
+func (h *Handler) RenameLabel(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	var req labelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.HandleError(w, r, h.logger, err, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		httputil.HandleError(w, r, h.logger, nil, "Name cannot be empty", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := httputil.WithTimeout(r)
+	defer cancel()
+
+	label, err := h.taskClient.RenameLabel(ctx, id, req.Name)
+	if err != nil {
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to rename label")
+		return
+	}
+
+	httputil.HandleSuccess(w, r, h.logger, label, http.StatusOK)
+	h.logger.InfoContext(r.Context(), "Label renamed via API", "id", label.Id, "name", label.Name)
+}
+
+// DeleteLabel handles deleting a label. Tasks that carried it lose it.
+func (h *Handler) DeleteLabel(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	ctx, cancel := httputil.WithTimeout(r)
+	defer cancel()
+
+	if err := h.taskClient.DeleteLabel(ctx, id); err != nil {
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to delete label")
+		return
+	}
+
+	httputil.HandleNoContent(w, r, h.logger)
+	h.logger.InfoContext(r.Context(), "Label deleted via API", "id", id)
+}
+
+// AddTaskLabels handles attaching labels to a task. Unknown labels are created.
+func (h *Handler) AddTaskLabels(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	var req taskLabelsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.HandleError(w, r, h.logger, err, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if len(req.Labels) == 0 {
+		httputil.HandleError(w, r, h.logger, nil, "Labels cannot be empty", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := httputil.WithTimeout(r)
+	defer cancel()
+
+	task, err := h.taskClient.AddLabels(ctx, id, req.Labels)
+	if err != nil {
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to add labels")
+		return
+	}
+
+	httputil.HandleSuccess(w, r, h.logger, task, http.StatusOK)
+	h.logger.InfoContext(r.Context(), "Labels added via API", "id", task.Id, "labels", req.Labels)
+}
+
+// RemoveTaskLabels handles detaching the labels named by ?label= from a task.
+func (h *Handler) RemoveTaskLabels(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	labels := r.URL.Query()["label"]
+	if len(labels) == 0 {
+		httputil.HandleError(w, r, h.logger, nil, "At least one label query parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := httputil.WithTimeout(r)
+	defer cancel()
+
+	task, err := h.taskClient.RemoveLabels(ctx, id, labels)
+	if err != nil {
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to remove labels")
+		return
+	}
+
+	httputil.HandleSuccess(w, r, h.logger, task, http.StatusOK)
+	h.logger.InfoContext(r.Context(), "Labels removed via API", "id", task.Id, "labels", labels)
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/sahidhossen/todo/api-gateway/mocks"
+	pb "github.com/sahidhossen/todo/proto/task_service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateLabel_Success(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	mockTaskClient.On("CreateLabel", mock.AnythingOfType("*context.timerCtx"), "work").
+		Return(&pb.Label{Id: "label1", Name: "work"}, nil).Once()
+
+	req := newTestRequest(http.MethodPost, "/labels", labelRequest{Name: "work"})
+	rr := httptest.NewRecorder()
+
+	handler.CreateLabel(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var label pb.Label
+	assert.NoError(t, decodeResponse(rr, &label))
+	assert.Equal(t, "work", label.Name)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestCreateLabel_Duplicate(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	mockTaskClient.On("CreateLabel", mock.AnythingOfType("*context.timerCtx"), "work").
+		Return(nil, status.Error(codes.AlreadyExists, "a label with that name already exists")).Once()
+
+	req := newTestRequest(http.MethodPost, "/labels", labelRequest{Name: "work"})
+	rr := httptest.NewRecorder()
+
+	handler.CreateLabel(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestDeleteLabel_NoContent(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	mockTaskClient.On("DeleteLabel", mock.AnythingOfType("*context.timerCtx"), "label1").Return(nil).Once()
+
+	req := newTestRequest(http.MethodDelete, "/labels/label1", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "label1"})
+	rr := httptest.NewRecorder()
+
+	handler.DeleteLabel(rr, req)
+
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestAddTaskLabels_Success(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	mockTaskClient.On("AddLabels", mock.AnythingOfType("*context.timerCtx"), "task1", []string{"home", "errands"}).
+		Return(&pb.Task{Id: "task1", Labels: []string{"errands", "home"}}, nil).Once()
+
+	req := newTestRequest(http.MethodPost, "/tasks/task1/labels", taskLabelsRequest{Labels: []string{"home", "errands"}})
+	req = mux.SetURLVars(req, map[string]string{"id": "task1"})
+	rr := httptest.NewRecorder()
+
+	handler.AddTaskLabels(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var task pb.Task
+	assert.NoError(t, decodeResponse(rr, &task))
+	assert.Equal(t, []string{"errands", "home"}, task.Labels)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestRemoveTaskLabels_RequiresLabel(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	req := newTestRequest(http.MethodDelete, "/tasks/task1/labels", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "task1"})
+	rr := httptest.NewRecorder()
+
+	handler.RemoveTaskLabels(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockTaskClient.AssertNotCalled(t, "RemoveLabels", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestListTasks_LabelFilter(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	mockTaskClient.On("ListTasks", mock.AnythingOfType("*context.timerCtx"), &pb.ListTasksRequest{Labels: []string{"home", "urgent"}}).
+		Return(&pb.ListTasksResponse{}, nil).Once()
+
+	req := newTestRequest(http.MethodGet, "/tasks?label=home&label=urgent", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ListTasks(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockTaskClient.AssertExpectations(t)
+}
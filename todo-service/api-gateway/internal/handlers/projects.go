@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/sahidhossen/todo/api-gateway/internal/httputil"
+	pb "github.com/sahidhossen/todo/proto/task_service"
+)
+
+// CreateProject handles the creation of a new project.
+func (h *Handler) CreateProject(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name      string `json:"name"`
+		Color     string `json:"color"`
+		SortOrder int32  `json:"sort_order"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.HandleError(w, r, h.logger, err, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		httputil.HandleError(w, r, h.logger, nil, "Name cannot be empty", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := httputil.WithTimeout(r)
+	defer cancel()
+
+	project, err := h.taskClient.CreateProject(ctx, &pb.CreateProjectRequest{
+		Name:      req.Name,
+		Color:     req.Color,
+		SortOrder: req.SortOrder,
+	})
+	if err != nil {
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to create project")
+		return
+	}
+
+	httputil.HandleSuccess(w, r, h.logger, project, http.StatusCreated)
+	h.logger.InfoContext(r.Context(), "Project created via API", "id", project.Id, "name", project.Name)
+}
+
+// ListProjects handles listing projects. Archived projects are included only
+// with ?include_archived=true.
+func (h *Handler) ListProjects(w http.ResponseWriter, r *http.Request) {
+	includeArchived, err := parseBool(r.URL.Query(), "include_archived")
+	if err != nil {
+		httputil.HandleError(w, r, h.logger, err, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := httputil.WithTimeout(r)
+	defer cancel()
+
+	projects, err := h.taskClient.ListProjects(ctx, includeArchived)
+	if err != nil {
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to retrieve projects")
+		return
+	}
+	if projects == nil {
+		projects = []*pb.Project{}
+	}
+
+	httputil.HandleSuccess(w, r, h.logger, projects, http.StatusOK)
+	h.logger.InfoContext(r.Context(), "Listed projects via API", "count", len(projects))
+}
+
+// GetProject handles retrieving a single project by ID.
+func (h *Handler) GetProject(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	ctx, cancel := httputil.WithTimeout(r)
+	defer cancel()
+
+	project, err := h.taskClient.GetProject(ctx, id)
+	if err != nil {
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to retrieve project")
+		return
+	}
+
+	httputil.HandleSuccess(w, r, h.logger, project, http.StatusOK)
+	h.logger.InfoContext(r.Context(), "Project retrieved via API", "id", project.Id)
+}
+
+// UpdateProject handles partial updates of a project. Like UpdateTask, only
+// the fields present in the request body are changed.
+func (h *Handler) UpdateProject(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	var req struct {
+		Name      *string `json:"name"`
+		Color     *string `json:"color"`
+		Archived  *bool   `json:"archived"`
+		SortOrder *int32  `json:"sort_order"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.HandleError(w, r, h.logger, err, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	project := &pb.Project{Id: id}
+	var paths []string
+	if req.Name != nil {
+		if strings.TrimSpace(*req.Name) == "" {
+			httputil.HandleError(w, r, h.logger, nil, "Name cannot be empty", http.StatusBadRequest)
+			return
+		}
+		project.Name = *req.Name
+		paths = append(paths, "name")
+	}
+	if req.Color != nil {
+		project.Color = *req.Color
+		paths = append(paths, "color")
+	}
+	if req.Archived != nil {
+		project.Archived = *req.Archived
+		paths = append(paths, "archived")
+	}
+	if req.SortOrder != nil {
+		project.SortOrder = *req.SortOrder
+		paths = append(paths, "sort_order")
+	}
+	if len(paths) == 0 {
+		httputil.HandleError(w, r, h.logger, nil, "Request body must set at least one of name, color, archived or sort_order", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := httputil.WithTimeout(r)
+	defer cancel()
+
+	updated, err := h.taskClient.UpdateProject(ctx, project, paths)
+	if err != nil {
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to update project")
+		return
+	}
+
+	httputil.HandleSuccess(w, r, h.logger, updated, http.StatusOK)
+	h.logger.InfoContext(r.Context(), "Project updated via API", "id", updated.Id, "fields", paths)
+}
+
+// DeleteProject handles deleting a project. Its tasks are kept outside any project.
+func (h *Handler) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	ctx, cancel := httputil.WithTimeout(r)
+	defer cancel()
+
+	if err := h.taskClient.DeleteProject(ctx, id); err != nil {
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to delete project")
+		return
+	}
+
+	httputil.HandleNoContent(w, r, h.logger)
+	h.logger.InfoContext(r.Context(), "Project deleted via API", "id", id)
+}
+
+// ListProjectTasks handles listing one page of a project's tasks. It accepts
+// the same query parameters as ListTasks.
+func (h *Handler) ListProjectTasks(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	listReq, err := parseListTasksQuery(r.URL.Query())
+	if err != nil {
+		httputil.HandleError(w, r, h.logger, err, err.Error(), http.StatusBadRequest)
+		return
+	}
+	listReq.ProjectId = id
+	h.listTasks(w, r, listReq)
+}
+
+// GetProjectStats handles retrieving the task statistics of a project.
+func (h *Handler) GetProjectStats(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	h.taskStats(w, r, vars["id"])
+}
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/sahidhossen/todo/api-gateway/mocks"
+	pb "github.com/sahidhossen/todo/proto/task_service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateProject_Success(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	mockTaskClient.On("CreateProject", mock.AnythingOfType("*context.timerCtx"), &pb.CreateProjectRequest{Name: "Home", Color: "#ff8800"}).
+		Return(&pb.Project{Id: "project1", Name: "Home", Color: "#ff8800"}, nil).Once()
+
+	req := newTestRequest(http.MethodPost, "/projects", map[string]string{"name": "Home", "color": "#ff8800"})
+	rr := httptest.NewRecorder()
+
+	handler.CreateProject(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var project pb.Project
+	assert.NoError(t, decodeResponse(rr, &project))
+	assert.Equal(t, "project1", project.Id)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestListProjects_IncludeArchived(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	mockTaskClient.On("ListProjects", mock.AnythingOfType("*context.timerCtx"), true).Return(nil, nil).Once()
+
+	req := newTestRequest(http.MethodGet, "/projects?include_archived=true", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ListProjects(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `[]`, rr.Body.String())
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestUpdateProject_OnlySentFields(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	mockTaskClient.On("UpdateProject", mock.AnythingOfType("*context.timerCtx"), &pb.Project{Id: "project1", Archived: true}, []string{"archived"}).
+		Return(&pb.Project{Id: "project1", Name: "Home", Archived: true}, nil).Once()
+
+	req := newTestRequest(http.MethodPatch, "/projects/project1", map[string]bool{"archived": true})
+	req = mux.SetURLVars(req, map[string]string{"id": "project1"})
+	rr := httptest.NewRecorder()
+
+	handler.UpdateProject(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestListProjectTasks_SetsProject(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	mockTaskClient.On("ListTasks", mock.AnythingOfType("*context.timerCtx"), mock.MatchedBy(func(req *pb.ListTasksRequest) bool {
+		return req.ProjectId == "project1" && req.PageSize == 10
+	})).Return(&pb.ListTasksResponse{Tasks: []*pb.Task{{Id: "1", ProjectId: "project1"}}, TotalSize: 1}, nil).Once()
+
+	req := newTestRequest(http.MethodGet, "/projects/project1/tasks?page_size=10", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "project1"})
+	rr := httptest.NewRecorder()
+
+	handler.ListProjectTasks(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var page listTasksResponse
+	assert.NoError(t, decodeResponse(rr, &page))
+	assert.Equal(t, int32(1), page.TotalSize)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestGetProjectStats_Success(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	mockTaskClient.On("GetTaskStats", mock.AnythingOfType("*context.timerCtx"), &pb.GetTaskStatsRequest{ProjectId: "project1"}).
+		Return(&pb.GetTaskStatsResponse{TotalTasks: 2, PendingTasks: 2}, nil).Once()
+
+	req := newTestRequest(http.MethodGet, "/projects/project1/stats", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "project1"})
+	rr := httptest.NewRecorder()
+
+	handler.GetProjectStats(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestListProjectTasks_NotFound(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	mockTaskClient.On("ListTasks", mock.AnythingOfType("*context.timerCtx"), mock.AnythingOfType("*task_service.ListTasksRequest")).
+		Return(nil, status.Error(codes.NotFound, "project with ID missing not found")).Once()
+
+	req := newTestRequest(http.MethodGet, "/projects/missing/tasks", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "missing"})
+	rr := httptest.NewRecorder()
+
+	handler.ListProjectTasks(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestGetProjectStats_NotFound(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	mockTaskClient.On("GetTaskStats", mock.AnythingOfType("*context.timerCtx"), &pb.GetTaskStatsRequest{ProjectId: "missing"}).
+		Return(nil, status.Error(codes.NotFound, "project with ID missing not found")).Once()
+
+	req := newTestRequest(http.MethodGet, "/projects/missing/stats", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "missing"})
+	rr := httptest.NewRecorder()
+
+	handler.GetProjectStats(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestCreateTask_ArchivedProject(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	mockTaskClient.On("CreateTask", mock.AnythingOfType("*context.timerCtx"), &pb.CreateTaskRequest{Title: "Task", ProjectId: "project1"}).
+		Return(nil, status.Error(codes.FailedPrecondition, "project with ID project1 is archived")).Once()
+
+	req := newTestRequest(http.MethodPost, "/tasks", map[string]string{"title": "Task", "project_id": "project1"})
+	rr := httptest.NewRecorder()
+
+	handler.CreateTask(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockTaskClient.AssertExpectations(t)
+}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sahidhossen/todo/api-gateway/internal/httputil"
+	pb "github.com/sahidhossen/todo/proto/task_service"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// listTasksResponse is the JSON body of paginated task listings.
+type listTasksResponse struct {
+	Tasks         []*pb.Task `json:"tasks"`
+	NextPageToken string     `json:"next_page_token"`
+	TotalSize     int32      `json:"total_size"`
+}
+
+// searchTasksResponse is the JSON body of GET /tasks/search.
+type searchTasksResponse struct {
+	Results       []*pb.SearchResult `json:"results"`
+	NextPageToken string             `json:"next_page_token"`
+	TotalSize     int32              `json:"total_size"`
+}
+
+// parseSearchTasksQuery builds a SearchTasksRequest from the query parameters of GET /tasks/search.
+func parseSearchTasksQuery(q url.Values) (*pb.SearchTasksRequest, error) {
+	query := strings.TrimSpace(q.Get("q"))
+	if query == "" {
+		return nil, fmt.Errorf("q cannot be empty")
+	}
+
+	pageSize, err := parsePageSize(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SearchTasksRequest{
+		Query:     query,
+		PageSize:  pageSize,
+		PageToken: q.Get("page_token"),
+	}, nil
+}
+
+// parseListTasksQuery builds a ListTasksRequest from the query parameters of GET /tasks.
+func parseListTasksQuery(q url.Values) (*pb.ListTasksRequest, error) {
+	req := &pb.ListTasksRequest{
+		PageToken: q.Get("page_token"),
+		OrderBy:   q.Get("order_by"),
+		Labels:    q["label"],
+		ProjectId: q.Get("project_id"),
+	}
+
+	pageSize, err := parsePageSize(q)
+	if err != nil {
+		return nil, err
+	}
+	req.PageSize = pageSize
+
+	if v := q.Get("completed"); v != "" {
+		completed, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("completed must be true or false")
+		}
+		req.Completed = &completed
+	}
+
+	if v := q.Get("overdue"); v != "" {
+		overdue, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("overdue must be true or false")
+		}
+		req.Overdue = &overdue
+	}
+
+	timeParams := []struct {
+		name   string
+		target **timestamppb.Timestamp
+	}{
+		{"created_after", &req.CreatedAfter},
+		{"created_before", &req.CreatedBefore},
+		{"updated_after", &req.UpdatedAfter},
+		{"updated_before", &req.UpdatedBefore},
+		{"due_after", &req.DueAfter},
+		{"due_before", &req.DueBefore},
+	}
+	for _, p := range timeParams {
+		ts, err := parseTimestamp(q, p.name)
+		if err != nil {
+			return nil, err
+		}
+		*p.target = ts
+	}
+
+	return req, nil
+}
+
+// parsePriority converts the priority field of a JSON request body to its
+// enum value. It accepts the number that task responses carry, such as 3, or
+// the name of the value, such as "high". A missing field, null or an empty
+// name means no priority.
+func parsePriority(raw json.RawMessage) (pb.Priority, error) {
+	invalid := httputil.InvalidField("priority", "priority must be one of none, low, medium, high or urgent, or a number from 0 to 4")
+	var value any
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return 0, invalid
+		}
+	}
+	switch v := value.(type) {
+	case nil:
+		return pb.Priority_PRIORITY_NONE, nil
+	case float64:
+		if _, ok := pb.Priority_name[int32(v)]; !ok || float64(int32(v)) != v {
+			return 0, invalid
+		}
+		return pb.Priority(int32(v)), nil
+	case string:
+		if v == "" {
+			return pb.Priority_PRIORITY_NONE, nil
+		}
+		number, ok := pb.Priority_value["PRIORITY_"+strings.ToUpper(v)]
+		if !ok {
+			return 0, invalid
+		}
+		return pb.Priority(number), nil
+	default:
+		return 0, invalid
+	}
+}
+
+// parseBool reads an optional boolean query parameter, which defaults to false.
+func parseBool(q url.Values, name string) (bool, error) {
+	v := q.Get(name)
+	if v == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("%s must be true or false", name)
+	}
+	return b, nil
+}
+
+// parsePageSize reads the optional page_size query parameter.
+func parsePageSize(q url.Values) (int32, error) {
+	v := q.Get("page_size")
+	if v == "" {
+		return 0, nil
+	}
+	size, err := strconv.ParseInt(v, 10, 32)
+	if err != nil || size < 0 {
+		return 0, fmt.Errorf("page_size must be a non-negative integer")
+	}
+	return int32(size), nil
+}
+
+// parseTimestamp reads an optional timestamp query parameter. See parseTime for the accepted formats.
+func parseTimestamp(q url.Values, name string) (*timestamppb.Timestamp, error) {
+	v := q.Get(name)
+	if v == "" {
+		return nil, nil
+	}
+	return parseTime(name, v)
+}
+
+// parseTime parses an RFC 3339 timestamp or a YYYY-MM-DD date, which is read
+// as midnight UTC like all-day due dates.
+func parseTime(name, v string) (*timestamppb.Timestamp, error) {
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		t, err = time.Parse(time.DateOnly, v)
+	}
+	if err != nil {
+		return nil, httputil.InvalidField(name, name+" must be an RFC 3339 timestamp or a YYYY-MM-DD date")
+	}
+	return timestamppb.New(t), nil
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/sahidhossen/todo/api-gateway/internal/httputil"
+	pb "github.com/sahidhossen/todo/proto/task_service"
+)
+
+// UpdateTaskSeries handles changing every open occurrence of a recurring
+// task series. Only the fields present in the request body are changed; an
+// empty recurrence ends the series.
+func (h *Handler) UpdateTaskSeries(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	var req struct {
+		Title       *string         `json:"title"`
+		Description *string         `json:"description"`
+		Priority    json.RawMessage `json:"priority"`
+		ProjectID   *string         `json:"project_id"`
+		Recurrence  *string         `json:"recurrence"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.HandleError(w, r, h.logger, err, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	task := &pb.Task{}
+	var paths []string
+	if req.Title != nil {
+		if *req.Title == "" {
+			httputil.HandleError(w, r, h.logger, nil, "Title cannot be empty", http.StatusBadRequest)
+			return
+		}
+		task.Title = *req.Title
+		paths = append(paths, "title")
+	}
+	if req.Description != nil {
+		task.Description = *req.Description
+		paths = append(paths, "description")
+	}
+	if req.Priority != nil {
+		priority, err := parsePriority(req.Priority)
+		if err != nil {
+			httputil.HandleError(w, r, h.logger, err, err.Error(), http.StatusBadRequest)
+			return
+		}
+		task.Priority = priority
+		paths = append(paths, "priority")
+	}
+	if req.ProjectID != nil {
+		task.ProjectId = *req.ProjectID
+		paths = append(paths, "project_id")
+	}
+	if req.Recurrence != nil {
+		task.Recurrence = *req.Recurrence
+		paths = append(paths, "recurrence")
+	}
+	if len(paths) == 0 {
+		httputil.HandleError(w, r, h.logger, nil, "Request body must set at least one of title, description, priority, project_id or recurrence", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := httputil.WithTimeout(r)
+	defer cancel()
+
+	tasks, err := h.taskClient.UpdateTaskSeries(ctx, id, task, paths)
+	if err != nil {
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to update task series")
+		return
+	}
+	if tasks == nil {
+		tasks = []*pb.Task{}
+	}
+
+	httputil.HandleSuccess(w, r, h.logger, tasks, http.StatusOK)
+	h.logger.InfoContext(r.Context(), "Task series updated via API", "series_id", id, "fields", paths, "count", len(tasks))
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/sahidhossen/todo/api-gateway/mocks"
+	pb "github.com/sahidhossen/todo/proto/task_service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUpdateTaskSeries_EndsSeries(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	mockTaskClient.On("UpdateTaskSeries", mock.AnythingOfType("*context.timerCtx"), "series1", &pb.Task{}, []string{"recurrence"}).
+		Return([]*pb.Task{{Id: "task2", SeriesId: "series1"}}, nil).Once()
+
+	req := newTestRequest(http.MethodPatch, "/series/series1", map[string]string{"recurrence": ""})
+	req = mux.SetURLVars(req, map[string]string{"id": "series1"})
+	rr := httptest.NewRecorder()
+
+	handler.UpdateTaskSeries(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var tasks []*pb.Task
+	assert.NoError(t, decodeResponse(rr, &tasks))
+	assert.Len(t, tasks, 1)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestUpdateTaskSeries_InvalidRule(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	mockTaskClient.On("UpdateTaskSeries", mock.AnythingOfType("*context.timerCtx"), "series1", &pb.Task{Recurrence: "FREQ=HOURLY"}, []string{"recurrence"}).
+		Return(nil, status.Error(codes.InvalidArgument, "unsupported recurrence frequency HOURLY")).Once()
+
+	req := newTestRequest(http.MethodPatch, "/series/series1", map[string]string{"recurrence": "FREQ=HOURLY"})
+	req = mux.SetURLVars(req, map[string]string{"id": "series1"})
+	rr := httptest.NewRecorder()
+
+	handler.UpdateTaskSeries(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestUpdateTaskSeries_EmptyBody(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	req := newTestRequest(http.MethodPatch, "/series/series1", map[string]string{})
+	req = mux.SetURLVars(req, map[string]string{"id": "series1"})
+	rr := httptest.NewRecorder()
+
+	handler.UpdateTaskSeries(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockTaskClient.AssertNotCalled(t, "UpdateTaskSeries", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
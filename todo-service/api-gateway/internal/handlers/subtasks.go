@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/sahidhossen/todo/api-gateway/internal/httputil"
+	pb "github.com/sahidhossen/todo/proto/task_service"
+)
+
+// CreateSubtask handles the creation of a new subtask of the task in the path.
+func (h *Handler) CreateSubtask(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	h.createTask(w, r, vars["id"])
+}
+
+// ListSubtasks handles listing the direct subtasks of a task.
+func (h *Handler) ListSubtasks(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	ctx, cancel := httputil.WithTimeout(r)
+	defer cancel()
+
+	tasks, err := h.taskClient.ListSubtasks(ctx, id)
+	if err != nil {
+		httputil.HandleGrpcError(w, r, h.logger, err, "Failed to retrieve subtasks")
+		return
+	}
+	if tasks == nil {
+		tasks = []*pb.Task{}
+	}
+
+	httputil.HandleSuccess(w, r, h.logger, tasks, http.StatusOK)
+	h.logger.InfoContext(r.Context(), "Listed subtasks via API", "parent_id", id, "count", len(tasks))
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/sahidhossen/todo/api-gateway/mocks"
+	pb "github.com/sahidhossen/todo/proto/task_service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateSubtask_UsesPathParent(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	mockTaskClient.On("CreateTask", mock.AnythingOfType("*context.timerCtx"), mock.MatchedBy(func(req *pb.CreateTaskRequest) bool {
+		return req.Title == "Child" && req.ParentId == "task1"
+	})).Return(&pb.Task{Id: "task2", Title: "Child", ParentId: "task1"}, nil).Once()
+
+	req := newTestRequest(http.MethodPost, "/tasks/task1/subtasks", map[string]string{"title": "Child"})
+	req = mux.SetURLVars(req, map[string]string{"id": "task1"})
+	rr := httptest.NewRecorder()
+
+	handler.CreateSubtask(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var task pb.Task
+	assert.NoError(t, decodeResponse(rr, &task))
+	assert.Equal(t, "task1", task.ParentId)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestListSubtasks_NotFound(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	mockTaskClient.On("ListSubtasks", mock.AnythingOfType("*context.timerCtx"), "missing").
+		Return(nil, status.Error(codes.NotFound, "task with ID missing not found")).Once()
+
+	req := newTestRequest(http.MethodGet, "/tasks/missing/subtasks", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "missing"})
+	rr := httptest.NewRecorder()
+
+	handler.ListSubtasks(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestGetTask_IncludeChildren(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	mockTaskClient.On("GetTaskTree", mock.AnythingOfType("*context.timerCtx"), "task1").
+		Return(&pb.Task{Id: "task1", Children: []*pb.Task{{Id: "task2", ParentId: "task1"}}}, nil).Once()
+
+	req := newTestRequest(http.MethodGet, "/tasks/task1?include_children=true", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "task1"})
+	rr := httptest.NewRecorder()
+
+	handler.GetTask(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var task pb.Task
+	assert.NoError(t, decodeResponse(rr, &task))
+	assert.Len(t, task.Children, 1)
+	mockTaskClient.AssertNotCalled(t, "GetTask", mock.Anything, mock.Anything)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestGetTaskStats_InvalidLeavesOnly(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := &Handler{
+		taskClient: mockTaskClient,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	req := newTestRequest(http.MethodGet, "/tasks/stats?leaves_only=maybe", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetTaskStats(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockTaskClient.AssertNotCalled(t, "GetTaskStats", mock.Anything, mock.Anything)
+}
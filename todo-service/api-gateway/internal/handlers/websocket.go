@@ -0,0 +1,487 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/sahidhossen/todo/api-gateway/internal/auth"
+	"github.com/sahidhossen/todo/api-gateway/internal/httputil"
+	"github.com/sahidhossen/todo/api-gateway/internal/services"
+	pb "github.com/sahidhossen/todo/proto/task_service"
+)
+
+const (
+	// wsWriteWait is the time allowed to write a message to the client.
+	wsWriteWait = 10 * time.Second
+	// wsPongWait is the time allowed between messages or pongs from the client.
+	wsPongWait = 60 * time.Second
+	// wsPingPeriod is how often the client is pinged; shorter than wsPongWait.
+	wsPingPeriod = wsPongWait * 9 / 10
+	// wsMaxMessageSize is the largest message accepted from the client.
+	wsMaxMessageSize = 64 << 10
+	// wsSendBuffer is the number of messages that may wait for a slow client
+	// before its connection is closed.
+	wsSendBuffer = 64
+	// wsMaxSubscriptions caps the subscriptions of one connection.
+	wsMaxSubscriptions = 32
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// Any origin may connect, like the CORS headers allow for the REST API.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// wsRequest is a message from a WebSocket client. ID is a correlation ID
+// chosen by the client and echoed in the reply.
+type wsRequest struct {
+	ID   string `json:"id"`
+	Type string `json:"type"` // subscribe, unsubscribe, create, toggle or update
+
+	// Filter selects the tasks of a subscription; all tasks when omitted.
+	Filter *wsFilter `json:"filter"`
+	// AfterSequence resumes the events after a previously received sequence.
+	// It only applies to the first subscription of a connection.
+	AfterSequence uint64 `json:"after_sequence"`
+	// Subscription is the ID of the subscribe request to unsubscribe from.
+	Subscription string `json:"subscription"`
+	// TaskID is the task to toggle or update.
+	TaskID string `json:"task_id"`
+	// Task is the body of a create or update, as for POST /tasks and PATCH /tasks/{id}.
+	Task json.RawMessage `json:"task"`
+}
+
+// wsFilter selects the tasks a subscription receives events for.
+type wsFilter struct {
+	ProjectID string   `json:"project_id"`
+	ParentID  string   `json:"parent_id"`
+	Labels    []string `json:"labels"` // tasks must have all of them
+	Completed *bool    `json:"completed"`
+}
+
+// matches reports whether task is selected by the filter.
+func (f *wsFilter) matches(task *pb.Task) bool {
+	if f.ProjectID != "" && task.ProjectId != f.ProjectID {
+		return false
+	}
+	if f.ParentID != "" && task.ParentId != f.ParentID {
+		return false
+	}
+	if f.Completed != nil && task.Completed != *f.Completed {
+		return false
+	}
+	for _, label := range f.Labels {
+		if !slices.Contains(task.Labels, label) {
+			return false
+		}
+	}
+	return true
+}
+
+// wsMessage is a message to a WebSocket client: the result of a request, an
+// error, a task event, or a reset telling the client to reload its tasks
+// because events were missed.
+type wsMessage struct {
+	ID   string `json:"id,omitempty"`
+	Type string `json:"type"` // result, error, event or reset
+
+	Task          *pb.Task               `json:"task,omitempty"`
+	Subscription  string                 `json:"subscription,omitempty"`
+	Subscriptions []string               `json:"subscriptions,omitempty"` // of an event, the ones it matched
+	Event         string                 `json:"event,omitempty"`         // created, updated, completed or deleted
+	Sequence      uint64                 `json:"sequence,omitempty"`
+	Time          *timestamppb.Timestamp `json:"time,omitempty"`
+	Error         *wsError               `json:"error,omitempty"`
+}
+
+// wsError describes a failed request with the status code the REST API would use.
+type wsError struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+// wsSubscription is a filter together with the tasks this connection has
+// seen match it, so that a task that stops matching is delivered once more.
+type wsSubscription struct {
+	filter wsFilter
+	tasks  map[string]struct{}
+}
+
+// track records event and reports whether it is delivered to the subscription.
+func (s *wsSubscription) track(event *pb.TaskEvent) bool {
+	id := event.Task.GetId()
+	_, known := s.tasks[id]
+	matches := s.filter.matches(event.Task)
+	if matches && event.Type != pb.TaskEvent_TYPE_DELETED {
+		s.tasks[id] = struct{}{}
+	} else {
+		delete(s.tasks, id)
+	}
+	return matches || known
+}
+
+// ServeWebSocket handles GET /ws, a WebSocket for keeping clients in sync.
+// Clients subscribe to task events by filter and send mutations as JSON
+// messages; every request gets a result or error reply carrying its ID.
+// Requests are handled one at a time in the order they arrive. A client
+// that does not keep up with its messages is disconnected and should
+// resume with after_sequence.
+func (h *Handler) ServeWebSocket(w http.ResponseWriter, r *http.Request) {
+	if !h.trackSocket() {
+		httputil.HandleError(w, r, h.logger, nil, "Server is shutting down", http.StatusServiceUnavailable)
+		return
+	}
+	defer h.sockets.Done()
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has already replied with an HTTP error.
+		h.logger.WarnContext(r.Context(), "WebSocket upgrade failed", "error", err, "remote_addr", r.RemoteAddr)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	c := &wsConn{
+		taskClient: h.taskClient,
+		logger:     h.logger.With("remote_addr", r.RemoteAddr),
+		conn:       conn,
+		ctx:        ctx,
+		cancel:     cancel,
+		send:       make(chan wsMessage, wsSendBuffer),
+		subs:       make(map[string]*wsSubscription),
+	}
+	c.logger.InfoContext(r.Context(), "WebSocket opened via API")
+	c.serve(h.closing)
+	c.logger.InfoContext(r.Context(), "WebSocket closed via API")
+}
+
+// trackSocket counts a new WebSocket connection unless the handler is closing.
+func (h *Handler) trackSocket() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.isClosing() {
+		return false
+	}
+	h.sockets.Add(1)
+	return true
+}
+
+// wsConn is one WebSocket connection. Only writeLoop writes data messages;
+// everything else queues them on send.
+type wsConn struct {
+	taskClient services.TaskService
+	logger     *slog.Logger
+	conn       *websocket.Conn
+	ctx        context.Context // done when the connection is closed
+	cancel     context.CancelFunc
+	send       chan wsMessage
+	closeOnce  sync.Once
+	wg         sync.WaitGroup // writeLoop and relay
+
+	mu       sync.Mutex // guards subs and watching
+	subs     map[string]*wsSubscription
+	watching bool
+}
+
+// serve runs the connection until the client goes away or closing is closed.
+func (c *wsConn) serve(closing <-chan struct{}) {
+	c.wg.Add(1)
+	go c.writeLoop()
+	go func() {
+		select {
+		case <-closing:
+			c.close(websocket.CloseGoingAway, "server shutting down")
+		case <-c.ctx.Done():
+		}
+	}()
+
+	c.readLoop()
+	c.close(websocket.CloseNormalClosure, "")
+	c.wg.Wait()
+}
+
+// close sends a close message with code and text and closes the connection.
+func (c *wsConn) close(code int, text string) {
+	c.closeOnce.Do(func() {
+		c.cancel()
+		msg := websocket.FormatCloseMessage(code, text)
+		_ = c.conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(wsWriteWait))
+		c.conn.Close()
+	})
+}
+
+// enqueue queues msg for the client, closing the connection when the client
+// has fallen too far behind.
+func (c *wsConn) enqueue(msg wsMessage) {
+	select {
+	case c.send <- msg:
+	case <-c.ctx.Done():
+	default:
+		c.logger.WarnContext(c.ctx, "Closing WebSocket of client that is not keeping up")
+		c.close(websocket.CloseTryAgainLater, "client is not keeping up")
+	}
+}
+
+// writeLoop writes queued messages and pings the client.
+func (c *wsConn) writeLoop() {
+	defer c.wg.Done()
+	ping := time.NewTicker(wsPingPeriod)
+	defer ping.Stop()
+
+	for {
+		select {
+		case msg := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := c.conn.WriteJSON(msg); err != nil {
+				c.logger.WarnContext(c.ctx, "WebSocket write failed", "error", err)
+				c.close(websocket.CloseInternalServerErr, "write failed")
+				return
+			}
+		case <-ping.C:
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				c.close(websocket.CloseGoingAway, "ping failed")
+				return
+			}
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+// readLoop handles requests until reading fails. The client must send a
+// message or answer a ping within wsPongWait.
+func (c *wsConn) readLoop() {
+	c.conn.SetReadLimit(wsMaxMessageSize)
+	_ = c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) && c.ctx.Err() == nil {
+				c.logger.WarnContext(c.ctx, "WebSocket read failed", "error", err)
+			}
+			return
+		}
+		_ = c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+
+		var req wsRequest
+		if err := json.Unmarshal(data, &req); err != nil {
+			c.replyError(req.ID, http.StatusBadRequest, "Invalid message")
+			continue
+		}
+		c.handle(req)
+	}
+}
+
+// handle answers one request.
+func (c *wsConn) handle(req wsRequest) {
+	switch req.Type {
+	case "subscribe":
+		c.subscribe(req)
+	case "unsubscribe":
+		c.mu.Lock()
+		_, ok := c.subs[req.Subscription]
+		delete(c.subs, req.Subscription)
+		c.mu.Unlock()
+		if !ok {
+			c.replyError(req.ID, http.StatusNotFound, fmt.Sprintf("subscription %q not found", req.Subscription))
+			return
+		}
+		c.enqueue(wsMessage{ID: req.ID, Type: "result", Subscription: req.Subscription})
+	case "create", "toggle", "update":
+		c.mutate(req)
+	default:
+		c.replyError(req.ID, http.StatusBadRequest, "type must be one of subscribe, unsubscribe, create, toggle or update")
+	}
+}
+
+// subscribe adds a subscription, starting to watch tasks on the first one.
+func (c *wsConn) subscribe(req wsRequest) {
+	if req.ID == "" {
+		c.replyError(req.ID, http.StatusBadRequest, "id is required to subscribe")
+		return
+	}
+	sub := &wsSubscription{tasks: make(map[string]struct{})}
+	if req.Filter != nil {
+		sub.filter = *req.Filter
+	}
+
+	c.mu.Lock()
+	if _, ok := c.subs[req.ID]; ok {
+		c.mu.Unlock()
+		c.replyError(req.ID, http.StatusConflict, fmt.Sprintf("subscription %q already exists", req.ID))
+		return
+	}
+	if len(c.subs) >= wsMaxSubscriptions {
+		c.mu.Unlock()
+		c.replyError(req.ID, http.StatusBadRequest, fmt.Sprintf("at most %d subscriptions are allowed per connection", wsMaxSubscriptions))
+		return
+	}
+	c.subs[req.ID] = sub
+	watch := !c.watching
+	c.watching = true
+	c.mu.Unlock()
+
+	if !watch {
+		c.enqueue(wsMessage{ID: req.ID, Type: "result", Subscription: req.ID})
+		return
+	}
+
+	stream, err := c.taskClient.WatchTasks(c.ctx, req.AfterSequence)
+	if err != nil {
+		c.mu.Lock()
+		delete(c.subs, req.ID)
+		c.watching = false
+		c.mu.Unlock()
+		c.replyGrpcError(req.ID, err, "Failed to watch tasks")
+		return
+	}
+	// Reply before relaying, so that events never precede the result.
+	c.enqueue(wsMessage{ID: req.ID, Type: "result", Subscription: req.ID})
+	c.wg.Add(1)
+	go c.relay(stream)
+}
+
+// relay delivers the events of stream to the matching subscriptions until
+// the connection is closed. When the resume point has expired it sends a
+// reset and watches the live events instead.
+func (c *wsConn) relay(stream services.TaskEventStream) {
+	defer c.wg.Done()
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			if status.Code(err) == codes.OutOfRange {
+				c.enqueue(wsMessage{Type: "reset"})
+				stream, err = c.taskClient.WatchTasks(c.ctx, 0)
+				if err == nil {
+					continue
+				}
+			}
+			if c.ctx.Err() == nil {
+				c.logger.ErrorContext(c.ctx, "WebSocket event stream failed", "error", err)
+				c.close(websocket.CloseTryAgainLater, "event stream ended")
+			}
+			return
+		}
+
+		c.mu.Lock()
+		var matched []string
+		for id, sub := range c.subs {
+			if sub.track(event) {
+				matched = append(matched, id)
+			}
+		}
+		c.mu.Unlock()
+		if len(matched) == 0 {
+			continue
+		}
+		sort.Strings(matched)
+		c.enqueue(wsMessage{
+			Type:          "event",
+			Subscriptions: matched,
+			Event:         eventName(event.Type),
+			Sequence:      event.Sequence,
+			Task:          event.Task,
+			Time:          event.Time,
+		})
+	}
+}
+
+// mutate creates, toggles or updates a task and replies with the result.
+// Connections without the write scope may only subscribe.
+func (c *wsConn) mutate(req wsRequest) {
+	if !auth.HasScope(c.ctx, auth.ScopeWrite) {
+		c.replyError(req.ID, http.StatusForbidden, "This request needs the write scope")
+		return
+	}
+	if req.Type != "create" && req.TaskID == "" {
+		c.replyError(req.ID, http.StatusBadRequest, "task_id is required")
+		return
+	}
+	if req.Type != "toggle" && len(req.Task) == 0 {
+		c.replyError(req.ID, http.StatusBadRequest, "task is required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, httputil.DefaultTimeout)
+	defer cancel()
+
+	var task *pb.Task
+	var err error
+	switch req.Type {
+	case "create":
+		var body createTaskBody
+		if err := json.Unmarshal(req.Task, &body); err != nil {
+			c.replyError(req.ID, http.StatusBadRequest, "Invalid task")
+			return
+		}
+		createReq, err := body.request("")
+		if err != nil {
+			c.replyError(req.ID, http.StatusBadRequest, err.Error())
+			return
+		}
+		task, err = c.taskClient.CreateTask(ctx, createReq)
+		if err != nil {
+			c.replyGrpcError(req.ID, err, "Failed to create task")
+			return
+		}
+		c.logger.InfoContext(c.ctx, "Task created via WebSocket", "id", task.Id, "title", task.Title)
+	case "toggle":
+		task, err = c.taskClient.ToggleTaskCompletion(ctx, req.TaskID)
+		if err != nil {
+			c.replyGrpcError(req.ID, err, "Failed to toggle task")
+			return
+		}
+		c.logger.InfoContext(c.ctx, "Task completion toggled via WebSocket", "id", task.Id, "completed", task.Completed)
+	case "update":
+		var body updateTaskBody
+		if err := json.Unmarshal(req.Task, &body); err != nil {
+			c.replyError(req.ID, http.StatusBadRequest, "Invalid task")
+			return
+		}
+		changes, paths, err := body.changes(req.TaskID)
+		if err != nil {
+			c.replyError(req.ID, http.StatusBadRequest, err.Error())
+			return
+		}
+		task, err = c.taskClient.UpdateTask(ctx, changes, paths)
+		if err != nil {
+			c.replyGrpcError(req.ID, err, "Failed to update task")
+			return
+		}
+		c.logger.InfoContext(c.ctx, "Task updated via WebSocket", "id", task.Id, "fields", paths)
+	}
+	c.enqueue(wsMessage{ID: req.ID, Type: "result", Task: task})
+}
+
+// replyError sends an error reply to request id.
+func (c *wsConn) replyError(id string, statusCode int, message string) {
+	c.logger.WarnContext(c.ctx, "WebSocket request error", "id", id, "status_code", statusCode, "message", message)
+	c.enqueue(wsMessage{ID: id, Type: "error", Error: &wsError{Status: statusCode, Message: message}})
+}
+
+// replyGrpcError sends an error reply for a failed gRPC call to request id.
+func (c *wsConn) replyGrpcError(id string, err error, defaultMessage string) {
+	if c.ctx.Err() != nil {
+		return // the connection is gone
+	}
+	statusCode, message := httputil.GrpcErrorStatus(err, defaultMessage)
+	c.logger.ErrorContext(c.ctx, "WebSocket gRPC call failed", "id", id, "error", err)
+	c.replyError(id, statusCode, message)
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/sahidhossen/todo/api-gateway/internal/auth"
+	"github.com/sahidhossen/todo/api-gateway/mocks"
+	pb "github.com/sahidhossen/todo/proto/task_service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// dialTestWebSocket serves handler.ServeWebSocket and connects to it.
+func dialTestWebSocket(t *testing.T, handler *Handler) *websocket.Conn {
+	t.Helper()
+	return dialTestWebSocketWithScopes(t, handler, auth.AllScopes)
+}
+
+// dialTestWebSocketWithScopes connects as a client holding scopes.
+func dialTestWebSocketWithScopes(t *testing.T, handler *Handler, scopes []string) *websocket.Conn {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeWebSocket(w, r.WithContext(auth.WithScopes(r.Context(), scopes)))
+	}))
+	t.Cleanup(srv.Close)
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func readWSMessage(t *testing.T, conn *websocket.Conn) wsMessage {
+	t.Helper()
+	var msg wsMessage
+	require.NoError(t, conn.ReadJSON(&msg))
+	return msg
+}
+
+func TestServeWebSocket_SubscribeFiltersEvents(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := newEventHandler(mockTaskClient)
+
+	stream := newFakeEventStream(nil,
+		&pb.TaskEvent{Sequence: 11, Type: pb.TaskEvent_TYPE_CREATED, Task: &pb.Task{Id: "task1", ProjectId: "home"}},
+		&pb.TaskEvent{Sequence: 12, Type: pb.TaskEvent_TYPE_CREATED, Task: &pb.Task{Id: "task2", ProjectId: "work"}},
+		&pb.TaskEvent{Sequence: 13, Type: pb.TaskEvent_TYPE_UPDATED, Task: &pb.Task{Id: "task1", ProjectId: "work"}},
+		&pb.TaskEvent{Sequence: 14, Type: pb.TaskEvent_TYPE_UPDATED, Task: &pb.Task{Id: "task1", ProjectId: "garden"}},
+	)
+	t.Cleanup(func() { close(stream.done) })
+	mockTaskClient.On("WatchTasks", mock.Anything, uint64(10)).Return(stream, nil).Once()
+
+	conn := dialTestWebSocket(t, handler)
+	require.NoError(t, conn.WriteJSON(map[string]any{
+		"id": "home-tasks", "type": "subscribe", "filter": map[string]any{"project_id": "home"}, "after_sequence": 10,
+	}))
+
+	ack := readWSMessage(t, conn)
+	assert.Equal(t, "result", ack.Type)
+	assert.Equal(t, "home-tasks", ack.ID)
+
+	created := readWSMessage(t, conn)
+	assert.Equal(t, "event", created.Type)
+	assert.Equal(t, "created", created.Event)
+	assert.Equal(t, uint64(11), created.Sequence)
+	assert.Equal(t, []string{"home-tasks"}, created.Subscriptions)
+
+	// The task leaving the project is delivered once, later changes are not.
+	moved := readWSMessage(t, conn)
+	assert.Equal(t, uint64(13), moved.Sequence)
+	assert.Equal(t, "work", moved.Task.ProjectId)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestServeWebSocket_Mutations(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := newEventHandler(mockTaskClient)
+
+	mockTaskClient.On("CreateTask", mock.AnythingOfType("*context.timerCtx"), mock.MatchedBy(func(req *pb.CreateTaskRequest) bool {
+		return req.Title == "Buy milk" && req.Priority == pb.Priority_PRIORITY_HIGH
+	})).Return(&pb.Task{Id: "task1", Title: "Buy milk"}, nil).Once()
+	mockTaskClient.On("ToggleTaskCompletion", mock.AnythingOfType("*context.timerCtx"), "missing").
+		Return(nil, status.Error(codes.NotFound, "task with ID missing not found")).Once()
+	mockTaskClient.On("UpdateTask", mock.AnythingOfType("*context.timerCtx"), &pb.Task{Id: "task1", Title: "Buy oat milk"}, []string{"title"}).
+		Return(&pb.Task{Id: "task1", Title: "Buy oat milk"}, nil).Once()
+
+	conn := dialTestWebSocket(t, handler)
+	require.NoError(t, conn.WriteJSON(map[string]any{"id": "c1", "type": "create", "task": map[string]any{"title": "Buy milk", "priority": "high"}}))
+	require.NoError(t, conn.WriteJSON(map[string]any{"id": "c2", "type": "toggle", "task_id": "missing"}))
+	require.NoError(t, conn.WriteJSON(map[string]any{"id": "c3", "type": "update", "task_id": "task1", "task": map[string]any{"title": ""}}))
+	require.NoError(t, conn.WriteJSON(map[string]any{"id": "c4", "type": "update", "task_id": "task1", "task": map[string]any{"title": "Buy oat milk"}}))
+	require.NoError(t, conn.WriteJSON(map[string]any{"id": "c5", "type": "delete"}))
+
+	created := readWSMessage(t, conn)
+	assert.Equal(t, "c1", created.ID)
+	assert.Equal(t, "result", created.Type)
+	assert.Equal(t, "task1", created.Task.Id)
+
+	notFound := readWSMessage(t, conn)
+	assert.Equal(t, "c2", notFound.ID)
+	assert.Equal(t, "error", notFound.Type)
+	assert.Equal(t, http.StatusNotFound, notFound.Error.Status)
+
+	invalid := readWSMessage(t, conn)
+	assert.Equal(t, "c3", invalid.ID)
+	assert.Equal(t, http.StatusBadRequest, invalid.Error.Status)
+	assert.Equal(t, "Title cannot be empty", invalid.Error.Message)
+
+	updated := readWSMessage(t, conn)
+	assert.Equal(t, "c4", updated.ID)
+	assert.Equal(t, "Buy oat milk", updated.Task.Title)
+
+	unknown := readWSMessage(t, conn)
+	assert.Equal(t, "c5", unknown.ID)
+	assert.Equal(t, http.StatusBadRequest, unknown.Error.Status)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestServeWebSocket_ReadOnlyMutationRefused(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := newEventHandler(mockTaskClient)
+
+	conn := dialTestWebSocketWithScopes(t, handler, []string{auth.ScopeRead})
+	require.NoError(t, conn.WriteJSON(map[string]any{"id": "c1", "type": "toggle", "task_id": "task1"}))
+
+	refused := readWSMessage(t, conn)
+	assert.Equal(t, "c1", refused.ID)
+	assert.Equal(t, http.StatusForbidden, refused.Error.Status)
+	mockTaskClient.AssertNotCalled(t, "ToggleTaskCompletion", mock.Anything, mock.Anything)
+}
+
+func TestServeWebSocket_ClosedOnShutdown(t *testing.T) {
+	mockTaskClient := new(mocks.MockTaskService)
+	handler := newEventHandler(mockTaskClient)
+
+	conn := dialTestWebSocket(t, handler)
+	require.NoError(t, conn.WriteJSON(map[string]any{"id": "c1", "type": "unsubscribe", "subscription": "none"}))
+	assert.Equal(t, "error", readWSMessage(t, conn).Type)
+
+	require.NoError(t, handler.CloseStreams(context.Background()))
+
+	_, _, err := conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway), "got %v", err)
+
+	rr := httptest.NewRecorder()
+	handler.ServeWebSocket(rr, httptest.NewRequest(http.MethodGet, "/ws", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code, "new connections are refused")
+}
@@ -2,37 +2,112 @@ package httputil
 
 import (
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
+	"strings"
 
+	"github.com/sahidhossen/todo/api-gateway/internal/requestid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// ProblemContentType is the media type of error responses.
+const ProblemContentType = "application/problem+json"
+
+// problemTypePrefix starts the type URIs of problems with a reason code,
+// followed by the code in lower case with dashes, such as task-not-found.
+const problemTypePrefix = "urn:todo:problem:"
+
+// ErrorResponse is the body of error responses, an RFC 7807 problem details
+// document. The members after Instance are extensions: Message repeats
+// Detail for clients written before problem details.
 type ErrorResponse struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
 	Message string `json:"message"`
+	// RequestID is the X-Request-ID of the failed request, for bug reports.
+	RequestID string `json:"request_id,omitempty"`
+	// Reason is the storage service's reason code, such as TASK_NOT_FOUND.
+	Reason string `json:"reason,omitempty"`
+	// Resource names the missing or conflicting resource.
+	Resource *ResourceRef `json:"resource,omitempty"`
+	// Errors are the invalid fields of the request, for forms to highlight.
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// ResourceRef names a resource in an ErrorResponse.
+type ResourceRef struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+}
+
+// FieldError is an invalid request field, named as in the request body or
+// query string, such as "title". Request validation returns it as an error
+// and HandleError lists it in the response.
+type FieldError struct {
+	Field  string `json:"field"`
+	Detail string `json:"detail"`
+}
+
+func (e *FieldError) Error() string {
+	return e.Detail
 }
 
-// HandleError is a generic helper to send an HTTP JSON error response.
+// InvalidField returns a FieldError for field.
+func InvalidField(field, detail string) error {
+	return &FieldError{Field: field, Detail: detail}
+}
+
+// HandleError is a generic helper to send an HTTP problem details error
+// response. A FieldError in err's chain is listed in the response.
 func HandleError(w http.ResponseWriter, r *http.Request, logger *slog.Logger, err error, clientMessage string, statusCode int) {
-	logger.Error("API request error",
+	problem := newProblem(r, statusCode, clientMessage)
+	var fieldErr *FieldError
+	if errors.As(err, &fieldErr) {
+		problem.Errors = []FieldError{*fieldErr}
+	}
+	writeProblem(w, r, logger, err, problem)
+}
+
+// newProblem returns the problem details of an error without a reason code.
+func newProblem(r *http.Request, statusCode int, clientMessage string) *ErrorResponse {
+	return &ErrorResponse{
+		Type:      "about:blank",
+		Title:     http.StatusText(statusCode),
+		Status:    statusCode,
+		Detail:    clientMessage,
+		Instance:  r.URL.Path,
+		Message:   clientMessage,
+		RequestID: requestid.FromContext(r.Context()),
+	}
+}
+
+// writeProblem logs err and sends problem.
+func writeProblem(w http.ResponseWriter, r *http.Request, logger *slog.Logger, err error, problem *ErrorResponse) {
+	logger.ErrorContext(r.Context(), "API request error",
 		"error", err,
-		"client_message", clientMessage,
-		"status_code", statusCode,
+		"client_message", problem.Detail,
+		"status_code", problem.Status,
 		"path", r.URL.Path,
 		"method", r.Method,
 	)
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	if err := json.NewEncoder(w).Encode(ErrorResponse{Message: clientMessage}); err != nil {
-		logger.Error("Failed to encode error response", "error", err, "path", r.URL.Path)
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+	if err := json.NewEncoder(w).Encode(problem); err != nil {
+		logger.ErrorContext(r.Context(), "Failed to encode error response", "error", err, "path", r.URL.Path)
 	}
 }
 
 // HandleSuccess is a generic helper to send an HTTP JSON success response.
 func HandleSuccess(w http.ResponseWriter, r *http.Request, logger *slog.Logger, data interface{}, statusCode int) {
-	logger.Info("API request successful",
+	logger.InfoContext(r.Context(), "API request successful",
 		"status_code", statusCode,
 		"path", r.URL.Path,
 		"method", r.Method,
@@ -42,37 +117,91 @@ func HandleSuccess(w http.ResponseWriter, r *http.Request, logger *slog.Logger,
 	w.WriteHeader(statusCode)
 
 	if err := json.NewEncoder(w).Encode(data); err != nil {
-		logger.Error("Failed to encode success response", "error", err, "path", r.URL.Path)
+		logger.ErrorContext(r.Context(), "Failed to encode success response", "error", err, "path", r.URL.Path)
 		http.Error(w, "Internal Server Error: Failed to encode response", http.StatusInternalServerError)
 	}
 }
 
-// HandleGrpcError maps gRPC status codes to appropriate HTTP status codes and calls HandleError.
+// HandleNoContent sends an empty HTTP 204 No Content response.
+func HandleNoContent(w http.ResponseWriter, r *http.Request, logger *slog.Logger) {
+	logger.InfoContext(r.Context(), "API request successful",
+		"status_code", http.StatusNoContent,
+		"path", r.URL.Path,
+		"method", r.Method,
+	)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleGrpcError maps gRPC status codes to appropriate HTTP status codes and
+// sends the error like HandleError. The error details of client errors, see
+// addDetails, fill in the problem's type, reason, resource and field errors.
 func HandleGrpcError(w http.ResponseWriter, r *http.Request, logger *slog.Logger, grpcErr error, defaultClientMessage string) {
+	statusCode, clientMessage := GrpcErrorStatus(grpcErr, defaultClientMessage)
+	problem := newProblem(r, statusCode, clientMessage)
+	if st, ok := status.FromError(grpcErr); ok && statusCode < http.StatusInternalServerError {
+		addDetails(problem, st.Details())
+	}
+	writeProblem(w, r, logger, grpcErr, problem)
+}
+
+// addDetails adds google.rpc error details to problem: an ErrorInfo's reason
+// code, a ResourceInfo and the field violations of a BadRequest.
+func addDetails(problem *ErrorResponse, details []any) {
+	for _, detail := range details {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			problem.Reason = d.Reason
+			problem.Type = problemTypePrefix + strings.ToLower(strings.ReplaceAll(d.Reason, "_", "-"))
+		case *errdetails.ResourceInfo:
+			problem.Resource = &ResourceRef{Type: d.ResourceType, Name: d.ResourceName}
+		case *errdetails.BadRequest:
+			for _, v := range d.FieldViolations {
+				problem.Errors = append(problem.Errors, FieldError{Field: bodyField(v.Field), Detail: v.Description})
+			}
+		}
+	}
+}
+
+// bodyField returns the request body field for the path of a gRPC request
+// field. The bodies hold the fields of the task or project being updated at
+// the top level, where the request has them under task or project.
+func bodyField(path string) string {
+	for _, prefix := range []string{"task.", "project."} {
+		if field, ok := strings.CutPrefix(path, prefix); ok {
+			return field
+		}
+	}
+	return path
+}
+
+// GrpcErrorStatus returns the HTTP status code and client message for a gRPC
+// error. Errors without a known status get a 500 with defaultClientMessage.
+func GrpcErrorStatus(grpcErr error, defaultClientMessage string) (int, string) {
 	st, ok := status.FromError(grpcErr)
 	if !ok {
-		HandleError(w, r, logger, grpcErr, defaultClientMessage, http.StatusInternalServerError)
-		return
+		return http.StatusInternalServerError, defaultClientMessage
 	}
 
 	switch st.Code() {
 	case codes.NotFound:
-		HandleError(w, r, logger, grpcErr, st.Message(), http.StatusNotFound)
+		return http.StatusNotFound, st.Message()
 	case codes.InvalidArgument:
-		HandleError(w, r, logger, grpcErr, st.Message(), http.StatusBadRequest)
+		return http.StatusBadRequest, st.Message()
+	case codes.FailedPrecondition:
+		return http.StatusBadRequest, st.Message()
 	case codes.AlreadyExists:
-		HandleError(w, r, logger, grpcErr, st.Message(), http.StatusConflict)
+		return http.StatusConflict, st.Message()
 	case codes.PermissionDenied:
-		HandleError(w, r, logger, grpcErr, st.Message(), http.StatusForbidden)
+		return http.StatusForbidden, st.Message()
 	case codes.Unauthenticated:
-		HandleError(w, r, logger, grpcErr, st.Message(), http.StatusUnauthorized)
+		return http.StatusUnauthorized, st.Message()
 	case codes.Unavailable:
-		HandleError(w, r, logger, grpcErr, st.Message(), http.StatusServiceUnavailable)
+		return http.StatusServiceUnavailable, st.Message()
 	case codes.DeadlineExceeded:
-		HandleError(w, r, logger, grpcErr, st.Message(), http.StatusGatewayTimeout)
+		return http.StatusGatewayTimeout, st.Message()
 	case codes.Canceled:
-		HandleError(w, r, logger, grpcErr, st.Message(), http.StatusRequestTimeout)
+		return http.StatusRequestTimeout, st.Message()
 	default:
-		HandleError(w, r, logger, grpcErr, defaultClientMessage, http.StatusInternalServerError)
+		return http.StatusInternalServerError, defaultClientMessage
 	}
 }
@@ -0,0 +1,92 @@
+package httputil
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func decodeProblem(t *testing.T, rr *httptest.ResponseRecorder) ErrorResponse {
+	t.Helper()
+	assert.Equal(t, ProblemContentType, rr.Header().Get("Content-Type"))
+	var problem ErrorResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&problem))
+	return problem
+}
+
+func TestHandleError(t *testing.T) {
+	rr := httptest.NewRecorder()
+	err := InvalidField("title", "Title cannot be empty")
+	HandleError(rr, httptest.NewRequest(http.MethodPost, "/tasks", nil), slog.New(slog.DiscardHandler), err, err.Error(), http.StatusBadRequest)
+
+	problem := decodeProblem(t, rr)
+	assert.Equal(t, "about:blank", problem.Type)
+	assert.Equal(t, "Bad Request", problem.Title)
+	assert.Equal(t, http.StatusBadRequest, problem.Status)
+	assert.Equal(t, "Title cannot be empty", problem.Detail)
+	assert.Equal(t, problem.Detail, problem.Message)
+	assert.Equal(t, "/tasks", problem.Instance)
+	assert.Equal(t, []FieldError{{Field: "title", Detail: "Title cannot be empty"}}, problem.Errors)
+}
+
+func TestHandleGrpcError_Details(t *testing.T) {
+	st, err := status.New(codes.InvalidArgument, "title cannot be empty").WithDetails(
+		&errdetails.ErrorInfo{Reason: "INVALID_ARGUMENT", Domain: "storage-service.todo"},
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "task.title", Description: "title cannot be empty"},
+		}},
+	)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	HandleGrpcError(rr, httptest.NewRequest(http.MethodPatch, "/tasks/task-1", nil), slog.New(slog.DiscardHandler), st.Err(), "Failed to update task")
+
+	problem := decodeProblem(t, rr)
+	assert.Equal(t, http.StatusBadRequest, problem.Status)
+	assert.Equal(t, "urn:todo:problem:invalid-argument", problem.Type)
+	assert.Equal(t, "INVALID_ARGUMENT", problem.Reason)
+	assert.Equal(t, []FieldError{{Field: "title", Detail: "title cannot be empty"}}, problem.Errors, "fields are named as in the body")
+}
+
+func TestHandleGrpcError_Resource(t *testing.T) {
+	st, err := status.New(codes.NotFound, "task with ID task-1 not found").WithDetails(
+		&errdetails.ErrorInfo{Reason: "TASK_NOT_FOUND"},
+		&errdetails.ResourceInfo{ResourceType: "task", ResourceName: "task-1"},
+	)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	HandleGrpcError(rr, httptest.NewRequest(http.MethodGet, "/tasks/task-1", nil), slog.New(slog.DiscardHandler), st.Err(), "Failed to retrieve task")
+
+	problem := decodeProblem(t, rr)
+	assert.Equal(t, http.StatusNotFound, problem.Status)
+	assert.Equal(t, "urn:todo:problem:task-not-found", problem.Type)
+	assert.Equal(t, &ResourceRef{Type: "task", Name: "task-1"}, problem.Resource)
+}
+
+func TestHandleGrpcError_ServerErrorsHideDetails(t *testing.T) {
+	st, err := status.New(codes.Internal, "failed to save task: disk full").WithDetails(&errdetails.ErrorInfo{Reason: "DISK_FULL"})
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	HandleGrpcError(rr, httptest.NewRequest(http.MethodPost, "/tasks", nil), slog.New(slog.DiscardHandler), st.Err(), "Failed to create task")
+
+	problem := decodeProblem(t, rr)
+	assert.Equal(t, http.StatusInternalServerError, problem.Status)
+	assert.Equal(t, "about:blank", problem.Type)
+	assert.Equal(t, "Failed to create task", problem.Detail)
+	assert.Empty(t, problem.Reason)
+
+	rr = httptest.NewRecorder()
+	HandleGrpcError(rr, httptest.NewRequest(http.MethodPost, "/tasks", nil), slog.New(slog.DiscardHandler), errors.New("not a status"), "Failed to create task")
+	assert.Equal(t, http.StatusInternalServerError, decodeProblem(t, rr).Status)
+}
@@ -0,0 +1,134 @@
+// Package metrics collects the Prometheus metrics of the api-gateway: HTTP
+// requests by route and status, and gRPC calls to the storage service.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Metrics holds the collectors of the api-gateway in their own registry.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	grpcHandled  *prometheus.CounterVec
+	grpcDuration *prometheus.HistogramVec
+}
+
+// New returns Metrics with the HTTP and gRPC client collectors and the Go
+// runtime and process collectors registered.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "HTTP requests served, by method, route template and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Time taken to serve HTTP requests, by method, route template and status code.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		grpcHandled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_client_handled_total",
+			Help: "gRPC calls to the storage service completed, by method and status code.",
+		}, []string{"grpc_service", "grpc_method", "grpc_code"}),
+		grpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "grpc_client_handling_seconds",
+			Help:    "Time gRPC calls to the storage service took, by method.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"grpc_service", "grpc_method"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests, m.httpDuration, m.grpcHandled, m.grpcDuration,
+	)
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveRequest records a served HTTP request; it implements
+// middleware.RequestObserver.
+func (m *Metrics) ObserveRequest(method, route string, status int, duration time.Duration) {
+	code := strconv.Itoa(status)
+	m.httpRequests.WithLabelValues(method, route, code).Inc()
+	m.httpDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
+}
+
+// UnaryClientInterceptor records unary calls.
+func (m *Metrics) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		m.observeCall(method, start, err)
+		return err
+	}
+}
+
+// StreamClientInterceptor records streaming calls once they end, when the
+// client receives the end of the stream or an error.
+func (m *Metrics) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := time.Now()
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			m.observeCall(method, start, err)
+			return nil, err
+		}
+		return &observedStream{ClientStream: stream, done: func(err error) { m.observeCall(method, start, err) }}, nil
+	}
+}
+
+func (m *Metrics) observeCall(fullMethod string, start time.Time, err error) {
+	service, method := splitMethod(fullMethod)
+	m.grpcHandled.WithLabelValues(service, method, status.Code(err).String()).Inc()
+	m.grpcDuration.WithLabelValues(service, method).Observe(time.Since(start).Seconds())
+}
+
+// splitMethod splits a full method name, "/package.Service/Method", into
+// its service and method.
+func splitMethod(fullMethod string) (string, string) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return "unknown", fullMethod
+	}
+	return service, method
+}
+
+// observedStream is a grpc.ClientStream calling done once it ends.
+type observedStream struct {
+	grpc.ClientStream
+	done func(error)
+	once sync.Once
+}
+
+func (s *observedStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		end := err
+		if errors.Is(err, io.EOF) {
+			end = nil // the server finished the stream
+		}
+		s.once.Do(func() { s.done(end) })
+	}
+	return err
+}
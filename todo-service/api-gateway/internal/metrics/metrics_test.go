@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestObserveRequest(t *testing.T) {
+	m := New()
+
+	m.ObserveRequest(http.MethodGet, "/tasks/{id}", http.StatusOK, time.Millisecond)
+	m.ObserveRequest(http.MethodGet, "/tasks/{id}", http.StatusOK, time.Millisecond)
+	m.ObserveRequest(http.MethodGet, "/tasks/{id}", http.StatusNotFound, time.Millisecond)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.httpRequests.WithLabelValues("GET", "/tasks/{id}", "200")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.httpRequests.WithLabelValues("GET", "/tasks/{id}", "404")))
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	m := New()
+	interceptor := m.UnaryClientInterceptor()
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return status.Error(codes.Unavailable, "connection refused")
+	}
+
+	err := interceptor(context.Background(), "/task_service.TaskService/GetTask", nil, nil, nil, invoker)
+
+	assert.Error(t, err)
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.grpcHandled.WithLabelValues("task_service.TaskService", "GetTask", "Unavailable")))
+}
+
+// fakeClientStream ends after one message.
+type fakeClientStream struct {
+	grpc.ClientStream
+	sent bool
+}
+
+func (s *fakeClientStream) RecvMsg(m any) error {
+	if s.sent {
+		return io.EOF
+	}
+	s.sent = true
+	return nil
+}
+
+func TestStreamClientInterceptor(t *testing.T) {
+	m := New()
+	interceptor := m.StreamClientInterceptor()
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &fakeClientStream{}, nil
+	}
+
+	stream, err := interceptor(context.Background(), &grpc.StreamDesc{}, nil, "/task_service.TaskService/WatchTasks", streamer)
+	require.NoError(t, err)
+	require.NoError(t, stream.RecvMsg(nil))
+	assert.Equal(t, 0, testutil.CollectAndCount(m.grpcHandled), "the stream is still open")
+
+	assert.ErrorIs(t, stream.RecvMsg(nil), io.EOF)
+	assert.ErrorIs(t, stream.RecvMsg(nil), io.EOF)
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.grpcHandled.WithLabelValues("task_service.TaskService", "WatchTasks", "OK")))
+}
+
+func TestHandler(t *testing.T) {
+	m := New()
+	m.ObserveRequest(http.MethodPost, "/tasks", http.StatusCreated, time.Millisecond)
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `http_requests_total{method="POST",route="/tasks",status="201"} 1`)
+}
@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/sahidhossen/todo/api-gateway/internal/auth"
+	"github.com/sahidhossen/todo/api-gateway/internal/httputil"
+	"github.com/sahidhossen/todo/api-gateway/internal/services"
+)
+
+// Authenticator checks a bearer token and returns the user it acts for and
+// the scopes it grants. Refused tokens give errors wrapping
+// auth.ErrInvalidToken.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (string, []string, error)
+}
+
+// AuthOptions lists the paths AuthMiddleware treats specially.
+type AuthOptions struct {
+	// PublicPaths are served without a token.
+	PublicPaths []string
+	// QueryTokenPaths may pass the token in the access_token query parameter,
+	// for EventSource and WebSocket clients that cannot set headers.
+	QueryTokenPaths []string
+}
+
+// errMissingToken is logged when a request carries no token.
+var errMissingToken = errors.New("missing bearer token")
+
+// AuthMiddleware refuses requests without a valid bearer token with 401. The
+// token's user ID and scopes are put into the request context; the gRPC
+// client forwards the user ID to the storage service.
+func AuthMiddleware(authenticator Authenticator, opts AuthOptions, logger *slog.Logger) mux.MiddlewareFunc {
+	public := make(map[string]bool, len(opts.PublicPaths))
+	for _, path := range opts.PublicPaths {
+		public[path] = true
+	}
+	queryToken := make(map[string]bool, len(opts.QueryTokenPaths))
+	for _, path := range opts.QueryTokenPaths {
+		queryToken[path] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodOptions || public[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			token := bearerToken(r)
+			if token == "" && queryToken[r.URL.Path] {
+				token = r.URL.Query().Get("access_token")
+			}
+			if token == "" {
+				unauthorized(w, r, logger, errMissingToken, "Missing bearer token")
+				return
+			}
+			ctx, cancel := httputil.WithTimeout(r)
+			userID, scopes, err := authenticator.Authenticate(ctx, token)
+			cancel()
+			if errors.Is(err, auth.ErrInvalidToken) {
+				unauthorized(w, r, logger, err, "Invalid or expired token")
+				return
+			}
+			if err != nil {
+				httputil.HandleGrpcError(w, r, logger, err, "Failed to check token")
+				return
+			}
+
+			ctx = services.WithUserID(r.Context(), userID)
+			next.ServeHTTP(w, r.WithContext(auth.WithScopes(ctx, scopes)))
+		})
+	}
+}
+
+// bearerToken returns the token in the Authorization header, if any.
+func bearerToken(r *http.Request) string {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// unauthorized sends a 401 response asking for a bearer token.
+func unauthorized(w http.ResponseWriter, r *http.Request, logger *slog.Logger, err error, clientMessage string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="todo"`)
+	httputil.HandleError(w, r, logger, err, clientMessage, http.StatusUnauthorized)
+}
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sahidhossen/todo/api-gateway/internal/auth"
+	"github.com/sahidhossen/todo/api-gateway/internal/httputil"
+	"github.com/sahidhossen/todo/api-gateway/internal/services"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeAuthenticator accepts the token "good" for user-1 with the read scope.
+// The token "down" fails as if the storage service were unavailable.
+type fakeAuthenticator struct{}
+
+func (fakeAuthenticator) Authenticate(ctx context.Context, token string) (string, []string, error) {
+	switch token {
+	case "good":
+		return "user-1", []string{auth.ScopeRead}, nil
+	case "down":
+		return "", nil, status.Error(codes.Unavailable, "connection refused")
+	}
+	return "", nil, fmt.Errorf("%w: bad token", auth.ErrInvalidToken)
+}
+
+func serveAuth(r *http.Request) (*httptest.ResponseRecorder, string) {
+	var userID string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, _ = services.UserIDFromContext(r.Context())
+		if userID != "" && !auth.HasScope(r.Context(), auth.ScopeRead) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	opts := AuthOptions{PublicPaths: []string{"/auth/login"}, QueryTokenPaths: []string{"/events"}}
+	rr := httptest.NewRecorder()
+	AuthMiddleware(fakeAuthenticator{}, opts, slog.New(slog.DiscardHandler))(next).ServeHTTP(rr, r)
+	return rr, userID
+}
+
+func TestAuthMiddleware_ValidToken(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+	req.Header.Set("Authorization", "Bearer good")
+
+	rr, userID := serveAuth(req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "user-1", userID)
+}
+
+func TestAuthMiddleware_Unauthorized(t *testing.T) {
+	for name, header := range map[string]string{
+		"missing":      "",
+		"wrong scheme": "Basic good",
+		"invalid":      "Bearer bad",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/tasks?access_token=good", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+
+		rr, _ := serveAuth(req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code, name)
+		assert.Contains(t, rr.Header().Get("WWW-Authenticate"), "Bearer", name)
+		var body httputil.ErrorResponse
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&body), name)
+		assert.NotEmpty(t, body.Message, name)
+	}
+}
+
+func TestAuthMiddleware_StorageUnavailable(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+	req.Header.Set("Authorization", "Bearer down")
+
+	rr, _ := serveAuth(req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+}
+
+func TestAuthMiddleware_PublicAndQueryTokenPaths(t *testing.T) {
+	rr, userID := serveAuth(httptest.NewRequest(http.MethodPost, "/auth/login", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Empty(t, userID)
+
+	rr, _ = serveAuth(httptest.NewRequest(http.MethodOptions, "/tasks", nil))
+	assert.Equal(t, http.StatusOK, rr.Code, "preflights carry no credentials")
+
+	rr, userID = serveAuth(httptest.NewRequest(http.MethodGet, "/events?access_token=good", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "user-1", userID)
+}
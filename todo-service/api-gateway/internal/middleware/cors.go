@@ -12,13 +12,13 @@ func CORSMiddleware(logger *slog.Logger) func(http.Handler) http.Handler {
 			// Set common CORS headers for all responses
 			w.Header().Set("Access-Control-Allow-Origin", "*") // For development, "*" is fine. In prod, specify client origins.
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS, PATCH")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Accept")
-			w.Header().Set("Access-Control-Expose-Headers", "Content-Length")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Accept, Authorization, Last-Event-ID, X-Request-ID")
+			w.Header().Set("Access-Control-Expose-Headers", "Content-Length, X-Request-ID")
 			w.Header().Set("Access-Control-Max-Age", "86400")
 
 			// Handle preflight OPTIONS requests
 			if r.Method == http.MethodOptions {
-				logger.Info("CORS: Handling preflight OPTIONS request", "path", r.URL.Path, "origin", r.Header.Get("Origin"))
+				logger.InfoContext(r.Context(), "CORS: Handling preflight OPTIONS request", "path", r.URL.Path, "origin", r.Header.Get("Origin"))
 				w.WriteHeader(http.StatusNoContent)
 				return
 			}
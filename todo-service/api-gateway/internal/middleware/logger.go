@@ -1,25 +1,109 @@
 package middleware
 
 import (
+	"bufio"
 	"log/slog"
+	"net"
 	"net/http"
 	"time"
 
 	"github.com/gorilla/mux"
 )
 
-// LoggingMiddleware is a simple example of a middleware function.
-func LoggingMiddleware(logger *slog.Logger) mux.MiddlewareFunc {
+// RequestObserver is told about every request LoggingMiddleware sees, for
+// metrics. route is the path template of the matched mux route.
+type RequestObserver interface {
+	ObserveRequest(method, route string, status int, duration time.Duration)
+}
+
+// LoggingMiddleware logs every request with its route, status and duration,
+// and reports them to observer unless it is nil.
+func LoggingMiddleware(logger *slog.Logger, observer RequestObserver) mux.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
-			next.ServeHTTP(w, r)
-			logger.Info("HTTP Request",
+			rec := &statusRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r)
+			duration := time.Since(start)
+
+			route := routeTemplate(r)
+			logger.InfoContext(r.Context(), "HTTP Request",
 				"method", r.Method,
-				"uri", r.RequestURI,
+				"uri", loggedURI(r),
+				"route", route,
 				"protocol", r.Proto,
-				"duration", time.Since(start),
+				"status", rec.statusCode(),
+				"duration", duration,
 			)
+			if observer != nil {
+				observer.ObserveRequest(r.Method, route, rec.statusCode(), duration)
+			}
 		})
 	}
 }
+
+// loggedURI returns the request URI with the value of the access_token query
+// parameter replaced, so that tokens passed in URLs do not end up in logs.
+func loggedURI(r *http.Request) string {
+	query := r.URL.Query()
+	if !query.Has("access_token") {
+		return r.RequestURI
+	}
+	query.Set("access_token", "REDACTED")
+	u := *r.URL
+	u.RawQuery = query.Encode()
+	return u.RequestURI()
+}
+
+// routeTemplate returns the path template of the route r matched, so that
+// requests for different tasks share one label.
+func routeTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+	return "unmatched"
+}
+
+// statusRecorder is an http.ResponseWriter remembering the status code of
+// the response. It passes Flush and SetWriteDeadline on through Unwrap, and
+// Hijack for WebSocket upgrades.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	if r.status == 0 {
+		r.status = code
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := http.NewResponseController(r.ResponseWriter).Hijack()
+	if err == nil && r.status == 0 {
+		r.status = http.StatusSwitchingProtocols
+	}
+	return conn, rw, err
+}
+
+// statusCode returns the status sent, 200 when the handler wrote nothing.
+func (r *statusRecorder) statusCode() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
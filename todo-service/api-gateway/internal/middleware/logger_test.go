@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type observed struct {
+	method, route string
+	status        int
+}
+
+type recordingObserver struct {
+	requests []observed
+}
+
+func (o *recordingObserver) ObserveRequest(method, route string, status int, duration time.Duration) {
+	o.requests = append(o.requests, observed{method, route, status})
+}
+
+func TestLoggingMiddleware_ObservesRouteAndStatus(t *testing.T) {
+	observer := &recordingObserver{}
+	router := mux.NewRouter()
+	router.Use(LoggingMiddleware(slog.New(slog.DiscardHandler), observer))
+	router.HandleFunc("/tasks/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}).Methods(http.MethodGet)
+	router.HandleFunc("/tasks", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("[]"))
+	}).Methods(http.MethodGet)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/tasks/task-1", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/tasks/task-2", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/tasks", nil))
+
+	assert.Equal(t, []observed{
+		{http.MethodGet, "/tasks/{id}", http.StatusNotFound},
+		{http.MethodGet, "/tasks/{id}", http.StatusNotFound},
+		{http.MethodGet, "/tasks", http.StatusOK},
+	}, observer.requests)
+}
+
+func TestLoggingMiddleware_KeepsHijacker(t *testing.T) {
+	// The handler runs on the server's goroutine and reports back over hijacked.
+	hijacked := make(chan bool, 1)
+	handler := LoggingMiddleware(slog.New(slog.DiscardHandler), nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			conn.Close()
+		}
+		hijacked <- err == nil
+	}))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err == nil {
+		resp.Body.Close()
+	}
+	select {
+	case ok := <-hijacked:
+		require.True(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("handler did not run")
+	}
+}
+
+func TestLoggingMiddleware_RedactsAccessToken(t *testing.T) {
+	var logs bytes.Buffer
+	router := mux.NewRouter()
+	router.Use(LoggingMiddleware(slog.New(slog.NewTextHandler(&logs, nil)), nil))
+	router.HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {}).Methods(http.MethodGet)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/events?last_event_id=7&access_token=secret-jwt", nil))
+
+	assert.NotContains(t, logs.String(), "secret-jwt")
+	assert.Contains(t, logs.String(), "access_token=REDACTED")
+	assert.Contains(t, logs.String(), "last_event_id=7")
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sahidhossen/todo/api-gateway/internal/httputil"
+	"github.com/sahidhossen/todo/api-gateway/internal/requestid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	var seen string
+	handler := RequestIDMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = requestid.FromContext(r.Context())
+		httputil.HandleError(w, r, slog.New(slog.DiscardHandler), errors.New("boom"), "Something failed", http.StatusInternalServerError)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+	req.Header.Set(requestid.Header, "client-req-1")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, "client-req-1", seen, "valid client IDs are kept")
+	assert.Equal(t, "client-req-1", rr.Header().Get(requestid.Header))
+	var body httputil.ErrorResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+	assert.Equal(t, "client-req-1", body.RequestID, "error responses quote the ID")
+
+	for _, header := range []string{"", "not valid"} {
+		req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+		req.Header.Set(requestid.Header, header)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.True(t, requestid.Valid(seen), "an ID is generated for %q", header)
+		assert.NotEqual(t, header, seen)
+		assert.Equal(t, seen, rr.Header().Get(requestid.Header))
+	}
+}
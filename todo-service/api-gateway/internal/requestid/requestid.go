@@ -0,0 +1,82 @@
+// Package requestid carries the ID of the HTTP request a call serves. The
+// gateway takes it from the X-Request-ID header or generates one, returns
+// it in the response and sends it to the storage service in the gRPC
+// metadata, so log lines of both services can be matched up.
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/sahidhossen/todo/proto/ctxlog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Header is the HTTP header carrying the request ID, both ways.
+const Header = "X-Request-ID"
+
+// MetadataKey is the gRPC metadata key the storage service reads the
+// request ID from.
+const MetadataKey = "x-request-id"
+
+// maxLength bounds the length of request IDs accepted from clients.
+const maxLength = 128
+
+// New returns a random request ID.
+func New() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Valid reports whether id may be used as a request ID: 1 to 128 letters,
+// digits and the characters - _ . : which need no escaping in logs.
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// NewContext returns a copy of ctx carrying the request ID.
+func NewContext(ctx context.Context, id string) context.Context {
+	return ctxlog.WithRequestID(ctx, id)
+}
+
+// FromContext returns the request ID carried by ctx, or "" when there is none.
+func FromContext(ctx context.Context) string {
+	return ctxlog.RequestID(ctx)
+}
+
+// withMetadata returns ctx with the request ID it carries, if any, added to
+// the outgoing gRPC metadata.
+func withMetadata(ctx context.Context) context.Context {
+	if id := FromContext(ctx); id != "" {
+		return metadata.AppendToOutgoingContext(ctx, MetadataKey, id)
+	}
+	return ctx
+}
+
+// UnaryClientInterceptor sends the request ID of unary calls.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(withMetadata(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor sends the request ID of streaming calls.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(withMetadata(ctx), desc, cc, method, opts...)
+	}
+}
@@ -0,0 +1,37 @@
+package requestid
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestValid(t *testing.T) {
+	assert.True(t, Valid(New()))
+	assert.True(t, Valid("req_1.a:b-c"))
+	assert.False(t, Valid(""))
+	assert.False(t, Valid("has space"))
+	assert.False(t, Valid("line\nbreak"))
+	assert.False(t, Valid(strings.Repeat("a", maxLength+1)))
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	var sent []string
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		sent = md.Get(MetadataKey)
+		return nil
+	}
+	interceptor := UnaryClientInterceptor()
+
+	require.NoError(t, interceptor(NewContext(context.Background(), "req-1"), "/m", nil, nil, nil, invoker))
+	assert.Equal(t, []string{"req-1"}, sent)
+
+	require.NoError(t, interceptor(context.Background(), "/m", nil, nil, nil, invoker))
+	assert.Empty(t, sent)
+}
@@ -12,11 +12,13 @@ import (
 
 // HTTPService represents the HTTP server for the API Gateway.
 type HTTPService struct {
-	srv    *http.Server
-	logger *slog.Logger
+	srv        *http.Server
+	logger     *slog.Logger
+	onShutdown []func(context.Context) error
 }
 
-// NewHTTPService creates a new HTTPService.
+// NewHTTPService creates a new HTTPService. Streaming handlers such as the
+// event stream lift the WriteTimeout for their own responses.
 func NewHTTPService(port string, router *mux.Router, logger *slog.Logger) *HTTPService {
 	if logger == nil {
 		logger = slog.Default()
@@ -39,21 +41,40 @@ func (s *HTTPService) Start() error {
 	return s.srv.ListenAndServe()
 }
 
-// Shutdown gracefully shuts down the HTTP server.
+// Shutdown gracefully shuts down the HTTP server. The functions registered
+// with RegisterOnShutdown run first, then in-flight requests are drained.
 func (s *HTTPService) Shutdown(ctx context.Context) error {
 	s.logger.Info("Shutting down REST server...")
+	for _, f := range s.onShutdown {
+		if err := f(ctx); err != nil {
+			s.logger.Error("Shutdown hook failed", "error", err)
+		}
+	}
 	return s.srv.Shutdown(ctx)
 }
 
-// NewRouter initializes and returns a new Gorilla Mux router with common middleware.
-func NewRouter(logger *slog.Logger) *mux.Router {
+// RegisterOnShutdown registers a function that Shutdown calls before draining
+// requests. It ends long-lived responses and hijacked connections, which
+// graceful shutdown would otherwise wait on or not see at all, and returns
+// once they are torn down or ctx is done.
+func (s *HTTPService) RegisterOnShutdown(f func(ctx context.Context) error) {
+	s.onShutdown = append(s.onShutdown, f)
+}
+
+// NewRouter initializes and returns a new Gorilla Mux router with common
+// middleware. Requests are given an ID and traced, and reported to observer
+// unless it is nil.
+func NewRouter(logger *slog.Logger, observer middleware.RequestObserver) *mux.Router {
 	if logger == nil {
 		logger = slog.Default()
 	}
 	router := mux.NewRouter()
 
-	// Add global middleware (e.g., logging, CORS)
-	router.Use(middleware.LoggingMiddleware(logger))
+	// Add global middleware (e.g., request ID, tracing, logging, CORS). The
+	// request ID and span come first so request logs carry them.
+	router.Use(middleware.RequestIDMiddleware())
+	router.Use(middleware.TracingMiddleware())
+	router.Use(middleware.LoggingMiddleware(logger, observer))
 	router.Use(middleware.CORSMiddleware(logger))
 
 	return router
@@ -2,44 +2,87 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
+	"github.com/sahidhossen/todo/api-gateway/internal/requestid"
 	pb "github.com/sahidhossen/todo/proto/task_service" // Alias for generated code
 )
 
 // GRPCClient wraps the gRPC client for the TaskService.
 type GRPCClient struct {
 	client pb.TaskServiceClient
+	health healthpb.HealthClient
 	conn   *grpc.ClientConn
 	logger *slog.Logger
 }
 
+// TaskEventStream receives the task events sent by WatchTasks.
+type TaskEventStream = grpc.ServerStreamingClient[pb.TaskEvent]
+
 // TaskService interface for interacting with the Task gRPC service.
 type TaskService interface {
-	CreateTask(ctx context.Context, title, description string) (*pb.Task, error)
+	CreateTask(ctx context.Context, req *pb.CreateTaskRequest) (*pb.Task, error)
 	GetTask(ctx context.Context, id string) (*pb.Task, error)
-	ListTasks(ctx context.Context) ([]*pb.Task, error)
+	GetTaskTree(ctx context.Context, id string) (*pb.Task, error)
+	ListSubtasks(ctx context.Context, parentID string) ([]*pb.Task, error)
+	UpdateTaskSeries(ctx context.Context, seriesID string, task *pb.Task, paths []string) ([]*pb.Task, error)
+	WatchTasks(ctx context.Context, afterSequence uint64) (TaskEventStream, error)
+	ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error)
+	SearchTasks(ctx context.Context, req *pb.SearchTasksRequest) (*pb.SearchTasksResponse, error)
 	ToggleTaskCompletion(ctx context.Context, id string) (*pb.Task, error)
-	GetTaskStats(ctx context.Context) (*pb.GetTaskStatsResponse, error) // NEW: Add this
+	CompleteTask(ctx context.Context, id string) (*pb.Task, error)
+	ReopenTask(ctx context.Context, id string) (*pb.Task, error)
+	UpdateTask(ctx context.Context, task *pb.Task, paths []string) (*pb.Task, error)
+	DeleteTask(ctx context.Context, id string) (*pb.Task, error)
+	RestoreTask(ctx context.Context, id string) (*pb.Task, error)
+	PurgeTask(ctx context.Context, id string) error
+	ListTrash(ctx context.Context) ([]*pb.Task, error)
+	GetTaskStats(ctx context.Context, req *pb.GetTaskStatsRequest) (*pb.GetTaskStatsResponse, error)
+	CreateLabel(ctx context.Context, name string) (*pb.Label, error)
+	ListLabels(ctx context.Context) ([]*pb.Label, error)
+	RenameLabel(ctx context.Context, id, name string) (*pb.Label, error)
+	DeleteLabel(ctx context.Context, id string) error
+	AddLabels(ctx context.Context, taskID string, labels []string) (*pb.Task, error)
+	RemoveLabels(ctx context.Context, taskID string, labels []string) (*pb.Task, error)
+	CreateProject(ctx context.Context, req *pb.CreateProjectRequest) (*pb.Project, error)
+	GetProject(ctx context.Context, id string) (*pb.Project, error)
+	ListProjects(ctx context.Context, includeArchived bool) ([]*pb.Project, error)
+	UpdateProject(ctx context.Context, project *pb.Project, paths []string) (*pb.Project, error)
+	DeleteProject(ctx context.Context, id string) error
+	Register(ctx context.Context, email, password string) (*pb.User, error)
+	Login(ctx context.Context, email, password string) (*pb.User, error)
+	CreateApiToken(ctx context.Context, req *pb.CreateApiTokenRequest) (*pb.CreateApiTokenResponse, error)
+	ListApiTokens(ctx context.Context) ([]*pb.ApiToken, error)
+	RevokeApiToken(ctx context.Context, id string) error
+	VerifyApiToken(ctx context.Context, token string) (*pb.VerifyApiTokenResponse, error)
+	CheckHealth(ctx context.Context) error
 	Close() error
 }
 
 // NewGRPCClient creates a new GRPCClient and establishes a gRPC connection.
-func NewGRPCClient(addr string, logger *slog.Logger) (TaskService, error) {
+// Without creds the connection is not encrypted. opts are added to the
+// client's own options, e.g. for metrics interceptors.
+func NewGRPCClient(addr string, creds credentials.TransportCredentials, logger *slog.Logger, opts ...grpc.DialOption) (TaskService, error) {
 	if logger == nil {
 		logger = slog.Default()
 	}
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
 
-	// In a real application, you'd use credentials.NewClientTLSFromFile or similar
-	// For simplicity, we use insecure transport.
-	logger.Info("Connecting to gRPC storage service", "address", addr)
-	conn, err := grpc.NewClient(
-		addr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	logger.Info("Connecting to gRPC storage service", "address", addr, "security", creds.Info().SecurityProtocol)
+	conn, err := grpc.NewClient(addr, append([]grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(forwardUserID, requestid.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(forwardUserIDStream, requestid.StreamClientInterceptor()),
+	}, opts...)...)
 	if err != nil {
 		logger.Error("Failed to connect to gRPC server", "address", addr, "error", err)
 		return nil, err
@@ -49,6 +92,7 @@ func NewGRPCClient(addr string, logger *slog.Logger) (TaskService, error) {
 	logger.Info("Successfully connected to gRPC server!")
 	return &GRPCClient{
 		client: client,
+		health: healthpb.NewHealthClient(conn),
 		conn:   conn,
 		logger: logger,
 	}, nil
@@ -61,10 +105,10 @@ func (c *GRPCClient) Close() error {
 }
 
 // CreateTask calls the gRPC CreateTask method.
-func (c *GRPCClient) CreateTask(ctx context.Context, title, description string) (*pb.Task, error) {
-	resp, err := c.client.CreateTask(ctx, &pb.CreateTaskRequest{Title: title, Description: description})
+func (c *GRPCClient) CreateTask(ctx context.Context, req *pb.CreateTaskRequest) (*pb.Task, error) {
+	resp, err := c.client.CreateTask(ctx, req)
 	if err != nil {
-		c.logger.Error("gRPC CreateTask failed", "error", err)
+		c.logger.ErrorContext(ctx, "gRPC CreateTask failed", "error", err)
 		return nil, err
 	}
 	return resp.Task, nil
@@ -74,38 +118,353 @@ func (c *GRPCClient) CreateTask(ctx context.Context, title, description string)
 func (c *GRPCClient) GetTask(ctx context.Context, id string) (*pb.Task, error) {
 	resp, err := c.client.GetTask(ctx, &pb.GetTaskRequest{Id: id})
 	if err != nil {
-		c.logger.Error("gRPC GetTask failed", "id", id, "error", err)
+		c.logger.ErrorContext(ctx, "gRPC GetTask failed", "id", id, "error", err)
+		return nil, err
+	}
+	return resp.Task, nil
+}
+
+// GetTaskTree calls the gRPC GetTask method with include_children, returning
+// the task with its subtasks filled in as children.
+func (c *GRPCClient) GetTaskTree(ctx context.Context, id string) (*pb.Task, error) {
+	resp, err := c.client.GetTask(ctx, &pb.GetTaskRequest{Id: id, IncludeChildren: true})
+	if err != nil {
+		c.logger.ErrorContext(ctx, "gRPC GetTask failed", "id", id, "include_children", true, "error", err)
 		return nil, err
 	}
 	return resp.Task, nil
 }
 
-// ListTasks calls the gRPC ListTasks method.
-func (c *GRPCClient) ListTasks(ctx context.Context) ([]*pb.Task, error) {
-	resp, err := c.client.ListTasks(ctx, &pb.ListTasksRequest{})
+// ListSubtasks calls the gRPC ListSubtasks method.
+func (c *GRPCClient) ListSubtasks(ctx context.Context, parentID string) ([]*pb.Task, error) {
+	resp, err := c.client.ListSubtasks(ctx, &pb.ListSubtasksRequest{ParentId: parentID})
 	if err != nil {
-		c.logger.Error("gRPC ListTasks failed", "error", err)
+		c.logger.ErrorContext(ctx, "gRPC ListSubtasks failed", "parent_id", parentID, "error", err)
 		return nil, err
 	}
 	return resp.Tasks, nil
 }
 
+// ListTasks calls the gRPC ListTasks method and returns one page of tasks.
+func (c *GRPCClient) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
+	resp, err := c.client.ListTasks(ctx, req)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "gRPC ListTasks failed", "error", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SearchTasks calls the gRPC SearchTasks method and returns one page of results.
+func (c *GRPCClient) SearchTasks(ctx context.Context, req *pb.SearchTasksRequest) (*pb.SearchTasksResponse, error) {
+	resp, err := c.client.SearchTasks(ctx, req)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "gRPC SearchTasks failed", "query", req.GetQuery(), "error", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
 // CompleteTask calls the gRPC CompleteTask method.
 func (c *GRPCClient) ToggleTaskCompletion(ctx context.Context, id string) (*pb.Task, error) {
 	resp, err := c.client.ToggleTaskCompletion(ctx, &pb.ToggleTaskCompletionRequest{Id: id})
 	if err != nil {
-		c.logger.Error("gRPC CompleteTask failed", "id", id, "error", err)
+		c.logger.ErrorContext(ctx, "gRPC CompleteTask failed", "id", id, "error", err)
+		return nil, err
+	}
+	return resp.Task, nil
+}
+
+// CompleteTask calls the gRPC CompleteTask method.
+func (c *GRPCClient) CompleteTask(ctx context.Context, id string) (*pb.Task, error) {
+	resp, err := c.client.CompleteTask(ctx, &pb.CompleteTaskRequest{Id: id})
+	if err != nil {
+		c.logger.ErrorContext(ctx, "gRPC CompleteTask failed", "id", id, "error", err)
+		return nil, err
+	}
+	return resp.Task, nil
+}
+
+// UpdateTaskSeries calls the gRPC UpdateTaskSeries method, changing only the
+// fields named in paths on every open occurrence of the series.
+func (c *GRPCClient) UpdateTaskSeries(ctx context.Context, seriesID string, task *pb.Task, paths []string) ([]*pb.Task, error) {
+	resp, err := c.client.UpdateTaskSeries(ctx, &pb.UpdateTaskSeriesRequest{
+		SeriesId:   seriesID,
+		Task:       task,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: paths},
+	})
+	if err != nil {
+		c.logger.ErrorContext(ctx, "gRPC UpdateTaskSeries failed", "series_id", seriesID, "error", err)
+		return nil, err
+	}
+	return resp.Tasks, nil
+}
+
+// WatchTasks calls the gRPC WatchTasks method. The stream ends when ctx is
+// cancelled; errors such as an expired afterSequence are returned by Recv.
+func (c *GRPCClient) WatchTasks(ctx context.Context, afterSequence uint64) (TaskEventStream, error) {
+	stream, err := c.client.WatchTasks(ctx, &pb.WatchTasksRequest{AfterSequence: afterSequence})
+	if err != nil {
+		c.logger.ErrorContext(ctx, "gRPC WatchTasks failed", "after_sequence", afterSequence, "error", err)
+		return nil, err
+	}
+	return stream, nil
+}
+
+// ReopenTask calls the gRPC ReopenTask method.
+func (c *GRPCClient) ReopenTask(ctx context.Context, id string) (*pb.Task, error) {
+	resp, err := c.client.ReopenTask(ctx, &pb.ReopenTaskRequest{Id: id})
+	if err != nil {
+		c.logger.ErrorContext(ctx, "gRPC ReopenTask failed", "id", id, "error", err)
+		return nil, err
+	}
+	return resp.Task, nil
+}
+
+// UpdateTask calls the gRPC UpdateTask method, changing only the fields named in paths.
+func (c *GRPCClient) UpdateTask(ctx context.Context, task *pb.Task, paths []string) (*pb.Task, error) {
+	resp, err := c.client.UpdateTask(ctx, &pb.UpdateTaskRequest{
+		Task:       task,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: paths},
+	})
+	if err != nil {
+		c.logger.ErrorContext(ctx, "gRPC UpdateTask failed", "id", task.GetId(), "error", err)
+		return nil, err
+	}
+	return resp.Task, nil
+}
+
+// DeleteTask calls the gRPC DeleteTask method.
+func (c *GRPCClient) DeleteTask(ctx context.Context, id string) (*pb.Task, error) {
+	resp, err := c.client.DeleteTask(ctx, &pb.DeleteTaskRequest{Id: id})
+	if err != nil {
+		c.logger.ErrorContext(ctx, "gRPC DeleteTask failed", "id", id, "error", err)
+		return nil, err
+	}
+	return resp.Task, nil
+}
+
+// RestoreTask calls the gRPC RestoreTask method.
+func (c *GRPCClient) RestoreTask(ctx context.Context, id string) (*pb.Task, error) {
+	resp, err := c.client.RestoreTask(ctx, &pb.RestoreTaskRequest{Id: id})
+	if err != nil {
+		c.logger.ErrorContext(ctx, "gRPC RestoreTask failed", "id", id, "error", err)
 		return nil, err
 	}
 	return resp.Task, nil
 }
 
+// PurgeTask calls the gRPC PurgeTask method.
+func (c *GRPCClient) PurgeTask(ctx context.Context, id string) error {
+	if _, err := c.client.PurgeTask(ctx, &pb.PurgeTaskRequest{Id: id}); err != nil {
+		c.logger.ErrorContext(ctx, "gRPC PurgeTask failed", "id", id, "error", err)
+		return err
+	}
+	return nil
+}
+
+// ListTrash calls the gRPC ListTrash method.
+func (c *GRPCClient) ListTrash(ctx context.Context) ([]*pb.Task, error) {
+	resp, err := c.client.ListTrash(ctx, &pb.ListTrashRequest{})
+	if err != nil {
+		c.logger.ErrorContext(ctx, "gRPC ListTrash failed", "error", err)
+		return nil, err
+	}
+	return resp.Tasks, nil
+}
+
 // GetTaskStats calls the gRPC GetTaskStats method.
-func (c *GRPCClient) GetTaskStats(ctx context.Context) (*pb.GetTaskStatsResponse, error) {
-	resp, err := c.client.GetTaskStats(ctx, &pb.GetTaskStatsRequest{})
+func (c *GRPCClient) GetTaskStats(ctx context.Context, req *pb.GetTaskStatsRequest) (*pb.GetTaskStatsResponse, error) {
+	resp, err := c.client.GetTaskStats(ctx, req)
 	if err != nil {
-		c.logger.Error("gRPC GetTaskStats failed", "error", err)
+		c.logger.ErrorContext(ctx, "gRPC GetTaskStats failed", "error", err)
 		return nil, err
 	}
 	return resp, nil
 }
+
+// CreateLabel calls the gRPC CreateLabel method.
+func (c *GRPCClient) CreateLabel(ctx context.Context, name string) (*pb.Label, error) {
+	resp, err := c.client.CreateLabel(ctx, &pb.CreateLabelRequest{Name: name})
+	if err != nil {
+		c.logger.ErrorContext(ctx, "gRPC CreateLabel failed", "name", name, "error", err)
+		return nil, err
+	}
+	return resp.Label, nil
+}
+
+// ListLabels calls the gRPC ListLabels method.
+func (c *GRPCClient) ListLabels(ctx context.Context) ([]*pb.Label, error) {
+	resp, err := c.client.ListLabels(ctx, &pb.ListLabelsRequest{})
+	if err != nil {
+		c.logger.ErrorContext(ctx, "gRPC ListLabels failed", "error", err)
+		return nil, err
+	}
+	return resp.Labels, nil
+}
+
+// RenameLabel calls the gRPC RenameLabel method.
+func (c *GRPCClient) RenameLabel(ctx context.Context, id, name string) (*pb.Label, error) {
+	resp, err := c.client.RenameLabel(ctx, &pb.RenameLabelRequest{Id: id, Name: name})
+	if err != nil {
+		c.logger.ErrorContext(ctx, "gRPC RenameLabel failed", "id", id, "error", err)
+		return nil, err
+	}
+	return resp.Label, nil
+}
+
+// DeleteLabel calls the gRPC DeleteLabel method.
+func (c *GRPCClient) DeleteLabel(ctx context.Context, id string) error {
+	if _, err := c.client.DeleteLabel(ctx, &pb.DeleteLabelRequest{Id: id}); err != nil {
+		c.logger.ErrorContext(ctx, "gRPC DeleteLabel failed", "id", id, "error", err)
+		return err
+	}
+	return nil
+}
+
+// AddLabels calls the gRPC AddLabels method.
+func (c *GRPCClient) AddLabels(ctx context.Context, taskID string, labels []string) (*pb.Task, error) {
+	resp, err := c.client.AddLabels(ctx, &pb.AddLabelsRequest{TaskId: taskID, Labels: labels})
+	if err != nil {
+		c.logger.ErrorContext(ctx, "gRPC AddLabels failed", "id", taskID, "error", err)
+		return nil, err
+	}
+	return resp.Task, nil
+}
+
+// RemoveLabels calls the gRPC RemoveLabels method.
+func (c *GRPCClient) RemoveLabels(ctx context.Context, taskID string, labels []string) (*pb.Task, error) {
+	resp, err := c.client.RemoveLabels(ctx, &pb.RemoveLabelsRequest{TaskId: taskID, Labels: labels})
+	if err != nil {
+		c.logger.ErrorContext(ctx, "gRPC RemoveLabels failed", "id", taskID, "error", err)
+		return nil, err
+	}
+	return resp.Task, nil
+}
+
+// CreateProject calls the gRPC CreateProject method.
+func (c *GRPCClient) CreateProject(ctx context.Context, req *pb.CreateProjectRequest) (*pb.Project, error) {
+	resp, err := c.client.CreateProject(ctx, req)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "gRPC CreateProject failed", "name", req.GetName(), "error", err)
+		return nil, err
+	}
+	return resp.Project, nil
+}
+
+// GetProject calls the gRPC GetProject method.
+func (c *GRPCClient) GetProject(ctx context.Context, id string) (*pb.Project, error) {
+	resp, err := c.client.GetProject(ctx, &pb.GetProjectRequest{Id: id})
+	if err != nil {
+		c.logger.ErrorContext(ctx, "gRPC GetProject failed", "id", id, "error", err)
+		return nil, err
+	}
+	return resp.Project, nil
+}
+
+// ListProjects calls the gRPC ListProjects method.
+func (c *GRPCClient) ListProjects(ctx context.Context, includeArchived bool) ([]*pb.Project, error) {
+	resp, err := c.client.ListProjects(ctx, &pb.ListProjectsRequest{IncludeArchived: includeArchived})
+	if err != nil {
+		c.logger.ErrorContext(ctx, "gRPC ListProjects failed", "error", err)
+		return nil, err
+	}
+	return resp.Projects, nil
+}
+
+// UpdateProject calls the gRPC UpdateProject method, changing only the fields named in paths.
+func (c *GRPCClient) UpdateProject(ctx context.Context, project *pb.Project, paths []string) (*pb.Project, error) {
+	resp, err := c.client.UpdateProject(ctx, &pb.UpdateProjectRequest{
+		Project:    project,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: paths},
+	})
+	if err != nil {
+		c.logger.ErrorContext(ctx, "gRPC UpdateProject failed", "id", project.GetId(), "error", err)
+		return nil, err
+	}
+	return resp.Project, nil
+}
+
+// DeleteProject calls the gRPC DeleteProject method.
+func (c *GRPCClient) DeleteProject(ctx context.Context, id string) error {
+	if _, err := c.client.DeleteProject(ctx, &pb.DeleteProjectRequest{Id: id}); err != nil {
+		c.logger.ErrorContext(ctx, "gRPC DeleteProject failed", "id", id, "error", err)
+		return err
+	}
+	return nil
+}
+
+// Register calls the gRPC Register method.
+func (c *GRPCClient) Register(ctx context.Context, email, password string) (*pb.User, error) {
+	resp, err := c.client.Register(ctx, &pb.RegisterRequest{Email: email, Password: password})
+	if err != nil {
+		c.logger.ErrorContext(ctx, "gRPC Register failed", "error", err)
+		return nil, err
+	}
+	return resp.User, nil
+}
+
+// Login calls the gRPC Login method, which checks the user's password.
+func (c *GRPCClient) Login(ctx context.Context, email, password string) (*pb.User, error) {
+	resp, err := c.client.Login(ctx, &pb.LoginRequest{Email: email, Password: password})
+	if err != nil {
+		c.logger.ErrorContext(ctx, "gRPC Login failed", "error", err)
+		return nil, err
+	}
+	return resp.User, nil
+}
+
+// CreateApiToken calls the gRPC CreateApiToken method. The response holds the
+// token's secret, which cannot be retrieved again.
+func (c *GRPCClient) CreateApiToken(ctx context.Context, req *pb.CreateApiTokenRequest) (*pb.CreateApiTokenResponse, error) {
+	resp, err := c.client.CreateApiToken(ctx, req)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "gRPC CreateApiToken failed", "error", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListApiTokens calls the gRPC ListApiTokens method.
+func (c *GRPCClient) ListApiTokens(ctx context.Context) ([]*pb.ApiToken, error) {
+	resp, err := c.client.ListApiTokens(ctx, &pb.ListApiTokensRequest{})
+	if err != nil {
+		c.logger.ErrorContext(ctx, "gRPC ListApiTokens failed", "error", err)
+		return nil, err
+	}
+	return resp.ApiTokens, nil
+}
+
+// RevokeApiToken calls the gRPC RevokeApiToken method.
+func (c *GRPCClient) RevokeApiToken(ctx context.Context, id string) error {
+	if _, err := c.client.RevokeApiToken(ctx, &pb.RevokeApiTokenRequest{Id: id}); err != nil {
+		c.logger.ErrorContext(ctx, "gRPC RevokeApiToken failed", "id", id, "error", err)
+		return err
+	}
+	return nil
+}
+
+// VerifyApiToken calls the gRPC VerifyApiToken method. Unknown, revoked and
+// expired tokens fail with codes.Unauthenticated.
+func (c *GRPCClient) VerifyApiToken(ctx context.Context, token string) (*pb.VerifyApiTokenResponse, error) {
+	resp, err := c.client.VerifyApiToken(ctx, &pb.VerifyApiTokenRequest{Token: token})
+	if err != nil {
+		c.logger.WarnContext(ctx, "gRPC VerifyApiToken failed", "error", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CheckHealth asks the storage service whether TaskService is serving, with
+// the standard grpc.health.v1 Check method.
+func (c *GRPCClient) CheckHealth(ctx context.Context) error {
+	resp, err := c.health.Check(ctx, &healthpb.HealthCheckRequest{Service: pb.TaskService_ServiceDesc.ServiceName})
+	if err != nil {
+		c.logger.WarnContext(ctx, "gRPC health check failed", "error", err)
+		return err
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("storage service is %s", resp.GetStatus())
+	}
+	return nil
+}
@@ -10,7 +10,9 @@ import (
 	pb "github.com/sahidhossen/todo/proto/task_service"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
 )
 
@@ -25,7 +27,7 @@ func TestGRPCClient_CreateTask_Success(t *testing.T) {
 	mockClient.On("CreateTask", mock.Anything, &pb.CreateTaskRequest{Title: "Test Task", Description: "Description"}).
 		Return(&pb.CreateTaskResponse{Task: expectedTask}, nil)
 
-	task, err := grpcClient.CreateTask(context.Background(), "Test Task", "Description")
+	task, err := grpcClient.CreateTask(context.Background(), &pb.CreateTaskRequest{Title: "Test Task", Description: "Description"})
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedTask, task)
@@ -43,7 +45,7 @@ func TestGRPCClient_CreateTask_Error(t *testing.T) {
 	mockClient.On("CreateTask", mock.Anything, &pb.CreateTaskRequest{Title: "Error Task", Description: ""}).
 		Return(nil, expectedErr) // Return nil response, and an error
 
-	task, err := grpcClient.CreateTask(context.Background(), "Error Task", "")
+	task, err := grpcClient.CreateTask(context.Background(), &pb.CreateTaskRequest{Title: "Error Task"})
 
 	assert.Error(t, err)
 	assert.Nil(t, task)
@@ -99,13 +101,15 @@ func TestGRPCClient_ListTasks_Success(t *testing.T) {
 		{Id: "1", Title: "Task 1"},
 		{Id: "2", Title: "Task 2"},
 	}
-	mockClient.On("ListTasks", mock.Anything, &pb.ListTasksRequest{}).
-		Return(&pb.ListTasksResponse{Tasks: expectedTasks}, nil)
+	req := &pb.ListTasksRequest{PageSize: 2}
+	mockClient.On("ListTasks", mock.Anything, req).
+		Return(&pb.ListTasksResponse{Tasks: expectedTasks, NextPageToken: "next", TotalSize: 3}, nil)
 
-	tasks, err := grpcClient.ListTasks(context.Background())
+	resp, err := grpcClient.ListTasks(context.Background(), req)
 
 	assert.NoError(t, err)
-	assert.Equal(t, expectedTasks, tasks)
+	assert.Equal(t, expectedTasks, resp.Tasks)
+	assert.Equal(t, "next", resp.NextPageToken)
 	mockClient.AssertExpectations(t)
 }
 
@@ -120,10 +124,10 @@ func TestGRPCClient_ListTasks_Error(t *testing.T) {
 	mockClient.On("ListTasks", mock.Anything, &pb.ListTasksRequest{}).
 		Return(nil, expectedErr)
 
-	tasks, err := grpcClient.ListTasks(context.Background())
+	resp, err := grpcClient.ListTasks(context.Background(), &pb.ListTasksRequest{})
 
 	assert.Error(t, err)
-	assert.Nil(t, tasks)
+	assert.Nil(t, resp)
 	assert.Equal(t, expectedErr, err)
 	mockClient.AssertExpectations(t)
 }
@@ -164,3 +168,70 @@ func TestGRPCClient_ToggleTaskCompletion_Error(t *testing.T) {
 	assert.Equal(t, expectedErr, err)
 	mockClient.AssertExpectations(t)
 }
+
+func TestGRPCClient_CompleteTask_Success(t *testing.T) {
+	mockClient := new(mocks.MockTaskServiceClient)
+	grpcClient := &GRPCClient{
+		client: mockClient,
+		logger: slog.Default(),
+	}
+
+	expectedTask := &pb.Task{Id: "789", Title: "Done Task", Completed: true}
+	mockClient.On("CompleteTask", mock.Anything, &pb.CompleteTaskRequest{Id: "789"}).
+		Return(&pb.CompleteTaskResponse{Task: expectedTask}, nil)
+
+	task, err := grpcClient.CompleteTask(context.Background(), "789")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedTask, task)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGRPCClient_ReopenTask_Error(t *testing.T) {
+	mockClient := new(mocks.MockTaskServiceClient)
+	grpcClient := &GRPCClient{
+		client: mockClient,
+		logger: slog.Default(),
+	}
+
+	expectedErr := status.Error(codes.NotFound, "task not found")
+	mockClient.On("ReopenTask", mock.Anything, &pb.ReopenTaskRequest{Id: "missing"}).
+		Return(nil, expectedErr)
+
+	task, err := grpcClient.ReopenTask(context.Background(), "missing")
+
+	assert.Error(t, err)
+	assert.Nil(t, task)
+	assert.Equal(t, expectedErr, err)
+	mockClient.AssertExpectations(t)
+}
+
+// fakeHealthClient answers health checks with status, or fails with err.
+type fakeHealthClient struct {
+	healthpb.HealthClient
+	service string
+	status  healthpb.HealthCheckResponse_ServingStatus
+	err     error
+}
+
+func (f *fakeHealthClient) Check(ctx context.Context, req *healthpb.HealthCheckRequest, opts ...grpc.CallOption) (*healthpb.HealthCheckResponse, error) {
+	f.service = req.Service
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &healthpb.HealthCheckResponse{Status: f.status}, nil
+}
+
+func TestGRPCClient_CheckHealth(t *testing.T) {
+	health := &fakeHealthClient{status: healthpb.HealthCheckResponse_SERVING}
+	grpcClient := &GRPCClient{health: health, logger: slog.Default()}
+
+	assert.NoError(t, grpcClient.CheckHealth(context.Background()))
+	assert.Equal(t, pb.TaskService_ServiceDesc.ServiceName, health.service)
+
+	health.status = healthpb.HealthCheckResponse_NOT_SERVING
+	assert.ErrorContains(t, grpcClient.CheckHealth(context.Background()), "NOT_SERVING")
+
+	health.err = status.Error(codes.Unavailable, "connection refused")
+	assert.Equal(t, codes.Unavailable, status.Code(grpcClient.CheckHealth(context.Background())))
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+// userIDMetadataKey is the gRPC metadata key the storage service reads the
+// caller's user ID from.
+const userIDMetadataKey = "x-user-id"
+
+// secretMetadataKey is the gRPC metadata key carrying the gateway secret.
+const secretMetadataKey = "x-gateway-secret"
+
+type userIDKey struct{}
+
+// WithUserID returns a copy of ctx carrying the ID of the user a request is
+// made for. GRPCClient forwards it to the storage service.
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// UserIDFromContext returns the user ID carried by ctx, if any.
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	userID, ok := ctx.Value(userIDKey{}).(string)
+	return userID, ok && userID != ""
+}
+
+// withUserMetadata returns ctx with the user ID it carries, if any, added to
+// the outgoing gRPC metadata.
+func withUserMetadata(ctx context.Context) context.Context {
+	if userID, ok := UserIDFromContext(ctx); ok {
+		return metadata.AppendToOutgoingContext(ctx, userIDMetadataKey, userID)
+	}
+	return ctx
+}
+
+// forwardUserID is a unary client interceptor sending the caller's user ID.
+func forwardUserID(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(withUserMetadata(ctx), method, req, reply, cc, opts...)
+}
+
+// forwardUserIDStream is a stream client interceptor sending the caller's user ID.
+func forwardUserIDStream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(withUserMetadata(ctx), desc, cc, method, opts...)
+}
+
+// gatewaySecret is call credentials sending the shared secret the storage
+// service requires before it believes the forwarded user ID from a caller
+// without a TLS client certificate.
+type gatewaySecret string
+
+// GatewaySecret returns a dial option sending secret with every call.
+func GatewaySecret(secret string) grpc.DialOption {
+	return grpc.WithPerRPCCredentials(gatewaySecret(secret))
+}
+
+func (s gatewaySecret) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{secretMetadataKey: string(s)}, nil
+}
+
+// RequireTransportSecurity allows the secret over plaintext connections,
+// which are only meant for trusted networks.
+func (s gatewaySecret) RequireTransportSecurity() bool {
+	return false
+}
+
+var _ credentials.PerRPCCredentials = gatewaySecret("")
@@ -0,0 +1,25 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestWithUserMetadata(t *testing.T) {
+	ctx := withUserMetadata(WithUserID(context.Background(), "user-1"))
+	md, _ := metadata.FromOutgoingContext(ctx)
+	assert.Equal(t, []string{"user-1"}, md.Get(userIDMetadataKey))
+
+	ctx = withUserMetadata(context.Background())
+	_, ok := metadata.FromOutgoingContext(ctx)
+	assert.False(t, ok, "no metadata without a user")
+}
+
+func TestGatewaySecret(t *testing.T) {
+	md, err := gatewaySecret("secret").GetRequestMetadata(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{secretMetadataKey: "secret"}, md)
+}
@@ -0,0 +1,71 @@
+// Package tlsconfig sets up TLS for the connection to the storage service,
+// reloading the certificate files when they change on disk.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/sahidhossen/todo/proto/tlsreload"
+	"google.golang.org/grpc/credentials"
+)
+
+// ClientConfig names the PEM files the gRPC client uses for TLS.
+type ClientConfig struct {
+	// CAFile holds the CAs the storage service certificate is checked
+	// against. Without it the system roots are used.
+	CAFile string
+	// CertFile and KeyFile are the client certificate presented for mutual
+	// TLS.
+	CertFile string
+	KeyFile  string
+	// ServerName is checked against the storage service certificate instead
+	// of the host name in the address.
+	ServerName string
+}
+
+// Enabled reports whether any TLS setting is configured.
+func (c ClientConfig) Enabled() bool {
+	return c.CAFile != "" || c.CertFile != "" || c.KeyFile != "" || c.ServerName != ""
+}
+
+// NewClientCredentials returns transport credentials connecting over TLS
+// with the files of c. The files are read again when they change, so new
+// connections pick up renewed certificates without a restart.
+func NewClientCredentials(c ClientConfig, logger *slog.Logger) (credentials.TransportCredentials, error) {
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return nil, errors.New("a TLS client certificate needs both a certificate and a key file")
+	}
+	var files []string
+	for _, file := range []string{c.CAFile, c.CertFile, c.KeyFile} {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return tlsreload.New(files, c.load, logger)
+}
+
+// load reads the files of c into a tls.Config.
+func (c ClientConfig) load() (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName: c.ServerName,
+		MinVersion: tls.VersionTLS12,
+	}
+	if c.CAFile != "" {
+		pool, err := tlsreload.LoadCertPool(c.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = pool
+	}
+	if c.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS key pair: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
@@ -0,0 +1,178 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"log/slog"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// certs are PEM files signed by a test CA, written to a directory.
+type certs struct {
+	dir  string
+	pool *x509.CertPool
+}
+
+func (c certs) file(name string) string {
+	return filepath.Join(c.dir, name)
+}
+
+// generateCerts writes ca.pem, a server certificate for localhost and a
+// client certificate into dir.
+func generateCerts(t *testing.T, dir string) certs {
+	t.Helper()
+	caKey := newKey(t)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+	writePEM(t, filepath.Join(dir, "ca.pem"), "CERTIFICATE", caDER)
+
+	sign := func(name string, usage x509.ExtKeyUsage) {
+		key := newKey(t)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(time.Now().UnixNano()),
+			Subject:      pkix.Name{CommonName: name},
+			DNSNames:     []string{"localhost"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, ca, key.Public(), caKey)
+		require.NoError(t, err)
+		keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+		writePEM(t, filepath.Join(dir, name+".pem"), "CERTIFICATE", der)
+		writePEM(t, filepath.Join(dir, name+"-key.pem"), "PRIVATE KEY", keyDER)
+	}
+	sign("server", x509.ExtKeyUsageServerAuth)
+	sign("client", x509.ExtKeyUsageClientAuth)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	return certs{dir: dir, pool: pool}
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func writePEM(t *testing.T, file, blockType string, der []byte) {
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+}
+
+// touch moves the modification time of file forward, for file systems with
+// coarse timestamps.
+func touch(t *testing.T, file string) {
+	t.Helper()
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+	later := info.ModTime().Add(time.Second)
+	require.NoError(t, os.Chtimes(file, later, later))
+}
+
+// serveMutualTLS starts a gRPC server requiring client certificates signed by
+// the CA of c and returns its address.
+func serveMutualTLS(t *testing.T, c certs) string {
+	t.Helper()
+	cert, err := tls.LoadX509KeyPair(c.file("server.pem"), c.file("server-key.pem"))
+	require.NoError(t, err)
+	creds := credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    c.pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer(grpc.Creds(creds))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+	return lis.Addr().String()
+}
+
+func check(t *testing.T, addr string, creds credentials.TransportCredentials) error {
+	t.Helper()
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(creds))
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
+
+func clientConfig(c certs) ClientConfig {
+	return ClientConfig{
+		CAFile:     c.file("ca.pem"),
+		CertFile:   c.file("client.pem"),
+		KeyFile:    c.file("client-key.pem"),
+		ServerName: "localhost",
+	}
+}
+
+func TestClientCredentials_MutualTLS(t *testing.T) {
+	c := generateCerts(t, t.TempDir())
+	addr := serveMutualTLS(t, c)
+
+	creds, err := NewClientCredentials(clientConfig(c), slog.New(slog.DiscardHandler))
+	require.NoError(t, err)
+	assert.NoError(t, check(t, addr, creds))
+
+	cfg := clientConfig(c)
+	cfg.CertFile, cfg.KeyFile = "", ""
+	creds, err = NewClientCredentials(cfg, slog.New(slog.DiscardHandler))
+	require.NoError(t, err)
+	assert.Error(t, check(t, addr, creds), "the server wants a client certificate")
+}
+
+func TestClientCredentials_Reload(t *testing.T) {
+	dir := t.TempDir()
+	c := generateCerts(t, dir)
+	creds, err := NewClientCredentials(clientConfig(c), slog.New(slog.DiscardHandler))
+	require.NoError(t, err)
+	require.NoError(t, check(t, serveMutualTLS(t, c), creds))
+
+	// A new CA and certificates, as after a rotation
+	rotated := generateCerts(t, dir)
+	touch(t, rotated.file("ca.pem"))
+	assert.NoError(t, check(t, serveMutualTLS(t, rotated), creds))
+}
+
+func TestClientCredentials_Invalid(t *testing.T) {
+	_, err := NewClientCredentials(ClientConfig{CertFile: "client.pem"}, nil)
+	assert.Error(t, err, "a certificate needs a key")
+
+	_, err = NewClientCredentials(ClientConfig{CAFile: "missing.pem"}, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,53 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+// exportedAttr is an attribute as stdouttrace writes it.
+type exportedAttr struct {
+	Key   string
+	Value struct{ Value any }
+}
+
+func TestSetup_FileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.json")
+	shutdown, err := Setup(context.Background(), Config{Exporter: ExporterFile, File: path, ServiceName: "gateway-test"})
+	require.NoError(t, err)
+
+	_, span := otel.Tracer("test").Start(context.Background(), "op")
+	traceID := span.SpanContext().TraceID().String()
+	span.End()
+	require.NoError(t, shutdown(context.Background()))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var exported struct {
+		Name        string
+		SpanContext struct{ TraceID string }
+		Resource    []exportedAttr
+	}
+	require.NoError(t, json.Unmarshal(data, &exported))
+	assert.Equal(t, "op", exported.Name)
+	assert.Equal(t, traceID, exported.SpanContext.TraceID)
+	serviceName := exportedAttr{Key: "service.name"}
+	serviceName.Value.Value = "gateway-test"
+	assert.Contains(t, exported.Resource, serviceName)
+}
+
+func TestSetup_Exporters(t *testing.T) {
+	shutdown, err := Setup(context.Background(), Config{Exporter: ExporterNone})
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+
+	_, err = Setup(context.Background(), Config{Exporter: "zipkin"})
+	assert.ErrorContains(t, err, `unknown trace exporter "zipkin"`)
+}
@@ -38,6 +38,14 @@ func (m *MockTaskServiceClient) ListTasks(ctx context.Context, in *pb.ListTasksR
 	return args.Get(0).(*pb.ListTasksResponse), args.Error(1)
 }
 
+func (m *MockTaskServiceClient) SearchTasks(ctx context.Context, in *pb.SearchTasksRequest, opts ...grpc.CallOption) (*pb.SearchTasksResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.SearchTasksResponse), args.Error(1)
+}
+
 func (m *MockTaskServiceClient) ToggleTaskCompletion(ctx context.Context, in *pb.ToggleTaskCompletionRequest, opts ...grpc.CallOption) (*pb.ToggleTaskCompletionResponse, error) {
 	args := m.Called(ctx, in)
 	if args.Get(0) == nil {
@@ -54,6 +62,54 @@ func (m *MockTaskServiceClient) CompleteTask(ctx context.Context, in *pb.Complet
 	return args.Get(0).(*pb.CompleteTaskResponse), args.Error(1)
 }
 
+func (m *MockTaskServiceClient) UpdateTask(ctx context.Context, in *pb.UpdateTaskRequest, opts ...grpc.CallOption) (*pb.UpdateTaskResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.UpdateTaskResponse), args.Error(1)
+}
+
+func (m *MockTaskServiceClient) DeleteTask(ctx context.Context, in *pb.DeleteTaskRequest, opts ...grpc.CallOption) (*pb.DeleteTaskResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.DeleteTaskResponse), args.Error(1)
+}
+
+func (m *MockTaskServiceClient) RestoreTask(ctx context.Context, in *pb.RestoreTaskRequest, opts ...grpc.CallOption) (*pb.RestoreTaskResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.RestoreTaskResponse), args.Error(1)
+}
+
+func (m *MockTaskServiceClient) PurgeTask(ctx context.Context, in *pb.PurgeTaskRequest, opts ...grpc.CallOption) (*pb.PurgeTaskResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.PurgeTaskResponse), args.Error(1)
+}
+
+func (m *MockTaskServiceClient) ListTrash(ctx context.Context, in *pb.ListTrashRequest, opts ...grpc.CallOption) (*pb.ListTrashResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.ListTrashResponse), args.Error(1)
+}
+
+func (m *MockTaskServiceClient) ReopenTask(ctx context.Context, in *pb.ReopenTaskRequest, opts ...grpc.CallOption) (*pb.ReopenTaskResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.ReopenTaskResponse), args.Error(1)
+}
+
 func (m *MockTaskServiceClient) GetTaskStats(ctx context.Context, in *pb.GetTaskStatsRequest, opts ...grpc.CallOption) (*pb.GetTaskStatsResponse, error) {
 	args := m.Called(ctx, in)
 	if args.Get(0) == nil {
@@ -61,3 +117,163 @@ func (m *MockTaskServiceClient) GetTaskStats(ctx context.Context, in *pb.GetTask
 	}
 	return args.Get(0).(*pb.GetTaskStatsResponse), args.Error(1)
 }
+
+func (m *MockTaskServiceClient) CreateLabel(ctx context.Context, in *pb.CreateLabelRequest, opts ...grpc.CallOption) (*pb.CreateLabelResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.CreateLabelResponse), args.Error(1)
+}
+
+func (m *MockTaskServiceClient) ListLabels(ctx context.Context, in *pb.ListLabelsRequest, opts ...grpc.CallOption) (*pb.ListLabelsResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.ListLabelsResponse), args.Error(1)
+}
+
+func (m *MockTaskServiceClient) RenameLabel(ctx context.Context, in *pb.RenameLabelRequest, opts ...grpc.CallOption) (*pb.RenameLabelResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.RenameLabelResponse), args.Error(1)
+}
+
+func (m *MockTaskServiceClient) DeleteLabel(ctx context.Context, in *pb.DeleteLabelRequest, opts ...grpc.CallOption) (*pb.DeleteLabelResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.DeleteLabelResponse), args.Error(1)
+}
+
+func (m *MockTaskServiceClient) AddLabels(ctx context.Context, in *pb.AddLabelsRequest, opts ...grpc.CallOption) (*pb.AddLabelsResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.AddLabelsResponse), args.Error(1)
+}
+
+func (m *MockTaskServiceClient) RemoveLabels(ctx context.Context, in *pb.RemoveLabelsRequest, opts ...grpc.CallOption) (*pb.RemoveLabelsResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.RemoveLabelsResponse), args.Error(1)
+}
+
+func (m *MockTaskServiceClient) CreateProject(ctx context.Context, in *pb.CreateProjectRequest, opts ...grpc.CallOption) (*pb.CreateProjectResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.CreateProjectResponse), args.Error(1)
+}
+
+func (m *MockTaskServiceClient) GetProject(ctx context.Context, in *pb.GetProjectRequest, opts ...grpc.CallOption) (*pb.GetProjectResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.GetProjectResponse), args.Error(1)
+}
+
+func (m *MockTaskServiceClient) ListProjects(ctx context.Context, in *pb.ListProjectsRequest, opts ...grpc.CallOption) (*pb.ListProjectsResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.ListProjectsResponse), args.Error(1)
+}
+
+func (m *MockTaskServiceClient) UpdateProject(ctx context.Context, in *pb.UpdateProjectRequest, opts ...grpc.CallOption) (*pb.UpdateProjectResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.UpdateProjectResponse), args.Error(1)
+}
+
+func (m *MockTaskServiceClient) DeleteProject(ctx context.Context, in *pb.DeleteProjectRequest, opts ...grpc.CallOption) (*pb.DeleteProjectResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.DeleteProjectResponse), args.Error(1)
+}
+
+func (m *MockTaskServiceClient) ListSubtasks(ctx context.Context, in *pb.ListSubtasksRequest, opts ...grpc.CallOption) (*pb.ListSubtasksResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.ListSubtasksResponse), args.Error(1)
+}
+
+func (m *MockTaskServiceClient) UpdateTaskSeries(ctx context.Context, in *pb.UpdateTaskSeriesRequest, opts ...grpc.CallOption) (*pb.UpdateTaskSeriesResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.UpdateTaskSeriesResponse), args.Error(1)
+}
+
+func (m *MockTaskServiceClient) WatchTasks(ctx context.Context, in *pb.WatchTasksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[pb.TaskEvent], error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(grpc.ServerStreamingClient[pb.TaskEvent]), args.Error(1)
+}
+
+func (m *MockTaskServiceClient) Register(ctx context.Context, in *pb.RegisterRequest, opts ...grpc.CallOption) (*pb.RegisterResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.RegisterResponse), args.Error(1)
+}
+
+func (m *MockTaskServiceClient) Login(ctx context.Context, in *pb.LoginRequest, opts ...grpc.CallOption) (*pb.LoginResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.LoginResponse), args.Error(1)
+}
+
+func (m *MockTaskServiceClient) CreateApiToken(ctx context.Context, in *pb.CreateApiTokenRequest, opts ...grpc.CallOption) (*pb.CreateApiTokenResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.CreateApiTokenResponse), args.Error(1)
+}
+
+func (m *MockTaskServiceClient) ListApiTokens(ctx context.Context, in *pb.ListApiTokensRequest, opts ...grpc.CallOption) (*pb.ListApiTokensResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.ListApiTokensResponse), args.Error(1)
+}
+
+func (m *MockTaskServiceClient) RevokeApiToken(ctx context.Context, in *pb.RevokeApiTokenRequest, opts ...grpc.CallOption) (*pb.RevokeApiTokenResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.RevokeApiTokenResponse), args.Error(1)
+}
+
+func (m *MockTaskServiceClient) VerifyApiToken(ctx context.Context, in *pb.VerifyApiTokenRequest, opts ...grpc.CallOption) (*pb.VerifyApiTokenResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.VerifyApiTokenResponse), args.Error(1)
+}
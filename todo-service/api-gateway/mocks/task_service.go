@@ -5,6 +5,7 @@ import (
 
 	pb "github.com/sahidhossen/todo/proto/task_service"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
 )
 
 type MockTaskService struct {
@@ -14,8 +15,8 @@ type MockTaskService struct {
 // Ensure MockTaskService implements services.TaskService
 // var _ services.TaskService = &MockTaskService{}
 
-func (m *MockTaskService) CreateTask(ctx context.Context, title, description string) (*pb.Task, error) {
-	args := m.Called(ctx, title, description)
+func (m *MockTaskService) CreateTask(ctx context.Context, req *pb.CreateTaskRequest) (*pb.Task, error) {
+	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -30,12 +31,20 @@ func (m *MockTaskService) GetTask(ctx context.Context, id string) (*pb.Task, err
 	return args.Get(0).(*pb.Task), args.Error(1)
 }
 
-func (m *MockTaskService) ListTasks(ctx context.Context) ([]*pb.Task, error) {
-	args := m.Called(ctx)
+func (m *MockTaskService) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
+	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*pb.Task), args.Error(1)
+	return args.Get(0).(*pb.ListTasksResponse), args.Error(1)
+}
+
+func (m *MockTaskService) SearchTasks(ctx context.Context, req *pb.SearchTasksRequest) (*pb.SearchTasksResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.SearchTasksResponse), args.Error(1)
 }
 
 func (m *MockTaskService) ToggleTaskCompletion(ctx context.Context, id string) (*pb.Task, error) {
@@ -46,14 +55,231 @@ func (m *MockTaskService) ToggleTaskCompletion(ctx context.Context, id string) (
 	return args.Get(0).(*pb.Task), args.Error(1)
 }
 
-func (m *MockTaskService) GetTaskStats(ctx context.Context) (*pb.GetTaskStatsResponse, error) {
+func (m *MockTaskService) CompleteTask(ctx context.Context, id string) (*pb.Task, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.Task), args.Error(1)
+}
+
+func (m *MockTaskService) ReopenTask(ctx context.Context, id string) (*pb.Task, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.Task), args.Error(1)
+}
+
+func (m *MockTaskService) UpdateTask(ctx context.Context, task *pb.Task, paths []string) (*pb.Task, error) {
+	args := m.Called(ctx, task, paths)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.Task), args.Error(1)
+}
+
+func (m *MockTaskService) DeleteTask(ctx context.Context, id string) (*pb.Task, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.Task), args.Error(1)
+}
+
+func (m *MockTaskService) RestoreTask(ctx context.Context, id string) (*pb.Task, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.Task), args.Error(1)
+}
+
+func (m *MockTaskService) PurgeTask(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTaskService) ListTrash(ctx context.Context) ([]*pb.Task, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
+	return args.Get(0).([]*pb.Task), args.Error(1)
+}
+
+func (m *MockTaskService) GetTaskStats(ctx context.Context, req *pb.GetTaskStatsRequest) (*pb.GetTaskStatsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
 	return args.Get(0).(*pb.GetTaskStatsResponse), args.Error(1)
 }
 
+func (m *MockTaskService) CreateLabel(ctx context.Context, name string) (*pb.Label, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.Label), args.Error(1)
+}
+
+func (m *MockTaskService) ListLabels(ctx context.Context) ([]*pb.Label, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*pb.Label), args.Error(1)
+}
+
+func (m *MockTaskService) RenameLabel(ctx context.Context, id, name string) (*pb.Label, error) {
+	args := m.Called(ctx, id, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.Label), args.Error(1)
+}
+
+func (m *MockTaskService) DeleteLabel(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTaskService) AddLabels(ctx context.Context, taskID string, labels []string) (*pb.Task, error) {
+	args := m.Called(ctx, taskID, labels)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.Task), args.Error(1)
+}
+
+func (m *MockTaskService) RemoveLabels(ctx context.Context, taskID string, labels []string) (*pb.Task, error) {
+	args := m.Called(ctx, taskID, labels)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.Task), args.Error(1)
+}
+
+func (m *MockTaskService) CreateProject(ctx context.Context, req *pb.CreateProjectRequest) (*pb.Project, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.Project), args.Error(1)
+}
+
+func (m *MockTaskService) GetProject(ctx context.Context, id string) (*pb.Project, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.Project), args.Error(1)
+}
+
+func (m *MockTaskService) ListProjects(ctx context.Context, includeArchived bool) ([]*pb.Project, error) {
+	args := m.Called(ctx, includeArchived)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*pb.Project), args.Error(1)
+}
+
+func (m *MockTaskService) UpdateProject(ctx context.Context, project *pb.Project, paths []string) (*pb.Project, error) {
+	args := m.Called(ctx, project, paths)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.Project), args.Error(1)
+}
+
+func (m *MockTaskService) DeleteProject(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTaskService) GetTaskTree(ctx context.Context, id string) (*pb.Task, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.Task), args.Error(1)
+}
+
+func (m *MockTaskService) ListSubtasks(ctx context.Context, parentID string) ([]*pb.Task, error) {
+	args := m.Called(ctx, parentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*pb.Task), args.Error(1)
+}
+
+func (m *MockTaskService) UpdateTaskSeries(ctx context.Context, seriesID string, task *pb.Task, paths []string) ([]*pb.Task, error) {
+	args := m.Called(ctx, seriesID, task, paths)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*pb.Task), args.Error(1)
+}
+
+func (m *MockTaskService) WatchTasks(ctx context.Context, afterSequence uint64) (grpc.ServerStreamingClient[pb.TaskEvent], error) {
+	args := m.Called(ctx, afterSequence)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(grpc.ServerStreamingClient[pb.TaskEvent]), args.Error(1)
+}
+
+func (m *MockTaskService) Register(ctx context.Context, email, password string) (*pb.User, error) {
+	args := m.Called(ctx, email, password)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.User), args.Error(1)
+}
+
+func (m *MockTaskService) Login(ctx context.Context, email, password string) (*pb.User, error) {
+	args := m.Called(ctx, email, password)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.User), args.Error(1)
+}
+
+func (m *MockTaskService) CreateApiToken(ctx context.Context, req *pb.CreateApiTokenRequest) (*pb.CreateApiTokenResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.CreateApiTokenResponse), args.Error(1)
+}
+
+func (m *MockTaskService) ListApiTokens(ctx context.Context) ([]*pb.ApiToken, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*pb.ApiToken), args.Error(1)
+}
+
+func (m *MockTaskService) RevokeApiToken(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTaskService) VerifyApiToken(ctx context.Context, token string) (*pb.VerifyApiTokenResponse, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.VerifyApiTokenResponse), args.Error(1)
+}
+
+func (m *MockTaskService) CheckHealth(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
 func (m *MockTaskService) Close() error {
 	args := m.Called()
 	return args.Error(0)
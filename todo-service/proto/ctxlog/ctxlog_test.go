@@ -0,0 +1,53 @@
+package ctxlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestHandler_Trace(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(slog.NewJSONHandler(&buf, nil))).With("component", "test")
+
+	logger.Info("no span")
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.NotContains(t, record, "trace_id")
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01, 0x02},
+		SpanID:  trace.SpanID{0x03},
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	buf.Reset()
+	logger.InfoContext(ctx, "in span")
+	record = nil
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, sc.TraceID().String(), record["trace_id"])
+	assert.Equal(t, sc.SpanID().String(), record["span_id"])
+	assert.Equal(t, "test", record["component"])
+}
+
+func TestHandler_RequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(slog.NewJSONHandler(&buf, nil)))
+
+	logger.InfoContext(WithRequestID(context.Background(), "req-1"), "with ID")
+	logger.Info("without ID")
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	var withID, withoutID map[string]any
+	require.NoError(t, json.Unmarshal(lines[0], &withID))
+	require.NoError(t, json.Unmarshal(lines[1], &withoutID))
+	assert.Equal(t, "req-1", withID["request_id"])
+	assert.NotContains(t, withoutID, "request_id")
+}
@@ -9,6 +9,7 @@ package task_service
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -22,15 +23,158 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Priority ranks tasks. Higher values are more urgent.
+type Priority int32
+
+const (
+	Priority_PRIORITY_NONE   Priority = 0
+	Priority_PRIORITY_LOW    Priority = 1
+	Priority_PRIORITY_MEDIUM Priority = 2
+	Priority_PRIORITY_HIGH   Priority = 3
+	Priority_PRIORITY_URGENT Priority = 4
+)
+
+// Enum value maps for Priority.
+var (
+	Priority_name = map[int32]string{
+		0: "PRIORITY_NONE",
+		1: "PRIORITY_LOW",
+		2: "PRIORITY_MEDIUM",
+		3: "PRIORITY_HIGH",
+		4: "PRIORITY_URGENT",
+	}
+	Priority_value = map[string]int32{
+		"PRIORITY_NONE":   0,
+		"PRIORITY_LOW":    1,
+		"PRIORITY_MEDIUM": 2,
+		"PRIORITY_HIGH":   3,
+		"PRIORITY_URGENT": 4,
+	}
+)
+
+func (x Priority) Enum() *Priority {
+	p := new(Priority)
+	*p = x
+	return p
+}
+
+func (x Priority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Priority) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_task_service_proto_enumTypes[0].Descriptor()
+}
+
+func (Priority) Type() protoreflect.EnumType {
+	return &file_proto_task_service_proto_enumTypes[0]
+}
+
+func (x Priority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Priority.Descriptor instead.
+func (Priority) EnumDescriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{0}
+}
+
+type TaskEvent_Type int32
+
+const (
+	TaskEvent_TYPE_UNSPECIFIED TaskEvent_Type = 0
+	TaskEvent_TYPE_CREATED     TaskEvent_Type = 1
+	TaskEvent_TYPE_UPDATED     TaskEvent_Type = 2
+	// The task went from open to completed.
+	TaskEvent_TYPE_COMPLETED TaskEvent_Type = 3
+	// The task was moved to the trash.
+	TaskEvent_TYPE_DELETED TaskEvent_Type = 4
+)
+
+// Enum value maps for TaskEvent_Type.
+var (
+	TaskEvent_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "TYPE_CREATED",
+		2: "TYPE_UPDATED",
+		3: "TYPE_COMPLETED",
+		4: "TYPE_DELETED",
+	}
+	TaskEvent_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"TYPE_CREATED":     1,
+		"TYPE_UPDATED":     2,
+		"TYPE_COMPLETED":   3,
+		"TYPE_DELETED":     4,
+	}
+)
+
+func (x TaskEvent_Type) Enum() *TaskEvent_Type {
+	p := new(TaskEvent_Type)
+	*p = x
+	return p
+}
+
+func (x TaskEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_task_service_proto_enumTypes[1].Descriptor()
+}
+
+func (TaskEvent_Type) Type() protoreflect.EnumType {
+	return &file_proto_task_service_proto_enumTypes[1]
+}
+
+func (x TaskEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskEvent_Type.Descriptor instead.
+func (TaskEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{55, 0}
+}
+
 // Task represents a to-do item.
 type Task struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Completed     bool                   `protobuf:"varint,4,opt,name=completed,proto3" json:"completed,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Completed   bool                   `protobuf:"varint,4,opt,name=completed,proto3" json:"completed,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Set when the task has been moved to the trash.
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// Optional deadline. For all-day tasks only the date matters and due_at is
+	// midnight UTC of that date.
+	DueAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	AllDay   bool                   `protobuf:"varint,9,opt,name=all_day,json=allDay,proto3" json:"all_day,omitempty"`
+	Priority Priority               `protobuf:"varint,10,opt,name=priority,proto3,enum=task_service.Priority" json:"priority,omitempty"`
+	// Names of the labels attached to the task, in alphabetical order.
+	Labels []string `protobuf:"bytes,11,rep,name=labels,proto3" json:"labels,omitempty"`
+	// Project the task belongs to. Empty for tasks outside any project.
+	ProjectId string `protobuf:"bytes,12,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Parent of a subtask. Empty for top-level tasks. Tasks nest at most
+	// five levels deep.
+	ParentId string `protobuf:"bytes,13,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// When set, the task is completed automatically once all of its subtasks
+	// are completed, and reopened when one of them is reopened.
+	AutoComplete bool `protobuf:"varint,14,opt,name=auto_complete,json=autoComplete,proto3" json:"auto_complete,omitempty"`
+	// Subtasks, oldest first. Only filled in by GetTask with include_children.
+	Children []*Task `protobuf:"bytes,15,rep,name=children,proto3" json:"children,omitempty"`
+	// Recurrence rule of a recurring task, an RFC 5545 RRULE limited to FREQ
+	// (DAILY, WEEKLY, MONTHLY or YEARLY), INTERVAL, BYDAY, COUNT and UNTIL,
+	// e.g. "FREQ=WEEKLY;BYDAY=MO,TH". Recurring tasks need a due date.
+	// Completing one creates the series' next occurrence. Empty for one-off
+	// tasks.
+	Recurrence string `protobuf:"bytes,16,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	// Series the occurrence belongs to: the ID of its first occurrence. Empty
+	// for tasks that never recurred.
+	SeriesId string `protobuf:"bytes,17,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`
+	// User the task belongs to.
+	OwnerId       string `protobuf:"bytes,18,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -107,18 +251,184 @@ func (x *Task) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Task) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Task) GetDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueAt
+	}
+	return nil
+}
+
+func (x *Task) GetAllDay() bool {
+	if x != nil {
+		return x.AllDay
+	}
+	return false
+}
+
+func (x *Task) GetPriority() Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Priority_PRIORITY_NONE
+}
+
+func (x *Task) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Task) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *Task) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Task) GetAutoComplete() bool {
+	if x != nil {
+		return x.AutoComplete
+	}
+	return false
+}
+
+func (x *Task) GetChildren() []*Task {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+func (x *Task) GetRecurrence() string {
+	if x != nil {
+		return x.Recurrence
+	}
+	return ""
+}
+
+func (x *Task) GetSeriesId() string {
+	if x != nil {
+		return x.SeriesId
+	}
+	return ""
+}
+
+func (x *Task) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+// Label groups tasks by context, e.g. "home" or "work". Each user's label
+// names are unique, ignoring case.
+type Label struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Number of tasks outside the trash that carry the label.
+	TaskCount     int32                  `protobuf:"varint,3,opt,name=task_count,json=taskCount,proto3" json:"task_count,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Label) Reset() {
+	*x = Label{}
+	mi := &file_proto_task_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Label) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Label) ProtoMessage() {}
+
+func (x *Label) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Label.ProtoReflect.Descriptor instead.
+func (*Label) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Label) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Label) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Label) GetTaskCount() int32 {
+	if x != nil {
+		return x.TaskCount
+	}
+	return 0
+}
+
+func (x *Label) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
 // CreateTask
 type CreateTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Title       string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	DueAt       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	// Requires due_at, which is truncated to its UTC date.
+	AllDay   bool     `protobuf:"varint,4,opt,name=all_day,json=allDay,proto3" json:"all_day,omitempty"`
+	Priority Priority `protobuf:"varint,5,opt,name=priority,proto3,enum=task_service.Priority" json:"priority,omitempty"`
+	// Defaults to the parent's project for subtasks.
+	ProjectId string `protobuf:"bytes,6,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Creates the task as a subtask of this task.
+	ParentId     string `protobuf:"bytes,7,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	AutoComplete bool   `protobuf:"varint,8,opt,name=auto_complete,json=autoComplete,proto3" json:"auto_complete,omitempty"`
+	// Makes the task recurring. Requires due_at. See Task.recurrence.
+	Recurrence    string `protobuf:"bytes,9,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateTaskRequest) Reset() {
 	*x = CreateTaskRequest{}
-	mi := &file_proto_task_service_proto_msgTypes[1]
+	mi := &file_proto_task_service_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -130,7 +440,7 @@ func (x *CreateTaskRequest) String() string {
 func (*CreateTaskRequest) ProtoMessage() {}
 
 func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_service_proto_msgTypes[1]
+	mi := &file_proto_task_service_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -143,7 +453,7 @@ func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
 func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
-	return file_proto_task_service_proto_rawDescGZIP(), []int{1}
+	return file_proto_task_service_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CreateTaskRequest) GetTitle() string {
@@ -160,6 +470,55 @@ func (x *CreateTaskRequest) GetDescription() string {
 	return ""
 }
 
+func (x *CreateTaskRequest) GetDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueAt
+	}
+	return nil
+}
+
+func (x *CreateTaskRequest) GetAllDay() bool {
+	if x != nil {
+		return x.AllDay
+	}
+	return false
+}
+
+func (x *CreateTaskRequest) GetPriority() Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Priority_PRIORITY_NONE
+}
+
+func (x *CreateTaskRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetAutoComplete() bool {
+	if x != nil {
+		return x.AutoComplete
+	}
+	return false
+}
+
+func (x *CreateTaskRequest) GetRecurrence() string {
+	if x != nil {
+		return x.Recurrence
+	}
+	return ""
+}
+
 type CreateTaskResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
@@ -169,7 +528,7 @@ type CreateTaskResponse struct {
 
 func (x *CreateTaskResponse) Reset() {
 	*x = CreateTaskResponse{}
-	mi := &file_proto_task_service_proto_msgTypes[2]
+	mi := &file_proto_task_service_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -181,7 +540,7 @@ func (x *CreateTaskResponse) String() string {
 func (*CreateTaskResponse) ProtoMessage() {}
 
 func (x *CreateTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_service_proto_msgTypes[2]
+	mi := &file_proto_task_service_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -194,7 +553,7 @@ func (x *CreateTaskResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateTaskResponse.ProtoReflect.Descriptor instead.
 func (*CreateTaskResponse) Descriptor() ([]byte, []int) {
-	return file_proto_task_service_proto_rawDescGZIP(), []int{2}
+	return file_proto_task_service_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *CreateTaskResponse) GetTask() *Task {
@@ -204,29 +563,3050 @@ func (x *CreateTaskResponse) GetTask() *Task {
 	return nil
 }
 
-// GetTask
-type GetTaskRequest struct {
+// GetTask
+type GetTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Fills in the children of the task and of all its subtasks.
+	IncludeChildren bool `protobuf:"varint,2,opt,name=include_children,json=includeChildren,proto3" json:"include_children,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetTaskRequest) Reset() {
+	*x = GetTaskRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskRequest) ProtoMessage() {}
+
+func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetTaskRequest) GetIncludeChildren() bool {
+	if x != nil {
+		return x.IncludeChildren
+	}
+	return false
+}
+
+type GetTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskResponse) Reset() {
+	*x = GetTaskResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskResponse) ProtoMessage() {}
+
+func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskResponse.ProtoReflect.Descriptor instead.
+func (*GetTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+// ListTasks
+type ListTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maximum number of tasks to return. Defaults to 50 and is capped at 1000.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from a previous response. All other fields must match
+	// the request that produced the token.
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// When set, only tasks with this completion state are returned.
+	Completed *bool `protobuf:"varint,3,opt,name=completed,proto3,oneof" json:"completed,omitempty"`
+	// Inclusive lower and exclusive upper bounds on created_at and updated_at.
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	UpdatedAfter  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_after,json=updatedAfter,proto3" json:"updated_after,omitempty"`
+	UpdatedBefore *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_before,json=updatedBefore,proto3" json:"updated_before,omitempty"`
+	// Comma-separated sort fields, each optionally followed by "asc" or "desc",
+	// e.g. "updated_at desc". Supported fields: created_at, updated_at, title,
+	// due_at, priority. Tasks without a due date sort after all others in
+	// ascending order, so "priority desc, due_at" lists the most urgent and
+	// soonest due tasks first. Defaults to "created_at desc".
+	OrderBy string `protobuf:"bytes,8,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// When set, only open tasks past their due date (overdue = true) or all
+	// other tasks (overdue = false) are returned. All-day tasks become overdue
+	// the day after their due date.
+	Overdue *bool `protobuf:"varint,9,opt,name=overdue,proto3,oneof" json:"overdue,omitempty"`
+	// Inclusive lower and exclusive upper bounds on due_at. Tasks without a due
+	// date never match.
+	DueAfter  *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=due_after,json=dueAfter,proto3" json:"due_after,omitempty"`
+	DueBefore *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=due_before,json=dueBefore,proto3" json:"due_before,omitempty"`
+	// When set, only tasks carrying every one of these label names are returned.
+	Labels []string `protobuf:"bytes,12,rep,name=labels,proto3" json:"labels,omitempty"`
+	// When set, only tasks in this project are returned.
+	ProjectId     string `protobuf:"bytes,13,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksRequest) ProtoMessage() {}
+
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListTasksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetCompleted() bool {
+	if x != nil && x.Completed != nil {
+		return *x.Completed
+	}
+	return false
+}
+
+func (x *ListTasksRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetUpdatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAfter
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetUpdatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedBefore
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetOverdue() bool {
+	if x != nil && x.Overdue != nil {
+		return *x.Overdue
+	}
+	return false
+}
+
+func (x *ListTasksRequest) GetDueAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueAfter
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetDueBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueBefore
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type ListTasksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Tasks []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// Token for the next page, empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Number of tasks matching the filters across all pages.
+	TotalSize     int32 `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksResponse) Reset() {
+	*x = ListTasksResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksResponse) ProtoMessage() {}
+
+func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTasksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListTasksResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *ListTasksResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListTasksResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+// SearchTasks
+type SearchTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Free-text query matched against task titles and descriptions. Every
+	// word must match, as a prefix, in either field.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Same pagination contract as ListTasksRequest.
+	PageSize      int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchTasksRequest) Reset() {
+	*x = SearchTasksRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchTasksRequest) ProtoMessage() {}
+
+func (x *SearchTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchTasksRequest.ProtoReflect.Descriptor instead.
+func (*SearchTasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SearchTasksRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchTasksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchTasksRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type SearchResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Task  *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// bm25 relevance score. Lower scores are more relevant.
+	Score float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// HTML-escaped title with matching terms wrapped in <mark></mark>.
+	TitleHighlight string `protobuf:"bytes,3,opt,name=title_highlight,json=titleHighlight,proto3" json:"title_highlight,omitempty"`
+	// HTML-escaped excerpt of the description around the matching terms.
+	DescriptionSnippet string `protobuf:"bytes,4,opt,name=description_snippet,json=descriptionSnippet,proto3" json:"description_snippet,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_proto_task_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SearchResult) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *SearchResult) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SearchResult) GetTitleHighlight() string {
+	if x != nil {
+		return x.TitleHighlight
+	}
+	return ""
+}
+
+func (x *SearchResult) GetDescriptionSnippet() string {
+	if x != nil {
+		return x.DescriptionSnippet
+	}
+	return ""
+}
+
+type SearchTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*SearchResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalSize     int32                  `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchTasksResponse) Reset() {
+	*x = SearchTasksResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchTasksResponse) ProtoMessage() {}
+
+func (x *SearchTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchTasksResponse.ProtoReflect.Descriptor instead.
+func (*SearchTasksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SearchTasksResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchTasksResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *SearchTasksResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+// CompleteTask
+type CompleteTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteTaskRequest) Reset() {
+	*x = CompleteTaskRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteTaskRequest) ProtoMessage() {}
+
+func (x *CompleteTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteTaskRequest.ProtoReflect.Descriptor instead.
+func (*CompleteTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CompleteTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CompleteTaskResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Task  *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// Next occurrence created by completing a recurring task. Unset when the
+	// task does not recur, its series has ended or the occurrence already
+	// existed.
+	NextOccurrence *Task `protobuf:"bytes,2,opt,name=next_occurrence,json=nextOccurrence,proto3" json:"next_occurrence,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CompleteTaskResponse) Reset() {
+	*x = CompleteTaskResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteTaskResponse) ProtoMessage() {}
+
+func (x *CompleteTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteTaskResponse.ProtoReflect.Descriptor instead.
+func (*CompleteTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CompleteTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *CompleteTaskResponse) GetNextOccurrence() *Task {
+	if x != nil {
+		return x.NextOccurrence
+	}
+	return nil
+}
+
+// ReopenTask
+type ReopenTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReopenTaskRequest) Reset() {
+	*x = ReopenTaskRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReopenTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReopenTaskRequest) ProtoMessage() {}
+
+func (x *ReopenTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReopenTaskRequest.ProtoReflect.Descriptor instead.
+func (*ReopenTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ReopenTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ReopenTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReopenTaskResponse) Reset() {
+	*x = ReopenTaskResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReopenTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReopenTaskResponse) ProtoMessage() {}
+
+func (x *ReopenTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReopenTaskResponse.ProtoReflect.Descriptor instead.
+func (*ReopenTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ReopenTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+// ToggleTaskCompletion
+type ToggleTaskCompletionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToggleTaskCompletionRequest) Reset() {
+	*x = ToggleTaskCompletionRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToggleTaskCompletionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToggleTaskCompletionRequest) ProtoMessage() {}
+
+func (x *ToggleTaskCompletionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToggleTaskCompletionRequest.ProtoReflect.Descriptor instead.
+func (*ToggleTaskCompletionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ToggleTaskCompletionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ToggleTaskCompletionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Task  *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// See CompleteTaskResponse.next_occurrence.
+	NextOccurrence *Task `protobuf:"bytes,2,opt,name=next_occurrence,json=nextOccurrence,proto3" json:"next_occurrence,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ToggleTaskCompletionResponse) Reset() {
+	*x = ToggleTaskCompletionResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToggleTaskCompletionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToggleTaskCompletionResponse) ProtoMessage() {}
+
+func (x *ToggleTaskCompletionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToggleTaskCompletionResponse.ProtoReflect.Descriptor instead.
+func (*ToggleTaskCompletionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ToggleTaskCompletionResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *ToggleTaskCompletionResponse) GetNextOccurrence() *Task {
+	if x != nil {
+		return x.NextOccurrence
+	}
+	return nil
+}
+
+// UpdateTask
+type UpdateTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The task to update. Its id identifies the task; only the fields named in
+	// update_mask are read from it.
+	Task *Task `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// Fields to change. Supported paths: title, description, completed, due_at,
+	// all_day, priority, project_id, parent_id, auto_complete, recurrence.
+	// Naming due_at with an unset value clears the due date; an empty
+	// project_id moves the task out of its project and an empty parent_id
+	// makes it a top-level task. recurrence changes only this occurrence; use
+	// UpdateTaskSeries for the whole series.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskRequest) Reset() {
+	*x = UpdateTaskRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskRequest) ProtoMessage() {}
+
+func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateTaskRequest) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskResponse) Reset() {
+	*x = UpdateTaskResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskResponse) ProtoMessage() {}
+
+func (x *UpdateTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UpdateTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+// DeleteTask moves a task and its subtasks to the trash.
+type DeleteTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskRequest) Reset() {
+	*x = DeleteTaskRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskRequest) ProtoMessage() {}
+
+func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DeleteTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskResponse) Reset() {
+	*x = DeleteTaskResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskResponse) ProtoMessage() {}
+
+func (x *DeleteTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DeleteTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+// RestoreTask moves a task out of the trash, with the subtasks that were
+// trashed along with it. A subtask whose parent is still in the trash is
+// restored as a top-level task.
+type RestoreTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreTaskRequest) Reset() {
+	*x = RestoreTaskRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreTaskRequest) ProtoMessage() {}
+
+func (x *RestoreTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreTaskRequest.ProtoReflect.Descriptor instead.
+func (*RestoreTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RestoreTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RestoreTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreTaskResponse) Reset() {
+	*x = RestoreTaskResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreTaskResponse) ProtoMessage() {}
+
+func (x *RestoreTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreTaskResponse.ProtoReflect.Descriptor instead.
+func (*RestoreTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RestoreTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+// PurgeTask permanently removes a task that is in the trash, and its subtasks.
+type PurgeTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeTaskRequest) Reset() {
+	*x = PurgeTaskRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeTaskRequest) ProtoMessage() {}
+
+func (x *PurgeTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeTaskRequest.ProtoReflect.Descriptor instead.
+func (*PurgeTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PurgeTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type PurgeTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeTaskResponse) Reset() {
+	*x = PurgeTaskResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeTaskResponse) ProtoMessage() {}
+
+func (x *PurgeTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeTaskResponse.ProtoReflect.Descriptor instead.
+func (*PurgeTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{24}
+}
+
+// ListTrash
+type ListTrashRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrashRequest) Reset() {
+	*x = ListTrashRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashRequest) ProtoMessage() {}
+
+func (x *ListTrashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashRequest.ProtoReflect.Descriptor instead.
+func (*ListTrashRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{25}
+}
+
+type ListTrashResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrashResponse) Reset() {
+	*x = ListTrashResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashResponse) ProtoMessage() {}
+
+func (x *ListTrashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashResponse.ProtoReflect.Descriptor instead.
+func (*ListTrashResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListTrashResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+// CreateLabel
+type CreateLabelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLabelRequest) Reset() {
+	*x = CreateLabelRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLabelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLabelRequest) ProtoMessage() {}
+
+func (x *CreateLabelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLabelRequest.ProtoReflect.Descriptor instead.
+func (*CreateLabelRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CreateLabelRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateLabelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Label         *Label                 `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLabelResponse) Reset() {
+	*x = CreateLabelResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLabelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLabelResponse) ProtoMessage() {}
+
+func (x *CreateLabelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLabelResponse.ProtoReflect.Descriptor instead.
+func (*CreateLabelResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CreateLabelResponse) GetLabel() *Label {
+	if x != nil {
+		return x.Label
+	}
+	return nil
+}
+
+// ListLabels
+type ListLabelsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLabelsRequest) Reset() {
+	*x = ListLabelsRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLabelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLabelsRequest) ProtoMessage() {}
+
+func (x *ListLabelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLabelsRequest.ProtoReflect.Descriptor instead.
+func (*ListLabelsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{29}
+}
+
+type ListLabelsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// All labels, in alphabetical order.
+	Labels        []*Label `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLabelsResponse) Reset() {
+	*x = ListLabelsResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLabelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLabelsResponse) ProtoMessage() {}
+
+func (x *ListLabelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLabelsResponse.ProtoReflect.Descriptor instead.
+func (*ListLabelsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListLabelsResponse) GetLabels() []*Label {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+// RenameLabel
+type RenameLabelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameLabelRequest) Reset() {
+	*x = RenameLabelRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameLabelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameLabelRequest) ProtoMessage() {}
+
+func (x *RenameLabelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameLabelRequest.ProtoReflect.Descriptor instead.
+func (*RenameLabelRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RenameLabelRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RenameLabelRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type RenameLabelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Label         *Label                 `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameLabelResponse) Reset() {
+	*x = RenameLabelResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameLabelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameLabelResponse) ProtoMessage() {}
+
+func (x *RenameLabelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameLabelResponse.ProtoReflect.Descriptor instead.
+func (*RenameLabelResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RenameLabelResponse) GetLabel() *Label {
+	if x != nil {
+		return x.Label
+	}
+	return nil
+}
+
+// DeleteLabel removes the label from every task that carries it.
+type DeleteLabelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLabelRequest) Reset() {
+	*x = DeleteLabelRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLabelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLabelRequest) ProtoMessage() {}
+
+func (x *DeleteLabelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLabelRequest.ProtoReflect.Descriptor instead.
+func (*DeleteLabelRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DeleteLabelRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteLabelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLabelResponse) Reset() {
+	*x = DeleteLabelResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLabelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLabelResponse) ProtoMessage() {}
+
+func (x *DeleteLabelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLabelResponse.ProtoReflect.Descriptor instead.
+func (*DeleteLabelResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{34}
+}
+
+// AddLabels attaches labels to a task by name, creating labels that do not
+// exist yet.
+type AddLabelsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Labels        []string               `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddLabelsRequest) Reset() {
+	*x = AddLabelsRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddLabelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddLabelsRequest) ProtoMessage() {}
+
+func (x *AddLabelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddLabelsRequest.ProtoReflect.Descriptor instead.
+func (*AddLabelsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *AddLabelsRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *AddLabelsRequest) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type AddLabelsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddLabelsResponse) Reset() {
+	*x = AddLabelsResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddLabelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddLabelsResponse) ProtoMessage() {}
+
+func (x *AddLabelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddLabelsResponse.ProtoReflect.Descriptor instead.
+func (*AddLabelsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *AddLabelsResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+// RemoveLabels detaches labels from a task by name. Names the task does not
+// carry are ignored.
+type RemoveLabelsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Labels        []string               `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveLabelsRequest) Reset() {
+	*x = RemoveLabelsRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveLabelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveLabelsRequest) ProtoMessage() {}
+
+func (x *RemoveLabelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveLabelsRequest.ProtoReflect.Descriptor instead.
+func (*RemoveLabelsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *RemoveLabelsRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *RemoveLabelsRequest) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type RemoveLabelsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveLabelsResponse) Reset() {
+	*x = RemoveLabelsResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveLabelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveLabelsResponse) ProtoMessage() {}
+
+func (x *RemoveLabelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveLabelsResponse.ProtoReflect.Descriptor instead.
+func (*RemoveLabelsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RemoveLabelsResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+// Project groups tasks into a separate board or list.
+type Project struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Display color as "#rrggbb", or empty.
+	Color string `protobuf:"bytes,3,opt,name=color,proto3" json:"color,omitempty"`
+	// Archived projects are hidden from ListProjects by default and accept no new tasks.
+	Archived bool `protobuf:"varint,4,opt,name=archived,proto3" json:"archived,omitempty"`
+	// Position in project listings, ascending.
+	SortOrder     int32                  `protobuf:"varint,5,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Project) Reset() {
+	*x = Project{}
+	mi := &file_proto_task_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Project) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Project) ProtoMessage() {}
+
+func (x *Project) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Project.ProtoReflect.Descriptor instead.
+func (*Project) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *Project) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Project) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Project) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *Project) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *Project) GetSortOrder() int32 {
+	if x != nil {
+		return x.SortOrder
+	}
+	return 0
+}
+
+func (x *Project) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Project) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// CreateProject
+type CreateProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Color         string                 `protobuf:"bytes,2,opt,name=color,proto3" json:"color,omitempty"`
+	SortOrder     int32                  `protobuf:"varint,3,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProjectRequest) Reset() {
+	*x = CreateProjectRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProjectRequest) ProtoMessage() {}
+
+func (x *CreateProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProjectRequest.ProtoReflect.Descriptor instead.
+func (*CreateProjectRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CreateProjectRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetSortOrder() int32 {
+	if x != nil {
+		return x.SortOrder
+	}
+	return 0
+}
+
+type CreateProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProjectResponse) Reset() {
+	*x = CreateProjectResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProjectResponse) ProtoMessage() {}
+
+func (x *CreateProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProjectResponse.ProtoReflect.Descriptor instead.
+func (*CreateProjectResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CreateProjectResponse) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+// GetProject
+type GetProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProjectRequest) Reset() {
+	*x = GetProjectRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProjectRequest) ProtoMessage() {}
+
+func (x *GetProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProjectRequest.ProtoReflect.Descriptor instead.
+func (*GetProjectRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetProjectRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProjectResponse) Reset() {
+	*x = GetProjectResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProjectResponse) ProtoMessage() {}
+
+func (x *GetProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProjectResponse.ProtoReflect.Descriptor instead.
+func (*GetProjectResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetProjectResponse) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+// ListProjects
+type ListProjectsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	IncludeArchived bool                   `protobuf:"varint,1,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListProjectsRequest) Reset() {
+	*x = ListProjectsRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsRequest) ProtoMessage() {}
+
+func (x *ListProjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsRequest.ProtoReflect.Descriptor instead.
+func (*ListProjectsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListProjectsRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+type ListProjectsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Ordered by sort_order, then name.
+	Projects      []*Project `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsResponse) Reset() {
+	*x = ListProjectsResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsResponse) ProtoMessage() {}
+
+func (x *ListProjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsResponse.ProtoReflect.Descriptor instead.
+func (*ListProjectsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListProjectsResponse) GetProjects() []*Project {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+// UpdateProject
+type UpdateProjectRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The project to update. Its id identifies the project; only the fields
+	// named in update_mask are read from it.
+	Project *Project `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	// Fields to change. Supported paths: name, color, archived, sort_order.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProjectRequest) Reset() {
+	*x = UpdateProjectRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProjectRequest) ProtoMessage() {}
+
+func (x *UpdateProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProjectRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProjectRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *UpdateProjectRequest) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+func (x *UpdateProjectRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProjectResponse) Reset() {
+	*x = UpdateProjectResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProjectResponse) ProtoMessage() {}
+
+func (x *UpdateProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProjectResponse.ProtoReflect.Descriptor instead.
+func (*UpdateProjectResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *UpdateProjectResponse) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+// DeleteProject removes a project. Its tasks are kept and moved out of the project.
+type DeleteProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProjectRequest) Reset() {
+	*x = DeleteProjectRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProjectRequest) ProtoMessage() {}
+
+func (x *DeleteProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProjectRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProjectRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *DeleteProjectRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProjectResponse) Reset() {
+	*x = DeleteProjectResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProjectResponse) ProtoMessage() {}
+
+func (x *DeleteProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProjectResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProjectResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{49}
+}
+
+// ListSubtasks
+type ListSubtasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ParentId      string                 `protobuf:"bytes,1,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubtasksRequest) Reset() {
+	*x = ListSubtasksRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubtasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubtasksRequest) ProtoMessage() {}
+
+func (x *ListSubtasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubtasksRequest.ProtoReflect.Descriptor instead.
+func (*ListSubtasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ListSubtasksRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+type ListSubtasksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Direct subtasks of the parent, oldest first.
+	Tasks         []*Task `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubtasksResponse) Reset() {
+	*x = ListSubtasksResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubtasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubtasksResponse) ProtoMessage() {}
+
+func (x *ListSubtasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubtasksResponse.ProtoReflect.Descriptor instead.
+func (*ListSubtasksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ListSubtasksResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+// UpdateTaskSeries
+type UpdateTaskSeriesRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	SeriesId string                 `protobuf:"bytes,1,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`
+	// Only the fields named in update_mask are read.
+	Task *Task `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	// Fields to change on every open occurrence of the series. Supported
+	// paths: title, description, priority, project_id, recurrence. An empty
+	// recurrence ends the series: open occurrences stay but no further ones
+	// are created.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskSeriesRequest) Reset() {
+	*x = UpdateTaskSeriesRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskSeriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskSeriesRequest) ProtoMessage() {}
+
+func (x *UpdateTaskSeriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskSeriesRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTaskSeriesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *UpdateTaskSeriesRequest) GetSeriesId() string {
+	if x != nil {
+		return x.SeriesId
+	}
+	return ""
+}
+
+func (x *UpdateTaskSeriesRequest) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *UpdateTaskSeriesRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateTaskSeriesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The open occurrences, after the update.
+	Tasks         []*Task `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskSeriesResponse) Reset() {
+	*x = UpdateTaskSeriesResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskSeriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskSeriesResponse) ProtoMessage() {}
+
+func (x *UpdateTaskSeriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskSeriesResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTaskSeriesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *UpdateTaskSeriesResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+// WatchTasks
+type WatchTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Resumes after the event with this sequence number: buffered events that
+	// followed it are sent first. 0 sends only events from now on. Fails with
+	// OUT_OF_RANGE when those events are no longer buffered, e.g. after a
+	// restart; the client then reloads its tasks and watches from 0.
+	AfterSequence uint64 `protobuf:"varint,1,opt,name=after_sequence,json=afterSequence,proto3" json:"after_sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTasksRequest) Reset() {
+	*x = WatchTasksRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTasksRequest) ProtoMessage() {}
+
+func (x *WatchTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTasksRequest.ProtoReflect.Descriptor instead.
+func (*WatchTasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *WatchTasksRequest) GetAfterSequence() uint64 {
+	if x != nil {
+		return x.AfterSequence
+	}
+	return 0
+}
+
+// TaskEvent is a change to a task. Renaming or deleting labels and deleting
+// projects do not emit events for the tasks they touch.
+type TaskEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Increases with every event; pass the last one seen to
+	// WatchTasksRequest.after_sequence to resume.
+	Sequence uint64         `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Type     TaskEvent_Type `protobuf:"varint,2,opt,name=type,proto3,enum=task_service.TaskEvent_Type" json:"type,omitempty"`
+	// The task after the change. Restored tasks are sent as updates.
+	Task          *Task                  `protobuf:"bytes,3,opt,name=task,proto3" json:"task,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskEvent) Reset() {
+	*x = TaskEvent{}
+	mi := &file_proto_task_service_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskEvent) ProtoMessage() {}
+
+func (x *TaskEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskEvent.ProtoReflect.Descriptor instead.
+func (*TaskEvent) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *TaskEvent) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *TaskEvent) GetType() TaskEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return TaskEvent_TYPE_UNSPECIFIED
+}
+
+func (x *TaskEvent) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *TaskEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+// GetTaskStats
+type GetTaskStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// When set, only tasks in this project are counted.
+	ProjectId string `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Counts only tasks without subtasks, so a parent and its children are
+	// not counted twice.
+	LeavesOnly    bool `protobuf:"varint,2,opt,name=leaves_only,json=leavesOnly,proto3" json:"leaves_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskStatsRequest) Reset() {
+	*x = GetTaskStatsRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskStatsRequest) ProtoMessage() {}
+
+func (x *GetTaskStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskStatsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetTaskStatsRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *GetTaskStatsRequest) GetLeavesOnly() bool {
+	if x != nil {
+		return x.LeavesOnly
+	}
+	return false
+}
+
+type GetTaskStatsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TotalTasks     int32                  `protobuf:"varint,1,opt,name=total_tasks,json=totalTasks,proto3" json:"total_tasks,omitempty"`
+	CompletedTasks int32                  `protobuf:"varint,2,opt,name=completed_tasks,json=completedTasks,proto3" json:"completed_tasks,omitempty"`
+	PendingTasks   int32                  `protobuf:"varint,3,opt,name=pending_tasks,json=pendingTasks,proto3" json:"pending_tasks,omitempty"`
+	// Open tasks past their due date.
+	OverdueTasks int32 `protobuf:"varint,4,opt,name=overdue_tasks,json=overdueTasks,proto3" json:"overdue_tasks,omitempty"`
+	// Open tasks due today in the storage service's time zone.
+	DueTodayTasks int32 `protobuf:"varint,5,opt,name=due_today_tasks,json=dueTodayTasks,proto3" json:"due_today_tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskStatsResponse) Reset() {
+	*x = GetTaskStatsResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskStatsResponse) ProtoMessage() {}
+
+func (x *GetTaskStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetTaskStatsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *GetTaskStatsResponse) GetTotalTasks() int32 {
+	if x != nil {
+		return x.TotalTasks
+	}
+	return 0
+}
+
+func (x *GetTaskStatsResponse) GetCompletedTasks() int32 {
+	if x != nil {
+		return x.CompletedTasks
+	}
+	return 0
+}
+
+func (x *GetTaskStatsResponse) GetPendingTasks() int32 {
+	if x != nil {
+		return x.PendingTasks
+	}
+	return 0
+}
+
+func (x *GetTaskStatsResponse) GetOverdueTasks() int32 {
+	if x != nil {
+		return x.OverdueTasks
+	}
+	return 0
+}
+
+func (x *GetTaskStatsResponse) GetDueTodayTasks() int32 {
+	if x != nil {
+		return x.DueTodayTasks
+	}
+	return 0
+}
+
+// User is an account. Tasks, labels and projects belong to one user.
+type User struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_proto_task_service_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *User) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *User) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Register
+type RegisterRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Email string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	// 8 to 72 bytes.
+	Password      string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterRequest) Reset() {
+	*x = RegisterRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterRequest) ProtoMessage() {}
+
+func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterRequest.ProtoReflect.Descriptor instead.
+func (*RegisterRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *RegisterRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type RegisterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterResponse) Reset() {
+	*x = RegisterResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterResponse) ProtoMessage() {}
+
+func (x *RegisterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterResponse.ProtoReflect.Descriptor instead.
+func (*RegisterResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *RegisterResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+// Login
+type LoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginRequest) ProtoMessage() {}
+
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *LoginRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type LoginResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTaskRequest) Reset() {
-	*x = GetTaskRequest{}
-	mi := &file_proto_task_service_proto_msgTypes[3]
+func (x *LoginResponse) Reset() {
+	*x = LoginResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTaskRequest) String() string {
+func (x *LoginResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTaskRequest) ProtoMessage() {}
+func (*LoginResponse) ProtoMessage() {}
 
-func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_service_proto_msgTypes[3]
+func (x *LoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -237,40 +3617,50 @@ func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
-func (*GetTaskRequest) Descriptor() ([]byte, []int) {
-	return file_proto_task_service_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
+func (*LoginResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{62}
 }
 
-func (x *GetTaskRequest) GetId() string {
+func (x *LoginResponse) GetUser() *User {
 	if x != nil {
-		return x.Id
+		return x.User
 	}
-	return ""
+	return nil
 }
 
-type GetTaskResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+// ApiToken is a personal access token for scripts. The token itself is only
+// returned when it is created; the storage service keeps a hash of it.
+type ApiToken struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// "read", "write" or "admin". Each scope includes the ones before it.
+	Scopes    []string               `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Unset for tokens that do not expire.
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Unset until the token is first used.
+	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTaskResponse) Reset() {
-	*x = GetTaskResponse{}
-	mi := &file_proto_task_service_proto_msgTypes[4]
+func (x *ApiToken) Reset() {
+	*x = ApiToken{}
+	mi := &file_proto_task_service_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTaskResponse) String() string {
+func (x *ApiToken) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTaskResponse) ProtoMessage() {}
+func (*ApiToken) ProtoMessage() {}
 
-func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_service_proto_msgTypes[4]
+func (x *ApiToken) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -281,40 +3671,79 @@ func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTaskResponse.ProtoReflect.Descriptor instead.
-func (*GetTaskResponse) Descriptor() ([]byte, []int) {
-	return file_proto_task_service_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ApiToken.ProtoReflect.Descriptor instead.
+func (*ApiToken) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{63}
 }
 
-func (x *GetTaskResponse) GetTask() *Task {
+func (x *ApiToken) GetId() string {
 	if x != nil {
-		return x.Task
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ApiToken) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ApiToken) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
 	}
 	return nil
 }
 
-// ListTasks
-type ListTasksRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *ApiToken) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ApiToken) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *ApiToken) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+// CreateApiToken
+type CreateApiTokenRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Name   string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Scopes []string               `protobuf:"bytes,2,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// Optional; must be in the future.
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTasksRequest) Reset() {
-	*x = ListTasksRequest{}
-	mi := &file_proto_task_service_proto_msgTypes[5]
+func (x *CreateApiTokenRequest) Reset() {
+	*x = CreateApiTokenRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTasksRequest) String() string {
+func (x *CreateApiTokenRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTasksRequest) ProtoMessage() {}
+func (*CreateApiTokenRequest) ProtoMessage() {}
 
-func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_service_proto_msgTypes[5]
+func (x *CreateApiTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -325,33 +3754,56 @@ func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
-func (*ListTasksRequest) Descriptor() ([]byte, []int) {
-	return file_proto_task_service_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use CreateApiTokenRequest.ProtoReflect.Descriptor instead.
+func (*CreateApiTokenRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{64}
 }
 
-type ListTasksResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+func (x *CreateApiTokenRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateApiTokenRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *CreateApiTokenRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type CreateApiTokenResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ApiToken *ApiToken              `protobuf:"bytes,1,opt,name=api_token,json=apiToken,proto3" json:"api_token,omitempty"`
+	// The secret to send as a bearer token. It cannot be retrieved again.
+	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTasksResponse) Reset() {
-	*x = ListTasksResponse{}
-	mi := &file_proto_task_service_proto_msgTypes[6]
+func (x *CreateApiTokenResponse) Reset() {
+	*x = CreateApiTokenResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTasksResponse) String() string {
+func (x *CreateApiTokenResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTasksResponse) ProtoMessage() {}
+func (*CreateApiTokenResponse) ProtoMessage() {}
 
-func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_service_proto_msgTypes[6]
+func (x *CreateApiTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -362,41 +3814,47 @@ func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
-func (*ListTasksResponse) Descriptor() ([]byte, []int) {
-	return file_proto_task_service_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use CreateApiTokenResponse.ProtoReflect.Descriptor instead.
+func (*CreateApiTokenResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{65}
 }
 
-func (x *ListTasksResponse) GetTasks() []*Task {
+func (x *CreateApiTokenResponse) GetApiToken() *ApiToken {
 	if x != nil {
-		return x.Tasks
+		return x.ApiToken
 	}
 	return nil
 }
 
-// CompleteTask
-type CompleteTaskRequest struct {
+func (x *CreateApiTokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// ListApiTokens
+type ListApiTokensRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CompleteTaskRequest) Reset() {
-	*x = CompleteTaskRequest{}
-	mi := &file_proto_task_service_proto_msgTypes[7]
+func (x *ListApiTokensRequest) Reset() {
+	*x = ListApiTokensRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CompleteTaskRequest) String() string {
+func (x *ListApiTokensRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CompleteTaskRequest) ProtoMessage() {}
+func (*ListApiTokensRequest) ProtoMessage() {}
 
-func (x *CompleteTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_service_proto_msgTypes[7]
+func (x *ListApiTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -407,40 +3865,33 @@ func (x *CompleteTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CompleteTaskRequest.ProtoReflect.Descriptor instead.
-func (*CompleteTaskRequest) Descriptor() ([]byte, []int) {
-	return file_proto_task_service_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *CompleteTaskRequest) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
+// Deprecated: Use ListApiTokensRequest.ProtoReflect.Descriptor instead.
+func (*ListApiTokensRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{66}
 }
 
-type CompleteTaskResponse struct {
+type ListApiTokensResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	ApiTokens     []*ApiToken            `protobuf:"bytes,1,rep,name=api_tokens,json=apiTokens,proto3" json:"api_tokens,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CompleteTaskResponse) Reset() {
-	*x = CompleteTaskResponse{}
-	mi := &file_proto_task_service_proto_msgTypes[8]
+func (x *ListApiTokensResponse) Reset() {
+	*x = ListApiTokensResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CompleteTaskResponse) String() string {
+func (x *ListApiTokensResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CompleteTaskResponse) ProtoMessage() {}
+func (*ListApiTokensResponse) ProtoMessage() {}
 
-func (x *CompleteTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_service_proto_msgTypes[8]
+func (x *ListApiTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -451,41 +3902,41 @@ func (x *CompleteTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CompleteTaskResponse.ProtoReflect.Descriptor instead.
-func (*CompleteTaskResponse) Descriptor() ([]byte, []int) {
-	return file_proto_task_service_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ListApiTokensResponse.ProtoReflect.Descriptor instead.
+func (*ListApiTokensResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{67}
 }
 
-func (x *CompleteTaskResponse) GetTask() *Task {
+func (x *ListApiTokensResponse) GetApiTokens() []*ApiToken {
 	if x != nil {
-		return x.Task
+		return x.ApiTokens
 	}
 	return nil
 }
 
-// ToggleTaskCompletion
-type ToggleTaskCompletionRequest struct {
+// RevokeApiToken
+type RevokeApiTokenRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ToggleTaskCompletionRequest) Reset() {
-	*x = ToggleTaskCompletionRequest{}
-	mi := &file_proto_task_service_proto_msgTypes[9]
+func (x *RevokeApiTokenRequest) Reset() {
+	*x = RevokeApiTokenRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ToggleTaskCompletionRequest) String() string {
+func (x *RevokeApiTokenRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ToggleTaskCompletionRequest) ProtoMessage() {}
+func (*RevokeApiTokenRequest) ProtoMessage() {}
 
-func (x *ToggleTaskCompletionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_service_proto_msgTypes[9]
+func (x *RevokeApiTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -496,40 +3947,39 @@ func (x *ToggleTaskCompletionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ToggleTaskCompletionRequest.ProtoReflect.Descriptor instead.
-func (*ToggleTaskCompletionRequest) Descriptor() ([]byte, []int) {
-	return file_proto_task_service_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use RevokeApiTokenRequest.ProtoReflect.Descriptor instead.
+func (*RevokeApiTokenRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{68}
 }
 
-func (x *ToggleTaskCompletionRequest) GetId() string {
+func (x *RevokeApiTokenRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-type ToggleTaskCompletionResponse struct {
+type RevokeApiTokenResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ToggleTaskCompletionResponse) Reset() {
-	*x = ToggleTaskCompletionResponse{}
-	mi := &file_proto_task_service_proto_msgTypes[10]
+func (x *RevokeApiTokenResponse) Reset() {
+	*x = RevokeApiTokenResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ToggleTaskCompletionResponse) String() string {
+func (x *RevokeApiTokenResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ToggleTaskCompletionResponse) ProtoMessage() {}
+func (*RevokeApiTokenResponse) ProtoMessage() {}
 
-func (x *ToggleTaskCompletionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_service_proto_msgTypes[10]
+func (x *RevokeApiTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -540,40 +3990,34 @@ func (x *ToggleTaskCompletionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ToggleTaskCompletionResponse.ProtoReflect.Descriptor instead.
-func (*ToggleTaskCompletionResponse) Descriptor() ([]byte, []int) {
-	return file_proto_task_service_proto_rawDescGZIP(), []int{10}
-}
-
-func (x *ToggleTaskCompletionResponse) GetTask() *Task {
-	if x != nil {
-		return x.Task
-	}
-	return nil
+// Deprecated: Use RevokeApiTokenResponse.ProtoReflect.Descriptor instead.
+func (*RevokeApiTokenResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{69}
 }
 
-// GetTaskStats
-type GetTaskStatsRequest struct {
+// VerifyApiToken
+type VerifyApiTokenRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTaskStatsRequest) Reset() {
-	*x = GetTaskStatsRequest{}
-	mi := &file_proto_task_service_proto_msgTypes[11]
+func (x *VerifyApiTokenRequest) Reset() {
+	*x = VerifyApiTokenRequest{}
+	mi := &file_proto_task_service_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTaskStatsRequest) String() string {
+func (x *VerifyApiTokenRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTaskStatsRequest) ProtoMessage() {}
+func (*VerifyApiTokenRequest) ProtoMessage() {}
 
-func (x *GetTaskStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_service_proto_msgTypes[11]
+func (x *VerifyApiTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -584,35 +4028,42 @@ func (x *GetTaskStatsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTaskStatsRequest.ProtoReflect.Descriptor instead.
-func (*GetTaskStatsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_task_service_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use VerifyApiTokenRequest.ProtoReflect.Descriptor instead.
+func (*VerifyApiTokenRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{70}
 }
 
-type GetTaskStatsResponse struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	TotalTasks     int32                  `protobuf:"varint,1,opt,name=total_tasks,json=totalTasks,proto3" json:"total_tasks,omitempty"`
-	CompletedTasks int32                  `protobuf:"varint,2,opt,name=completed_tasks,json=completedTasks,proto3" json:"completed_tasks,omitempty"`
-	PendingTasks   int32                  `protobuf:"varint,3,opt,name=pending_tasks,json=pendingTasks,proto3" json:"pending_tasks,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *VerifyApiTokenRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
 }
 
-func (x *GetTaskStatsResponse) Reset() {
-	*x = GetTaskStatsResponse{}
-	mi := &file_proto_task_service_proto_msgTypes[12]
+type VerifyApiTokenResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The user the token acts for.
+	UserId        string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Scopes        []string `protobuf:"bytes,2,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyApiTokenResponse) Reset() {
+	*x = VerifyApiTokenResponse{}
+	mi := &file_proto_task_service_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTaskStatsResponse) String() string {
+func (x *VerifyApiTokenResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTaskStatsResponse) ProtoMessage() {}
+func (*VerifyApiTokenResponse) ProtoMessage() {}
 
-func (x *GetTaskStatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_service_proto_msgTypes[12]
+func (x *VerifyApiTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_service_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -623,37 +4074,30 @@ func (x *GetTaskStatsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTaskStatsResponse.ProtoReflect.Descriptor instead.
-func (*GetTaskStatsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_task_service_proto_rawDescGZIP(), []int{12}
-}
-
-func (x *GetTaskStatsResponse) GetTotalTasks() int32 {
-	if x != nil {
-		return x.TotalTasks
-	}
-	return 0
+// Deprecated: Use VerifyApiTokenResponse.ProtoReflect.Descriptor instead.
+func (*VerifyApiTokenResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_service_proto_rawDescGZIP(), []int{71}
 }
 
-func (x *GetTaskStatsResponse) GetCompletedTasks() int32 {
+func (x *VerifyApiTokenResponse) GetUserId() string {
 	if x != nil {
-		return x.CompletedTasks
+		return x.UserId
 	}
-	return 0
+	return ""
 }
 
-func (x *GetTaskStatsResponse) GetPendingTasks() int32 {
+func (x *VerifyApiTokenResponse) GetScopes() []string {
 	if x != nil {
-		return x.PendingTasks
+		return x.Scopes
 	}
-	return 0
+	return nil
 }
 
 var File_proto_task_service_proto protoreflect.FileDescriptor
 
 const file_proto_task_service_proto_rawDesc = "" +
 	"\n" +
-	"\x18proto/task_service.proto\x12\ftask_service\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe2\x01\n" +
+	"\x18proto/task_service.proto\x12\ftask_service\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9e\x05\n" +
 	"\x04Task\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
@@ -662,41 +4106,315 @@ const file_proto_task_service_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"K\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
+	"\n" +
+	"deleted_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x121\n" +
+	"\x06due_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x05dueAt\x12\x17\n" +
+	"\aall_day\x18\t \x01(\bR\x06allDay\x122\n" +
+	"\bpriority\x18\n" +
+	" \x01(\x0e2\x16.task_service.PriorityR\bpriority\x12\x16\n" +
+	"\x06labels\x18\v \x03(\tR\x06labels\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\f \x01(\tR\tprojectId\x12\x1b\n" +
+	"\tparent_id\x18\r \x01(\tR\bparentId\x12#\n" +
+	"\rauto_complete\x18\x0e \x01(\bR\fautoComplete\x12.\n" +
+	"\bchildren\x18\x0f \x03(\v2\x12.task_service.TaskR\bchildren\x12\x1e\n" +
+	"\n" +
+	"recurrence\x18\x10 \x01(\tR\n" +
+	"recurrence\x12\x1b\n" +
+	"\tseries_id\x18\x11 \x01(\tR\bseriesId\x12\x19\n" +
+	"\bowner_id\x18\x12 \x01(\tR\aownerId\"\x85\x01\n" +
+	"\x05Label\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"task_count\x18\x03 \x01(\x05R\ttaskCount\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xcc\x02\n" +
 	"\x11CreateTaskRequest\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
-	"\vdescription\x18\x02 \x01(\tR\vdescription\"<\n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x121\n" +
+	"\x06due_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05dueAt\x12\x17\n" +
+	"\aall_day\x18\x04 \x01(\bR\x06allDay\x122\n" +
+	"\bpriority\x18\x05 \x01(\x0e2\x16.task_service.PriorityR\bpriority\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x06 \x01(\tR\tprojectId\x12\x1b\n" +
+	"\tparent_id\x18\a \x01(\tR\bparentId\x12#\n" +
+	"\rauto_complete\x18\b \x01(\bR\fautoComplete\x12\x1e\n" +
+	"\n" +
+	"recurrence\x18\t \x01(\tR\n" +
+	"recurrence\"<\n" +
 	"\x12CreateTaskResponse\x12&\n" +
-	"\x04task\x18\x01 \x01(\v2\x12.task_service.TaskR\x04task\" \n" +
+	"\x04task\x18\x01 \x01(\v2\x12.task_service.TaskR\x04task\"K\n" +
 	"\x0eGetTaskRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"9\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12)\n" +
+	"\x10include_children\x18\x02 \x01(\bR\x0fincludeChildren\"9\n" +
 	"\x0fGetTaskResponse\x12&\n" +
-	"\x04task\x18\x01 \x01(\v2\x12.task_service.TaskR\x04task\"\x12\n" +
-	"\x10ListTasksRequest\"=\n" +
+	"\x04task\x18\x01 \x01(\v2\x12.task_service.TaskR\x04task\"\xf8\x04\n" +
+	"\x10ListTasksRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12!\n" +
+	"\tcompleted\x18\x03 \x01(\bH\x00R\tcompleted\x88\x01\x01\x12?\n" +
+	"\rcreated_after\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12?\n" +
+	"\rupdated_after\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\fupdatedAfter\x12A\n" +
+	"\x0eupdated_before\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\rupdatedBefore\x12\x19\n" +
+	"\border_by\x18\b \x01(\tR\aorderBy\x12\x1d\n" +
+	"\aoverdue\x18\t \x01(\bH\x01R\aoverdue\x88\x01\x01\x127\n" +
+	"\tdue_after\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\bdueAfter\x129\n" +
+	"\n" +
+	"due_before\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tdueBefore\x12\x16\n" +
+	"\x06labels\x18\f \x03(\tR\x06labels\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\r \x01(\tR\tprojectIdB\f\n" +
+	"\n" +
+	"_completedB\n" +
+	"\n" +
+	"\b_overdue\"\x84\x01\n" +
 	"\x11ListTasksResponse\x12(\n" +
-	"\x05tasks\x18\x01 \x03(\v2\x12.task_service.TaskR\x05tasks\"%\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x12.task_service.TaskR\x05tasks\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\"f\n" +
+	"\x12SearchTasksRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\xa6\x01\n" +
+	"\fSearchResult\x12&\n" +
+	"\x04task\x18\x01 \x01(\v2\x12.task_service.TaskR\x04task\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12'\n" +
+	"\x0ftitle_highlight\x18\x03 \x01(\tR\x0etitleHighlight\x12/\n" +
+	"\x13description_snippet\x18\x04 \x01(\tR\x12descriptionSnippet\"\x92\x01\n" +
+	"\x13SearchTasksResponse\x124\n" +
+	"\aresults\x18\x01 \x03(\v2\x1a.task_service.SearchResultR\aresults\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\"%\n" +
 	"\x13CompleteTaskRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\">\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"{\n" +
 	"\x14CompleteTaskResponse\x12&\n" +
+	"\x04task\x18\x01 \x01(\v2\x12.task_service.TaskR\x04task\x12;\n" +
+	"\x0fnext_occurrence\x18\x02 \x01(\v2\x12.task_service.TaskR\x0enextOccurrence\"#\n" +
+	"\x11ReopenTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"<\n" +
+	"\x12ReopenTaskResponse\x12&\n" +
 	"\x04task\x18\x01 \x01(\v2\x12.task_service.TaskR\x04task\"-\n" +
 	"\x1bToggleTaskCompletionRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"F\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x83\x01\n" +
 	"\x1cToggleTaskCompletionResponse\x12&\n" +
-	"\x04task\x18\x01 \x01(\v2\x12.task_service.TaskR\x04task\"\x15\n" +
-	"\x13GetTaskStatsRequest\"\x85\x01\n" +
+	"\x04task\x18\x01 \x01(\v2\x12.task_service.TaskR\x04task\x12;\n" +
+	"\x0fnext_occurrence\x18\x02 \x01(\v2\x12.task_service.TaskR\x0enextOccurrence\"x\n" +
+	"\x11UpdateTaskRequest\x12&\n" +
+	"\x04task\x18\x01 \x01(\v2\x12.task_service.TaskR\x04task\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"<\n" +
+	"\x12UpdateTaskResponse\x12&\n" +
+	"\x04task\x18\x01 \x01(\v2\x12.task_service.TaskR\x04task\"#\n" +
+	"\x11DeleteTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"<\n" +
+	"\x12DeleteTaskResponse\x12&\n" +
+	"\x04task\x18\x01 \x01(\v2\x12.task_service.TaskR\x04task\"$\n" +
+	"\x12RestoreTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"=\n" +
+	"\x13RestoreTaskResponse\x12&\n" +
+	"\x04task\x18\x01 \x01(\v2\x12.task_service.TaskR\x04task\"\"\n" +
+	"\x10PurgeTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x13\n" +
+	"\x11PurgeTaskResponse\"\x12\n" +
+	"\x10ListTrashRequest\"=\n" +
+	"\x11ListTrashResponse\x12(\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x12.task_service.TaskR\x05tasks\"(\n" +
+	"\x12CreateLabelRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"@\n" +
+	"\x13CreateLabelResponse\x12)\n" +
+	"\x05label\x18\x01 \x01(\v2\x13.task_service.LabelR\x05label\"\x13\n" +
+	"\x11ListLabelsRequest\"A\n" +
+	"\x12ListLabelsResponse\x12+\n" +
+	"\x06labels\x18\x01 \x03(\v2\x13.task_service.LabelR\x06labels\"8\n" +
+	"\x12RenameLabelRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"@\n" +
+	"\x13RenameLabelResponse\x12)\n" +
+	"\x05label\x18\x01 \x01(\v2\x13.task_service.LabelR\x05label\"$\n" +
+	"\x12DeleteLabelRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x15\n" +
+	"\x13DeleteLabelResponse\"C\n" +
+	"\x10AddLabelsRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x16\n" +
+	"\x06labels\x18\x02 \x03(\tR\x06labels\";\n" +
+	"\x11AddLabelsResponse\x12&\n" +
+	"\x04task\x18\x01 \x01(\v2\x12.task_service.TaskR\x04task\"F\n" +
+	"\x13RemoveLabelsRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x16\n" +
+	"\x06labels\x18\x02 \x03(\tR\x06labels\">\n" +
+	"\x14RemoveLabelsResponse\x12&\n" +
+	"\x04task\x18\x01 \x01(\v2\x12.task_service.TaskR\x04task\"\xf4\x01\n" +
+	"\aProject\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05color\x18\x03 \x01(\tR\x05color\x12\x1a\n" +
+	"\barchived\x18\x04 \x01(\bR\barchived\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\x05 \x01(\x05R\tsortOrder\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"_\n" +
+	"\x14CreateProjectRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05color\x18\x02 \x01(\tR\x05color\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\x03 \x01(\x05R\tsortOrder\"H\n" +
+	"\x15CreateProjectResponse\x12/\n" +
+	"\aproject\x18\x01 \x01(\v2\x15.task_service.ProjectR\aproject\"#\n" +
+	"\x11GetProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"E\n" +
+	"\x12GetProjectResponse\x12/\n" +
+	"\aproject\x18\x01 \x01(\v2\x15.task_service.ProjectR\aproject\"@\n" +
+	"\x13ListProjectsRequest\x12)\n" +
+	"\x10include_archived\x18\x01 \x01(\bR\x0fincludeArchived\"I\n" +
+	"\x14ListProjectsResponse\x121\n" +
+	"\bprojects\x18\x01 \x03(\v2\x15.task_service.ProjectR\bprojects\"\x84\x01\n" +
+	"\x14UpdateProjectRequest\x12/\n" +
+	"\aproject\x18\x01 \x01(\v2\x15.task_service.ProjectR\aproject\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"H\n" +
+	"\x15UpdateProjectResponse\x12/\n" +
+	"\aproject\x18\x01 \x01(\v2\x15.task_service.ProjectR\aproject\"&\n" +
+	"\x14DeleteProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x17\n" +
+	"\x15DeleteProjectResponse\"2\n" +
+	"\x13ListSubtasksRequest\x12\x1b\n" +
+	"\tparent_id\x18\x01 \x01(\tR\bparentId\"@\n" +
+	"\x14ListSubtasksResponse\x12(\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x12.task_service.TaskR\x05tasks\"\x9b\x01\n" +
+	"\x17UpdateTaskSeriesRequest\x12\x1b\n" +
+	"\tseries_id\x18\x01 \x01(\tR\bseriesId\x12&\n" +
+	"\x04task\x18\x02 \x01(\v2\x12.task_service.TaskR\x04task\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"D\n" +
+	"\x18UpdateTaskSeriesResponse\x12(\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x12.task_service.TaskR\x05tasks\":\n" +
+	"\x11WatchTasksRequest\x12%\n" +
+	"\x0eafter_sequence\x18\x01 \x01(\x04R\rafterSequence\"\x99\x02\n" +
+	"\tTaskEvent\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x04R\bsequence\x120\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x1c.task_service.TaskEvent.TypeR\x04type\x12&\n" +
+	"\x04task\x18\x03 \x01(\v2\x12.task_service.TaskR\x04task\x12.\n" +
+	"\x04time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\"f\n" +
+	"\x04Type\x12\x14\n" +
+	"\x10TYPE_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fTYPE_CREATED\x10\x01\x12\x10\n" +
+	"\fTYPE_UPDATED\x10\x02\x12\x12\n" +
+	"\x0eTYPE_COMPLETED\x10\x03\x12\x10\n" +
+	"\fTYPE_DELETED\x10\x04\"U\n" +
+	"\x13GetTaskStatsRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x1f\n" +
+	"\vleaves_only\x18\x02 \x01(\bR\n" +
+	"leavesOnly\"\xd2\x01\n" +
 	"\x14GetTaskStatsResponse\x12\x1f\n" +
 	"\vtotal_tasks\x18\x01 \x01(\x05R\n" +
 	"totalTasks\x12'\n" +
 	"\x0fcompleted_tasks\x18\x02 \x01(\x05R\x0ecompletedTasks\x12#\n" +
-	"\rpending_tasks\x18\x03 \x01(\x05R\fpendingTasks2\x91\x04\n" +
+	"\rpending_tasks\x18\x03 \x01(\x05R\fpendingTasks\x12#\n" +
+	"\roverdue_tasks\x18\x04 \x01(\x05R\foverdueTasks\x12&\n" +
+	"\x0fdue_today_tasks\x18\x05 \x01(\x05R\rdueTodayTasks\"g\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"C\n" +
+	"\x0fRegisterRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\":\n" +
+	"\x10RegisterResponse\x12&\n" +
+	"\x04user\x18\x01 \x01(\v2\x12.task_service.UserR\x04user\"@\n" +
+	"\fLoginRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"7\n" +
+	"\rLoginResponse\x12&\n" +
+	"\x04user\x18\x01 \x01(\v2\x12.task_service.UserR\x04user\"\xfa\x01\n" +
+	"\bApiToken\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06scopes\x18\x03 \x03(\tR\x06scopes\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12<\n" +
+	"\flast_used_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\"~\n" +
+	"\x15CreateApiTokenRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06scopes\x18\x02 \x03(\tR\x06scopes\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"c\n" +
+	"\x16CreateApiTokenResponse\x123\n" +
+	"\tapi_token\x18\x01 \x01(\v2\x16.task_service.ApiTokenR\bapiToken\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"\x16\n" +
+	"\x14ListApiTokensRequest\"N\n" +
+	"\x15ListApiTokensResponse\x125\n" +
+	"\n" +
+	"api_tokens\x18\x01 \x03(\v2\x16.task_service.ApiTokenR\tapiTokens\"'\n" +
+	"\x15RevokeApiTokenRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x18\n" +
+	"\x16RevokeApiTokenResponse\"-\n" +
+	"\x15VerifyApiTokenRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"I\n" +
+	"\x16VerifyApiTokenResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06scopes\x18\x02 \x03(\tR\x06scopes*l\n" +
+	"\bPriority\x12\x11\n" +
+	"\rPRIORITY_NONE\x10\x00\x12\x10\n" +
+	"\fPRIORITY_LOW\x10\x01\x12\x13\n" +
+	"\x0fPRIORITY_MEDIUM\x10\x02\x12\x11\n" +
+	"\rPRIORITY_HIGH\x10\x03\x12\x13\n" +
+	"\x0fPRIORITY_URGENT\x10\x042\xf2\x15\n" +
 	"\vTaskService\x12O\n" +
 	"\n" +
 	"CreateTask\x12\x1f.task_service.CreateTaskRequest\x1a .task_service.CreateTaskResponse\x12F\n" +
 	"\aGetTask\x12\x1c.task_service.GetTaskRequest\x1a\x1d.task_service.GetTaskResponse\x12L\n" +
-	"\tListTasks\x12\x1e.task_service.ListTasksRequest\x1a\x1f.task_service.ListTasksResponse\x12U\n" +
-	"\fCompleteTask\x12!.task_service.CompleteTaskRequest\x1a\".task_service.CompleteTaskResponse\x12m\n" +
-	"\x14ToggleTaskCompletion\x12).task_service.ToggleTaskCompletionRequest\x1a*.task_service.ToggleTaskCompletionResponse\x12U\n" +
-	"\fGetTaskStats\x12!.task_service.GetTaskStatsRequest\x1a\".task_service.GetTaskStatsResponseB0Z.github.com/sahidhossen/todo/proto/task_serviceb\x06proto3"
+	"\tListTasks\x12\x1e.task_service.ListTasksRequest\x1a\x1f.task_service.ListTasksResponse\x12R\n" +
+	"\vSearchTasks\x12 .task_service.SearchTasksRequest\x1a!.task_service.SearchTasksResponse\x12U\n" +
+	"\fCompleteTask\x12!.task_service.CompleteTaskRequest\x1a\".task_service.CompleteTaskResponse\x12O\n" +
+	"\n" +
+	"ReopenTask\x12\x1f.task_service.ReopenTaskRequest\x1a .task_service.ReopenTaskResponse\x12m\n" +
+	"\x14ToggleTaskCompletion\x12).task_service.ToggleTaskCompletionRequest\x1a*.task_service.ToggleTaskCompletionResponse\x12O\n" +
+	"\n" +
+	"UpdateTask\x12\x1f.task_service.UpdateTaskRequest\x1a .task_service.UpdateTaskResponse\x12O\n" +
+	"\n" +
+	"DeleteTask\x12\x1f.task_service.DeleteTaskRequest\x1a .task_service.DeleteTaskResponse\x12R\n" +
+	"\vRestoreTask\x12 .task_service.RestoreTaskRequest\x1a!.task_service.RestoreTaskResponse\x12L\n" +
+	"\tPurgeTask\x12\x1e.task_service.PurgeTaskRequest\x1a\x1f.task_service.PurgeTaskResponse\x12L\n" +
+	"\tListTrash\x12\x1e.task_service.ListTrashRequest\x1a\x1f.task_service.ListTrashResponse\x12U\n" +
+	"\fGetTaskStats\x12!.task_service.GetTaskStatsRequest\x1a\".task_service.GetTaskStatsResponse\x12R\n" +
+	"\vCreateLabel\x12 .task_service.CreateLabelRequest\x1a!.task_service.CreateLabelResponse\x12O\n" +
+	"\n" +
+	"ListLabels\x12\x1f.task_service.ListLabelsRequest\x1a .task_service.ListLabelsResponse\x12R\n" +
+	"\vRenameLabel\x12 .task_service.RenameLabelRequest\x1a!.task_service.RenameLabelResponse\x12R\n" +
+	"\vDeleteLabel\x12 .task_service.DeleteLabelRequest\x1a!.task_service.DeleteLabelResponse\x12L\n" +
+	"\tAddLabels\x12\x1e.task_service.AddLabelsRequest\x1a\x1f.task_service.AddLabelsResponse\x12U\n" +
+	"\fRemoveLabels\x12!.task_service.RemoveLabelsRequest\x1a\".task_service.RemoveLabelsResponse\x12X\n" +
+	"\rCreateProject\x12\".task_service.CreateProjectRequest\x1a#.task_service.CreateProjectResponse\x12O\n" +
+	"\n" +
+	"GetProject\x12\x1f.task_service.GetProjectRequest\x1a .task_service.GetProjectResponse\x12U\n" +
+	"\fListProjects\x12!.task_service.ListProjectsRequest\x1a\".task_service.ListProjectsResponse\x12X\n" +
+	"\rUpdateProject\x12\".task_service.UpdateProjectRequest\x1a#.task_service.UpdateProjectResponse\x12X\n" +
+	"\rDeleteProject\x12\".task_service.DeleteProjectRequest\x1a#.task_service.DeleteProjectResponse\x12U\n" +
+	"\fListSubtasks\x12!.task_service.ListSubtasksRequest\x1a\".task_service.ListSubtasksResponse\x12a\n" +
+	"\x10UpdateTaskSeries\x12%.task_service.UpdateTaskSeriesRequest\x1a&.task_service.UpdateTaskSeriesResponse\x12H\n" +
+	"\n" +
+	"WatchTasks\x12\x1f.task_service.WatchTasksRequest\x1a\x17.task_service.TaskEvent0\x01\x12I\n" +
+	"\bRegister\x12\x1d.task_service.RegisterRequest\x1a\x1e.task_service.RegisterResponse\x12@\n" +
+	"\x05Login\x12\x1a.task_service.LoginRequest\x1a\x1b.task_service.LoginResponse\x12[\n" +
+	"\x0eCreateApiToken\x12#.task_service.CreateApiTokenRequest\x1a$.task_service.CreateApiTokenResponse\x12X\n" +
+	"\rListApiTokens\x12\".task_service.ListApiTokensRequest\x1a#.task_service.ListApiTokensResponse\x12[\n" +
+	"\x0eRevokeApiToken\x12#.task_service.RevokeApiTokenRequest\x1a$.task_service.RevokeApiTokenResponse\x12[\n" +
+	"\x0eVerifyApiToken\x12#.task_service.VerifyApiTokenRequest\x1a$.task_service.VerifyApiTokenResponseB0Z.github.com/sahidhossen/todo/proto/task_serviceb\x06proto3"
 
 var (
 	file_proto_task_service_proto_rawDescOnce sync.Once
@@ -710,48 +4428,218 @@ func file_proto_task_service_proto_rawDescGZIP() []byte {
 	return file_proto_task_service_proto_rawDescData
 }
 
-var file_proto_task_service_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_proto_task_service_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_task_service_proto_msgTypes = make([]protoimpl.MessageInfo, 72)
 var file_proto_task_service_proto_goTypes = []any{
-	(*Task)(nil),                         // 0: task_service.Task
-	(*CreateTaskRequest)(nil),            // 1: task_service.CreateTaskRequest
-	(*CreateTaskResponse)(nil),           // 2: task_service.CreateTaskResponse
-	(*GetTaskRequest)(nil),               // 3: task_service.GetTaskRequest
-	(*GetTaskResponse)(nil),              // 4: task_service.GetTaskResponse
-	(*ListTasksRequest)(nil),             // 5: task_service.ListTasksRequest
-	(*ListTasksResponse)(nil),            // 6: task_service.ListTasksResponse
-	(*CompleteTaskRequest)(nil),          // 7: task_service.CompleteTaskRequest
-	(*CompleteTaskResponse)(nil),         // 8: task_service.CompleteTaskResponse
-	(*ToggleTaskCompletionRequest)(nil),  // 9: task_service.ToggleTaskCompletionRequest
-	(*ToggleTaskCompletionResponse)(nil), // 10: task_service.ToggleTaskCompletionResponse
-	(*GetTaskStatsRequest)(nil),          // 11: task_service.GetTaskStatsRequest
-	(*GetTaskStatsResponse)(nil),         // 12: task_service.GetTaskStatsResponse
-	(*timestamppb.Timestamp)(nil),        // 13: google.protobuf.Timestamp
+	(Priority)(0),                        // 0: task_service.Priority
+	(TaskEvent_Type)(0),                  // 1: task_service.TaskEvent.Type
+	(*Task)(nil),                         // 2: task_service.Task
+	(*Label)(nil),                        // 3: task_service.Label
+	(*CreateTaskRequest)(nil),            // 4: task_service.CreateTaskRequest
+	(*CreateTaskResponse)(nil),           // 5: task_service.CreateTaskResponse
+	(*GetTaskRequest)(nil),               // 6: task_service.GetTaskRequest
+	(*GetTaskResponse)(nil),              // 7: task_service.GetTaskResponse
+	(*ListTasksRequest)(nil),             // 8: task_service.ListTasksRequest
+	(*ListTasksResponse)(nil),            // 9: task_service.ListTasksResponse
+	(*SearchTasksRequest)(nil),           // 10: task_service.SearchTasksRequest
+	(*SearchResult)(nil),                 // 11: task_service.SearchResult
+	(*SearchTasksResponse)(nil),          // 12: task_service.SearchTasksResponse
+	(*CompleteTaskRequest)(nil),          // 13: task_service.CompleteTaskRequest
+	(*CompleteTaskResponse)(nil),         // 14: task_service.CompleteTaskResponse
+	(*ReopenTaskRequest)(nil),            // 15: task_service.ReopenTaskRequest
+	(*ReopenTaskResponse)(nil),           // 16: task_service.ReopenTaskResponse
+	(*ToggleTaskCompletionRequest)(nil),  // 17: task_service.ToggleTaskCompletionRequest
+	(*ToggleTaskCompletionResponse)(nil), // 18: task_service.ToggleTaskCompletionResponse
+	(*UpdateTaskRequest)(nil),            // 19: task_service.UpdateTaskRequest
+	(*UpdateTaskResponse)(nil),           // 20: task_service.UpdateTaskResponse
+	(*DeleteTaskRequest)(nil),            // 21: task_service.DeleteTaskRequest
+	(*DeleteTaskResponse)(nil),           // 22: task_service.DeleteTaskResponse
+	(*RestoreTaskRequest)(nil),           // 23: task_service.RestoreTaskRequest
+	(*RestoreTaskResponse)(nil),          // 24: task_service.RestoreTaskResponse
+	(*PurgeTaskRequest)(nil),             // 25: task_service.PurgeTaskRequest
+	(*PurgeTaskResponse)(nil),            // 26: task_service.PurgeTaskResponse
+	(*ListTrashRequest)(nil),             // 27: task_service.ListTrashRequest
+	(*ListTrashResponse)(nil),            // 28: task_service.ListTrashResponse
+	(*CreateLabelRequest)(nil),           // 29: task_service.CreateLabelRequest
+	(*CreateLabelResponse)(nil),          // 30: task_service.CreateLabelResponse
+	(*ListLabelsRequest)(nil),            // 31: task_service.ListLabelsRequest
+	(*ListLabelsResponse)(nil),           // 32: task_service.ListLabelsResponse
+	(*RenameLabelRequest)(nil),           // 33: task_service.RenameLabelRequest
+	(*RenameLabelResponse)(nil),          // 34: task_service.RenameLabelResponse
+	(*DeleteLabelRequest)(nil),           // 35: task_service.DeleteLabelRequest
+	(*DeleteLabelResponse)(nil),          // 36: task_service.DeleteLabelResponse
+	(*AddLabelsRequest)(nil),             // 37: task_service.AddLabelsRequest
+	(*AddLabelsResponse)(nil),            // 38: task_service.AddLabelsResponse
+	(*RemoveLabelsRequest)(nil),          // 39: task_service.RemoveLabelsRequest
+	(*RemoveLabelsResponse)(nil),         // 40: task_service.RemoveLabelsResponse
+	(*Project)(nil),                      // 41: task_service.Project
+	(*CreateProjectRequest)(nil),         // 42: task_service.CreateProjectRequest
+	(*CreateProjectResponse)(nil),        // 43: task_service.CreateProjectResponse
+	(*GetProjectRequest)(nil),            // 44: task_service.GetProjectRequest
+	(*GetProjectResponse)(nil),           // 45: task_service.GetProjectResponse
+	(*ListProjectsRequest)(nil),          // 46: task_service.ListProjectsRequest
+	(*ListProjectsResponse)(nil),         // 47: task_service.ListProjectsResponse
+	(*UpdateProjectRequest)(nil),         // 48: task_service.UpdateProjectRequest
+	(*UpdateProjectResponse)(nil),        // 49: task_service.UpdateProjectResponse
+	(*DeleteProjectRequest)(nil),         // 50: task_service.DeleteProjectRequest
+	(*DeleteProjectResponse)(nil),        // 51: task_service.DeleteProjectResponse
+	(*ListSubtasksRequest)(nil),          // 52: task_service.ListSubtasksRequest
+	(*ListSubtasksResponse)(nil),         // 53: task_service.ListSubtasksResponse
+	(*UpdateTaskSeriesRequest)(nil),      // 54: task_service.UpdateTaskSeriesRequest
+	(*UpdateTaskSeriesResponse)(nil),     // 55: task_service.UpdateTaskSeriesResponse
+	(*WatchTasksRequest)(nil),            // 56: task_service.WatchTasksRequest
+	(*TaskEvent)(nil),                    // 57: task_service.TaskEvent
+	(*GetTaskStatsRequest)(nil),          // 58: task_service.GetTaskStatsRequest
+	(*GetTaskStatsResponse)(nil),         // 59: task_service.GetTaskStatsResponse
+	(*User)(nil),                         // 60: task_service.User
+	(*RegisterRequest)(nil),              // 61: task_service.RegisterRequest
+	(*RegisterResponse)(nil),             // 62: task_service.RegisterResponse
+	(*LoginRequest)(nil),                 // 63: task_service.LoginRequest
+	(*LoginResponse)(nil),                // 64: task_service.LoginResponse
+	(*ApiToken)(nil),                     // 65: task_service.ApiToken
+	(*CreateApiTokenRequest)(nil),        // 66: task_service.CreateApiTokenRequest
+	(*CreateApiTokenResponse)(nil),       // 67: task_service.CreateApiTokenResponse
+	(*ListApiTokensRequest)(nil),         // 68: task_service.ListApiTokensRequest
+	(*ListApiTokensResponse)(nil),        // 69: task_service.ListApiTokensResponse
+	(*RevokeApiTokenRequest)(nil),        // 70: task_service.RevokeApiTokenRequest
+	(*RevokeApiTokenResponse)(nil),       // 71: task_service.RevokeApiTokenResponse
+	(*VerifyApiTokenRequest)(nil),        // 72: task_service.VerifyApiTokenRequest
+	(*VerifyApiTokenResponse)(nil),       // 73: task_service.VerifyApiTokenResponse
+	(*timestamppb.Timestamp)(nil),        // 74: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),        // 75: google.protobuf.FieldMask
 }
 var file_proto_task_service_proto_depIdxs = []int32{
-	13, // 0: task_service.Task.created_at:type_name -> google.protobuf.Timestamp
-	13, // 1: task_service.Task.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 2: task_service.CreateTaskResponse.task:type_name -> task_service.Task
-	0,  // 3: task_service.GetTaskResponse.task:type_name -> task_service.Task
-	0,  // 4: task_service.ListTasksResponse.tasks:type_name -> task_service.Task
-	0,  // 5: task_service.CompleteTaskResponse.task:type_name -> task_service.Task
-	0,  // 6: task_service.ToggleTaskCompletionResponse.task:type_name -> task_service.Task
-	1,  // 7: task_service.TaskService.CreateTask:input_type -> task_service.CreateTaskRequest
-	3,  // 8: task_service.TaskService.GetTask:input_type -> task_service.GetTaskRequest
-	5,  // 9: task_service.TaskService.ListTasks:input_type -> task_service.ListTasksRequest
-	7,  // 10: task_service.TaskService.CompleteTask:input_type -> task_service.CompleteTaskRequest
-	9,  // 11: task_service.TaskService.ToggleTaskCompletion:input_type -> task_service.ToggleTaskCompletionRequest
-	11, // 12: task_service.TaskService.GetTaskStats:input_type -> task_service.GetTaskStatsRequest
-	2,  // 13: task_service.TaskService.CreateTask:output_type -> task_service.CreateTaskResponse
-	4,  // 14: task_service.TaskService.GetTask:output_type -> task_service.GetTaskResponse
-	6,  // 15: task_service.TaskService.ListTasks:output_type -> task_service.ListTasksResponse
-	8,  // 16: task_service.TaskService.CompleteTask:output_type -> task_service.CompleteTaskResponse
-	10, // 17: task_service.TaskService.ToggleTaskCompletion:output_type -> task_service.ToggleTaskCompletionResponse
-	12, // 18: task_service.TaskService.GetTaskStats:output_type -> task_service.GetTaskStatsResponse
-	13, // [13:19] is the sub-list for method output_type
-	7,  // [7:13] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	74, // 0: task_service.Task.created_at:type_name -> google.protobuf.Timestamp
+	74, // 1: task_service.Task.updated_at:type_name -> google.protobuf.Timestamp
+	74, // 2: task_service.Task.deleted_at:type_name -> google.protobuf.Timestamp
+	74, // 3: task_service.Task.due_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: task_service.Task.priority:type_name -> task_service.Priority
+	2,  // 5: task_service.Task.children:type_name -> task_service.Task
+	74, // 6: task_service.Label.created_at:type_name -> google.protobuf.Timestamp
+	74, // 7: task_service.CreateTaskRequest.due_at:type_name -> google.protobuf.Timestamp
+	0,  // 8: task_service.CreateTaskRequest.priority:type_name -> task_service.Priority
+	2,  // 9: task_service.CreateTaskResponse.task:type_name -> task_service.Task
+	2,  // 10: task_service.GetTaskResponse.task:type_name -> task_service.Task
+	74, // 11: task_service.ListTasksRequest.created_after:type_name -> google.protobuf.Timestamp
+	74, // 12: task_service.ListTasksRequest.created_before:type_name -> google.protobuf.Timestamp
+	74, // 13: task_service.ListTasksRequest.updated_after:type_name -> google.protobuf.Timestamp
+	74, // 14: task_service.ListTasksRequest.updated_before:type_name -> google.protobuf.Timestamp
+	74, // 15: task_service.ListTasksRequest.due_after:type_name -> google.protobuf.Timestamp
+	74, // 16: task_service.ListTasksRequest.due_before:type_name -> google.protobuf.Timestamp
+	2,  // 17: task_service.ListTasksResponse.tasks:type_name -> task_service.Task
+	2,  // 18: task_service.SearchResult.task:type_name -> task_service.Task
+	11, // 19: task_service.SearchTasksResponse.results:type_name -> task_service.SearchResult
+	2,  // 20: task_service.CompleteTaskResponse.task:type_name -> task_service.Task
+	2,  // 21: task_service.CompleteTaskResponse.next_occurrence:type_name -> task_service.Task
+	2,  // 22: task_service.ReopenTaskResponse.task:type_name -> task_service.Task
+	2,  // 23: task_service.ToggleTaskCompletionResponse.task:type_name -> task_service.Task
+	2,  // 24: task_service.ToggleTaskCompletionResponse.next_occurrence:type_name -> task_service.Task
+	2,  // 25: task_service.UpdateTaskRequest.task:type_name -> task_service.Task
+	75, // 26: task_service.UpdateTaskRequest.update_mask:type_name -> google.protobuf.FieldMask
+	2,  // 27: task_service.UpdateTaskResponse.task:type_name -> task_service.Task
+	2,  // 28: task_service.DeleteTaskResponse.task:type_name -> task_service.Task
+	2,  // 29: task_service.RestoreTaskResponse.task:type_name -> task_service.Task
+	2,  // 30: task_service.ListTrashResponse.tasks:type_name -> task_service.Task
+	3,  // 31: task_service.CreateLabelResponse.label:type_name -> task_service.Label
+	3,  // 32: task_service.ListLabelsResponse.labels:type_name -> task_service.Label
+	3,  // 33: task_service.RenameLabelResponse.label:type_name -> task_service.Label
+	2,  // 34: task_service.AddLabelsResponse.task:type_name -> task_service.Task
+	2,  // 35: task_service.RemoveLabelsResponse.task:type_name -> task_service.Task
+	74, // 36: task_service.Project.created_at:type_name -> google.protobuf.Timestamp
+	74, // 37: task_service.Project.updated_at:type_name -> google.protobuf.Timestamp
+	41, // 38: task_service.CreateProjectResponse.project:type_name -> task_service.Project
+	41, // 39: task_service.GetProjectResponse.project:type_name -> task_service.Project
+	41, // 40: task_service.ListProjectsResponse.projects:type_name -> task_service.Project
+	41, // 41: task_service.UpdateProjectRequest.project:type_name -> task_service.Project
+	75, // 42: task_service.UpdateProjectRequest.update_mask:type_name -> google.protobuf.FieldMask
+	41, // 43: task_service.UpdateProjectResponse.project:type_name -> task_service.Project
+	2,  // 44: task_service.ListSubtasksResponse.tasks:type_name -> task_service.Task
+	2,  // 45: task_service.UpdateTaskSeriesRequest.task:type_name -> task_service.Task
+	75, // 46: task_service.UpdateTaskSeriesRequest.update_mask:type_name -> google.protobuf.FieldMask
+	2,  // 47: task_service.UpdateTaskSeriesResponse.tasks:type_name -> task_service.Task
+	1,  // 48: task_service.TaskEvent.type:type_name -> task_service.TaskEvent.Type
+	2,  // 49: task_service.TaskEvent.task:type_name -> task_service.Task
+	74, // 50: task_service.TaskEvent.time:type_name -> google.protobuf.Timestamp
+	74, // 51: task_service.User.created_at:type_name -> google.protobuf.Timestamp
+	60, // 52: task_service.RegisterResponse.user:type_name -> task_service.User
+	60, // 53: task_service.LoginResponse.user:type_name -> task_service.User
+	74, // 54: task_service.ApiToken.created_at:type_name -> google.protobuf.Timestamp
+	74, // 55: task_service.ApiToken.expires_at:type_name -> google.protobuf.Timestamp
+	74, // 56: task_service.ApiToken.last_used_at:type_name -> google.protobuf.Timestamp
+	74, // 57: task_service.CreateApiTokenRequest.expires_at:type_name -> google.protobuf.Timestamp
+	65, // 58: task_service.CreateApiTokenResponse.api_token:type_name -> task_service.ApiToken
+	65, // 59: task_service.ListApiTokensResponse.api_tokens:type_name -> task_service.ApiToken
+	4,  // 60: task_service.TaskService.CreateTask:input_type -> task_service.CreateTaskRequest
+	6,  // 61: task_service.TaskService.GetTask:input_type -> task_service.GetTaskRequest
+	8,  // 62: task_service.TaskService.ListTasks:input_type -> task_service.ListTasksRequest
+	10, // 63: task_service.TaskService.SearchTasks:input_type -> task_service.SearchTasksRequest
+	13, // 64: task_service.TaskService.CompleteTask:input_type -> task_service.CompleteTaskRequest
+	15, // 65: task_service.TaskService.ReopenTask:input_type -> task_service.ReopenTaskRequest
+	17, // 66: task_service.TaskService.ToggleTaskCompletion:input_type -> task_service.ToggleTaskCompletionRequest
+	19, // 67: task_service.TaskService.UpdateTask:input_type -> task_service.UpdateTaskRequest
+	21, // 68: task_service.TaskService.DeleteTask:input_type -> task_service.DeleteTaskRequest
+	23, // 69: task_service.TaskService.RestoreTask:input_type -> task_service.RestoreTaskRequest
+	25, // 70: task_service.TaskService.PurgeTask:input_type -> task_service.PurgeTaskRequest
+	27, // 71: task_service.TaskService.ListTrash:input_type -> task_service.ListTrashRequest
+	58, // 72: task_service.TaskService.GetTaskStats:input_type -> task_service.GetTaskStatsRequest
+	29, // 73: task_service.TaskService.CreateLabel:input_type -> task_service.CreateLabelRequest
+	31, // 74: task_service.TaskService.ListLabels:input_type -> task_service.ListLabelsRequest
+	33, // 75: task_service.TaskService.RenameLabel:input_type -> task_service.RenameLabelRequest
+	35, // 76: task_service.TaskService.DeleteLabel:input_type -> task_service.DeleteLabelRequest
+	37, // 77: task_service.TaskService.AddLabels:input_type -> task_service.AddLabelsRequest
+	39, // 78: task_service.TaskService.RemoveLabels:input_type -> task_service.RemoveLabelsRequest
+	42, // 79: task_service.TaskService.CreateProject:input_type -> task_service.CreateProjectRequest
+	44, // 80: task_service.TaskService.GetProject:input_type -> task_service.GetProjectRequest
+	46, // 81: task_service.TaskService.ListProjects:input_type -> task_service.ListProjectsRequest
+	48, // 82: task_service.TaskService.UpdateProject:input_type -> task_service.UpdateProjectRequest
+	50, // 83: task_service.TaskService.DeleteProject:input_type -> task_service.DeleteProjectRequest
+	52, // 84: task_service.TaskService.ListSubtasks:input_type -> task_service.ListSubtasksRequest
+	54, // 85: task_service.TaskService.UpdateTaskSeries:input_type -> task_service.UpdateTaskSeriesRequest
+	56, // 86: task_service.TaskService.WatchTasks:input_type -> task_service.WatchTasksRequest
+	61, // 87: task_service.TaskService.Register:input_type -> task_service.RegisterRequest
+	63, // 88: task_service.TaskService.Login:input_type -> task_service.LoginRequest
+	66, // 89: task_service.TaskService.CreateApiToken:input_type -> task_service.CreateApiTokenRequest
+	68, // 90: task_service.TaskService.ListApiTokens:input_type -> task_service.ListApiTokensRequest
+	70, // 91: task_service.TaskService.RevokeApiToken:input_type -> task_service.RevokeApiTokenRequest
+	72, // 92: task_service.TaskService.VerifyApiToken:input_type -> task_service.VerifyApiTokenRequest
+	5,  // 93: task_service.TaskService.CreateTask:output_type -> task_service.CreateTaskResponse
+	7,  // 94: task_service.TaskService.GetTask:output_type -> task_service.GetTaskResponse
+	9,  // 95: task_service.TaskService.ListTasks:output_type -> task_service.ListTasksResponse
+	12, // 96: task_service.TaskService.SearchTasks:output_type -> task_service.SearchTasksResponse
+	14, // 97: task_service.TaskService.CompleteTask:output_type -> task_service.CompleteTaskResponse
+	16, // 98: task_service.TaskService.ReopenTask:output_type -> task_service.ReopenTaskResponse
+	18, // 99: task_service.TaskService.ToggleTaskCompletion:output_type -> task_service.ToggleTaskCompletionResponse
+	20, // 100: task_service.TaskService.UpdateTask:output_type -> task_service.UpdateTaskResponse
+	22, // 101: task_service.TaskService.DeleteTask:output_type -> task_service.DeleteTaskResponse
+	24, // 102: task_service.TaskService.RestoreTask:output_type -> task_service.RestoreTaskResponse
+	26, // 103: task_service.TaskService.PurgeTask:output_type -> task_service.PurgeTaskResponse
+	28, // 104: task_service.TaskService.ListTrash:output_type -> task_service.ListTrashResponse
+	59, // 105: task_service.TaskService.GetTaskStats:output_type -> task_service.GetTaskStatsResponse
+	30, // 106: task_service.TaskService.CreateLabel:output_type -> task_service.CreateLabelResponse
+	32, // 107: task_service.TaskService.ListLabels:output_type -> task_service.ListLabelsResponse
+	34, // 108: task_service.TaskService.RenameLabel:output_type -> task_service.RenameLabelResponse
+	36, // 109: task_service.TaskService.DeleteLabel:output_type -> task_service.DeleteLabelResponse
+	38, // 110: task_service.TaskService.AddLabels:output_type -> task_service.AddLabelsResponse
+	40, // 111: task_service.TaskService.RemoveLabels:output_type -> task_service.RemoveLabelsResponse
+	43, // 112: task_service.TaskService.CreateProject:output_type -> task_service.CreateProjectResponse
+	45, // 113: task_service.TaskService.GetProject:output_type -> task_service.GetProjectResponse
+	47, // 114: task_service.TaskService.ListProjects:output_type -> task_service.ListProjectsResponse
+	49, // 115: task_service.TaskService.UpdateProject:output_type -> task_service.UpdateProjectResponse
+	51, // 116: task_service.TaskService.DeleteProject:output_type -> task_service.DeleteProjectResponse
+	53, // 117: task_service.TaskService.ListSubtasks:output_type -> task_service.ListSubtasksResponse
+	55, // 118: task_service.TaskService.UpdateTaskSeries:output_type -> task_service.UpdateTaskSeriesResponse
+	57, // 119: task_service.TaskService.WatchTasks:output_type -> task_service.TaskEvent
+	62, // 120: task_service.TaskService.Register:output_type -> task_service.RegisterResponse
+	64, // 121: task_service.TaskService.Login:output_type -> task_service.LoginResponse
+	67, // 122: task_service.TaskService.CreateApiToken:output_type -> task_service.CreateApiTokenResponse
+	69, // 123: task_service.TaskService.ListApiTokens:output_type -> task_service.ListApiTokensResponse
+	71, // 124: task_service.TaskService.RevokeApiToken:output_type -> task_service.RevokeApiTokenResponse
+	73, // 125: task_service.TaskService.VerifyApiToken:output_type -> task_service.VerifyApiTokenResponse
+	93, // [93:126] is the sub-list for method output_type
+	60, // [60:93] is the sub-list for method input_type
+	60, // [60:60] is the sub-list for extension type_name
+	60, // [60:60] is the sub-list for extension extendee
+	0,  // [0:60] is the sub-list for field type_name
 }
 
 func init() { file_proto_task_service_proto_init() }
@@ -759,18 +4647,20 @@ func file_proto_task_service_proto_init() {
 	if File_proto_task_service_proto != nil {
 		return
 	}
+	file_proto_task_service_proto_msgTypes[6].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_task_service_proto_rawDesc), len(file_proto_task_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   13,
+			NumEnums:      2,
+			NumMessages:   72,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_task_service_proto_goTypes,
 		DependencyIndexes: file_proto_task_service_proto_depIdxs,
+		EnumInfos:         file_proto_task_service_proto_enumTypes,
 		MessageInfos:      file_proto_task_service_proto_msgTypes,
 	}.Build()
 	File_proto_task_service_proto = out.File
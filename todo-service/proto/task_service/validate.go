@@ -0,0 +1,206 @@
+package task_service
+
+import (
+	"errors"
+	"strings"
+)
+
+// Validate methods check the shape of a request: required fields and ranges
+// that need no storage lookup. The storage service's handlers call them and
+// refuse failing requests with InvalidArgument, as does its validation
+// interceptor before the handler runs. Their errors are FieldErrors naming
+// the invalid field.
+
+// FieldError is a validation error of the request field at Field, its path
+// in the request message such as "task.id".
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+func invalid(field string, err error) error {
+	return &FieldError{Field: field, Err: err}
+}
+
+var (
+	errEmptyTaskID      = errors.New("task ID cannot be empty")
+	errEmptyTitle       = errors.New("title cannot be empty")
+	errNegativePageSize = errors.New("page_size cannot be negative")
+	errEmptyUpdateMask  = errors.New("update_mask must name at least one field")
+	errEmptyLabelID     = errors.New("label ID cannot be empty")
+	errEmptyLabels      = errors.New("labels cannot be empty")
+	errEmptyProjectID   = errors.New("project ID cannot be empty")
+)
+
+func (r *CreateTaskRequest) Validate() error {
+	if r.GetTitle() == "" {
+		return invalid("title", errEmptyTitle)
+	}
+	return nil
+}
+
+func (r *GetTaskRequest) Validate() error {
+	if r.GetId() == "" {
+		return invalid("id", errEmptyTaskID)
+	}
+	return nil
+}
+
+func (r *ListTasksRequest) Validate() error {
+	if r.GetPageSize() < 0 {
+		return invalid("page_size", errNegativePageSize)
+	}
+	return nil
+}
+
+func (r *SearchTasksRequest) Validate() error {
+	if strings.TrimSpace(r.GetQuery()) == "" {
+		return invalid("query", errors.New("search query cannot be empty"))
+	}
+	if r.GetPageSize() < 0 {
+		return invalid("page_size", errNegativePageSize)
+	}
+	return nil
+}
+
+func (r *CompleteTaskRequest) Validate() error {
+	if r.GetId() == "" {
+		return invalid("id", errEmptyTaskID)
+	}
+	return nil
+}
+
+func (r *ReopenTaskRequest) Validate() error {
+	if r.GetId() == "" {
+		return invalid("id", errEmptyTaskID)
+	}
+	return nil
+}
+
+func (r *ToggleTaskCompletionRequest) Validate() error {
+	if r.GetId() == "" {
+		return invalid("id", errEmptyTaskID)
+	}
+	return nil
+}
+
+func (r *UpdateTaskRequest) Validate() error {
+	if r.GetTask().GetId() == "" {
+		return invalid("task.id", errEmptyTaskID)
+	}
+	if len(r.GetUpdateMask().GetPaths()) == 0 {
+		return invalid("update_mask", errEmptyUpdateMask)
+	}
+	return nil
+}
+
+func (r *DeleteTaskRequest) Validate() error {
+	if r.GetId() == "" {
+		return invalid("id", errEmptyTaskID)
+	}
+	return nil
+}
+
+func (r *RestoreTaskRequest) Validate() error {
+	if r.GetId() == "" {
+		return invalid("id", errEmptyTaskID)
+	}
+	return nil
+}
+
+func (r *PurgeTaskRequest) Validate() error {
+	if r.GetId() == "" {
+		return invalid("id", errEmptyTaskID)
+	}
+	return nil
+}
+
+func (r *RenameLabelRequest) Validate() error {
+	if r.GetId() == "" {
+		return invalid("id", errEmptyLabelID)
+	}
+	return nil
+}
+
+func (r *DeleteLabelRequest) Validate() error {
+	if r.GetId() == "" {
+		return invalid("id", errEmptyLabelID)
+	}
+	return nil
+}
+
+func (r *AddLabelsRequest) Validate() error {
+	if r.GetTaskId() == "" {
+		return invalid("task_id", errEmptyTaskID)
+	}
+	if len(r.GetLabels()) == 0 {
+		return invalid("labels", errEmptyLabels)
+	}
+	return nil
+}
+
+func (r *RemoveLabelsRequest) Validate() error {
+	if r.GetTaskId() == "" {
+		return invalid("task_id", errEmptyTaskID)
+	}
+	if len(r.GetLabels()) == 0 {
+		return invalid("labels", errEmptyLabels)
+	}
+	return nil
+}
+
+func (r *GetProjectRequest) Validate() error {
+	if r.GetId() == "" {
+		return invalid("id", errEmptyProjectID)
+	}
+	return nil
+}
+
+func (r *UpdateProjectRequest) Validate() error {
+	if r.GetProject().GetId() == "" {
+		return invalid("project.id", errEmptyProjectID)
+	}
+	if len(r.GetUpdateMask().GetPaths()) == 0 {
+		return invalid("update_mask", errEmptyUpdateMask)
+	}
+	return nil
+}
+
+func (r *DeleteProjectRequest) Validate() error {
+	if r.GetId() == "" {
+		return invalid("id", errEmptyProjectID)
+	}
+	return nil
+}
+
+func (r *ListSubtasksRequest) Validate() error {
+	if r.GetParentId() == "" {
+		return invalid("parent_id", errors.New("parent task ID cannot be empty"))
+	}
+	return nil
+}
+
+func (r *UpdateTaskSeriesRequest) Validate() error {
+	if r.GetSeriesId() == "" {
+		return invalid("series_id", errors.New("series ID cannot be empty"))
+	}
+	if len(r.GetUpdateMask().GetPaths()) == 0 {
+		return invalid("update_mask", errEmptyUpdateMask)
+	}
+	return nil
+}
+
+func (r *RevokeApiTokenRequest) Validate() error {
+	if r.GetId() == "" {
+		return invalid("id", errors.New("API token ID cannot be empty"))
+	}
+	return nil
+}
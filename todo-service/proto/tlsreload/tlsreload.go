@@ -0,0 +1,147 @@
+// Package tlsreload provides gRPC transport credentials that reload their
+// certificate files when they change on disk, so renewed certificates are
+// picked up without a restart. The api-gateway and storage-service build
+// their client and server TLS settings on it.
+package tlsreload
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// Credentials are transport credentials that build the tls.Config of every
+// handshake from files, loading them again when they change.
+type Credentials struct {
+	files  []string
+	load   func() (*tls.Config, error)
+	logger *slog.Logger
+
+	mu       sync.Mutex
+	modTimes []time.Time
+	config   *tls.Config
+}
+
+// New returns credentials built by load from files. The files are loaded
+// once here, so configuration errors show at startup.
+func New(files []string, load func() (*tls.Config, error), logger *slog.Logger) (*Credentials, error) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	c := &Credentials{files: files, load: load, logger: logger}
+	if _, err := c.Current(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Current returns the tls.Config for the files as they are now. When the
+// changed files fail to load, for example while only the certificate of a
+// new key pair has been written, the previous config is kept.
+func (c *Credentials) Current() (*tls.Config, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	modTimes, err := fileModTimes(c.files)
+	if err == nil && c.config != nil && sameTimes(modTimes, c.modTimes) {
+		return c.config, nil
+	}
+	var config *tls.Config
+	if err == nil {
+		config, err = c.load()
+	}
+	if err != nil {
+		if c.config == nil {
+			return nil, err
+		}
+		c.logger.Warn("Failed to reload TLS files, keeping the loaded ones", "error", err)
+		return c.config, nil
+	}
+
+	if c.config != nil {
+		c.logger.Info("TLS files reloaded", "files", c.files)
+	}
+	c.config, c.modTimes = config, modTimes
+	return config, nil
+}
+
+func (c *Credentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	config, err := c.Current()
+	if err != nil {
+		return nil, nil, err
+	}
+	return credentials.NewTLS(config).ClientHandshake(ctx, authority, conn)
+}
+
+func (c *Credentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	config, err := c.Current()
+	if err != nil {
+		return nil, nil, err
+	}
+	return credentials.NewTLS(config).ServerHandshake(conn)
+}
+
+func (c *Credentials) Info() credentials.ProtocolInfo {
+	config, err := c.Current()
+	if err != nil {
+		config = &tls.Config{}
+	}
+	return credentials.NewTLS(config).Info()
+}
+
+// Clone returns credentials sharing the loaded files with c.
+func (c *Credentials) Clone() credentials.TransportCredentials {
+	return c
+}
+
+// OverrideServerName is deprecated in gRPC and not supported.
+func (c *Credentials) OverrideServerName(string) error {
+	return errors.New("overriding the server name is not supported")
+}
+
+// fileModTimes returns the modification times of files.
+func fileModTimes(files []string) ([]time.Time, error) {
+	times := make([]time.Time, len(files))
+	for i, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		times[i] = info.ModTime()
+	}
+	return times, nil
+}
+
+func sameTimes(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// LoadCertPool reads the PEM certificates in file into a pool.
+func LoadCertPool(file string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in CA file %s", file)
+	}
+	return pool, nil
+}
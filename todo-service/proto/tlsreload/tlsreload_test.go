@@ -0,0 +1,81 @@
+package tlsreload
+
+import (
+	"crypto/tls"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredentials_Reload(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "cert.pem")
+	require.NoError(t, os.WriteFile(file, []byte("v1"), 0o644))
+	loads := 0
+	var failing error
+	load := func() (*tls.Config, error) {
+		if failing != nil {
+			return nil, failing
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		loads++
+		return &tls.Config{ServerName: string(content)}, nil
+	}
+
+	creds, err := New([]string{file}, load, slog.New(slog.DiscardHandler))
+	require.NoError(t, err)
+	first, err := creds.Current()
+	require.NoError(t, err)
+	assert.Equal(t, "v1", first.ServerName)
+	assert.Equal(t, 1, loads, "unchanged files are not loaded again")
+
+	// A file that fails to load keeps the loaded config
+	failing = errors.New("half-written key pair")
+	touch(t, file)
+	kept, err := creds.Current()
+	require.NoError(t, err)
+	assert.Same(t, first, kept)
+
+	failing = nil
+	require.NoError(t, os.WriteFile(file, []byte("v2"), 0o644))
+	touch(t, file)
+	reloaded, err := creds.Current()
+	require.NoError(t, err)
+	assert.Equal(t, "v2", reloaded.ServerName)
+}
+
+func TestNew_Invalid(t *testing.T) {
+	_, err := New([]string{"missing.pem"}, func() (*tls.Config, error) { return &tls.Config{}, nil }, nil)
+	assert.Error(t, err)
+
+	file := filepath.Join(t.TempDir(), "cert.pem")
+	require.NoError(t, os.WriteFile(file, nil, 0o644))
+	_, err = New([]string{file}, func() (*tls.Config, error) { return nil, errors.New("bad") }, nil)
+	assert.Error(t, err)
+}
+
+func TestLoadCertPool_NoCertificates(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(file, []byte("not a certificate"), 0o644))
+
+	_, err := LoadCertPool(file)
+	assert.Error(t, err)
+}
+
+// touch moves the modification time of file forward, for file systems with
+// coarse timestamps.
+func touch(t *testing.T, file string) {
+	t.Helper()
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+	later := info.ModTime().Add(time.Second)
+	require.NoError(t, os.Chtimes(file, later, later))
+}
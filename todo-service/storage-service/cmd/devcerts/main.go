@@ -0,0 +1,28 @@
+// Command devcerts generates a local CA and the certificates for running the
+// storage service and api-gateway with mutual TLS in development:
+//
+//	go run ./storage-service/cmd/devcerts -out certs
+//
+// Nothing is fetched from the network.
+package main
+
+import (
+	"flag"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/sahidhossen/todo/storage-service/internal/devcerts"
+)
+
+func main() {
+	out := flag.String("out", "certs", "directory to write the certificates and keys to")
+	hosts := flag.String("hosts", strings.Join(devcerts.DefaultHosts, ","), "comma-separated names and IP addresses of the storage service")
+	validFor := flag.Duration("valid-for", 365*24*time.Hour, "how long the certificates are valid")
+	flag.Parse()
+
+	if err := devcerts.Generate(*out, strings.Split(*hosts, ","), *validFor); err != nil {
+		log.Fatalf("Failed to generate certificates: %v", err)
+	}
+	log.Printf("Wrote the CA, storage-service and api-gateway certificates to %s", *out)
+}
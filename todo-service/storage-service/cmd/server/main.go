@@ -2,38 +2,64 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/sahidhossen/todo/proto/ctxlog"
 	pb "github.com/sahidhossen/todo/proto/task_service"
 	"github.com/sahidhossen/todo/storage-service/internal/config"
-	"github.com/sahidhossen/todo/storage-service/internal/db"
+	"github.com/sahidhossen/todo/storage-service/internal/events"
+	"github.com/sahidhossen/todo/storage-service/internal/healthcheck"
+	"github.com/sahidhossen/todo/storage-service/internal/interceptors"
+	"github.com/sahidhossen/todo/storage-service/internal/jobs"
+	"github.com/sahidhossen/todo/storage-service/internal/metrics"
+	"github.com/sahidhossen/todo/storage-service/internal/migrations"
 	"github.com/sahidhossen/todo/storage-service/internal/services"
 	"github.com/sahidhossen/todo/storage-service/internal/store"
+	"github.com/sahidhossen/todo/storage-service/internal/tlsconfig"
+	"github.com/sahidhossen/todo/storage-service/internal/tracing"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
 func main() {
-	// Setup structured logger
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	// Setup structured logger, tagging records with the request ID and trace
+	// of their context
+	logger := slog.New(ctxlog.NewHandler(slog.NewJSONHandler(os.Stdout, nil)))
 	slog.SetDefault(logger)
 
 	cfg := config.LoadConfig()
 
-	// Initialize Database Connection
-	// Create directory for database file if it doesn't exist
-	dbDir := "./data"
-	if err := os.MkdirAll(dbDir, 0755); err != nil {
-		logger.Error("Failed to create database directory", "path", dbDir, "error", err)
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(cfg, logger, os.Args[2:]))
+	}
+
+	// TaskService calls act for the user named in their metadata, which is
+	// only believed from callers with a client certificate or the gateway secret
+	mutualTLS := cfg.TLSCertFile != "" && cfg.TLSKeyFile != "" && cfg.TLSClientCAFile != ""
+	if !mutualTLS && cfg.GatewaySecret == "" {
+		logger.Error("Refusing to start without caller authentication, set GATEWAY_SECRET or enable mutual TLS with TLS_CLIENT_CA_FILE")
+		os.Exit(1)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Exporter:    cfg.TracesExporter,
+		File:        cfg.TracesFile,
+		ServiceName: cfg.ServiceName,
+	})
+	if err != nil {
+		logger.Error("Failed to set up tracing", "error", err)
 		os.Exit(1)
 	}
 
-	database, err := db.NewConnection(cfg.DBPath, logger)
+	database, err := openDatabase(cfg, logger)
 	if err != nil {
 		logger.Error("Failed to establish database connection", "error", err)
 		os.Exit(1)
@@ -45,13 +71,36 @@ func main() {
 		}
 	}()
 
-	// Apply Database Schema. We can think about migration command
-	if err := db.ApplySchema(database, logger); err != nil {
-		logger.Error("Failed to apply database schema", "error", err)
-		os.Exit(1)
+	// Apply pending schema migrations unless they are run as a separate step
+	if cfg.AutoMigrate {
+		migrator, err := migrations.New(database, logger)
+		if err == nil {
+			err = migrator.Up(context.Background())
+		}
+		if err != nil {
+			logger.Error("Failed to migrate database schema", "error", err)
+			os.Exit(1)
+		}
 	}
 
-	taskStore := store.NewSQLiteStore(database, logger)
+	// Metrics cover gRPC calls, store queries, the connection pool and task totals
+	serviceMetrics := metrics.New(logger)
+	serviceMetrics.RegisterDB(database, "todo")
+
+	// Task events are published by the store and streamed by WatchTasks
+	broker := events.NewBroker(cfg.EventBacklog, logger)
+	sqliteStore := store.NewSQLiteStore(database, logger).WithEvents(broker)
+	serviceMetrics.RegisterTotals(sqliteStore)
+	taskStore := store.Instrument(sqliteStore, serviceMetrics)
+
+	// Start background jobs, stopped on shutdown
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go jobs.NewTrashPurger(taskStore, cfg.TrashRetention, cfg.TrashPurgeInterval, logger).Run(jobsCtx)
+
+	// Serving status follows database pings, see grpc.health.v1
+	healthChecker := healthcheck.New(database, cfg.HealthCheckInterval, logger)
+	go healthChecker.Run(jobsCtx)
 
 	// Setup gRPC server
 	list, err := net.Listen("tcp", ":"+cfg.GRPCPort)
@@ -61,10 +110,36 @@ func main() {
 		os.Exit(1)
 	}
 
-	server := grpc.NewServer()
+	// TaskService calls act for the user in their metadata, see auth.PublicMethods,
+	// so the auth interceptor is always installed
+	serverOpts := interceptors.ServerOptions(interceptors.Config{
+		Logging:    cfg.LogRequests,
+		Recovery:   cfg.RecoverPanics,
+		Auth:       true,
+		AuthSecret: cfg.GatewaySecret,
+		Validation: cfg.ValidateRequests,
+		Metrics:    serviceMetrics,
+		Tracing:    true,
+	}, logger)
+
+	tlsConfig := tlsconfig.ServerConfig{
+		CertFile:     cfg.TLSCertFile,
+		KeyFile:      cfg.TLSKeyFile,
+		ClientCAFile: cfg.TLSClientCAFile,
+	}
+	if tlsConfig.Enabled() {
+		creds, err := tlsconfig.NewServerCredentials(tlsConfig, logger)
+		if err != nil {
+			logger.Error("Failed to load TLS certificates", "error", err)
+			os.Exit(1)
+		}
+		serverOpts = append(serverOpts, grpc.Creds(creds))
+	}
+	server := grpc.NewServer(serverOpts...)
 
 	// Register task service server from gRPC
-	pb.RegisterTaskServiceServer(server, services.NewTaskServiceServer(taskStore, logger))
+	pb.RegisterTaskServiceServer(server, services.NewTaskServiceServer(taskStore, logger).WithEvents(broker))
+	healthpb.RegisterHealthServer(server, healthChecker.Server())
 	reflection.Register(server) // Enable gRPC reflection for debugging
 
 	// Graceful shutdown channel
@@ -73,15 +148,38 @@ func main() {
 
 	// Start gRPC server in a goroutine
 	go func() {
-		logger.Info("gRPC server listening", "port", cfg.GRPCPort)
+		logger.Info("gRPC server listening", "port", cfg.GRPCPort, "tls", tlsConfig.Enabled(), "mtls", tlsConfig.ClientCAFile != "")
 		if err := server.Serve(list); err != nil {
 			logger.Error("gRPC server failed to serve", "error", err)
 		}
 	}()
 
+	// Serve metrics on the admin port, apart from the gRPC API
+	var adminServer *http.Server
+	if cfg.MetricsPort != "" {
+		adminMux := http.NewServeMux()
+		adminMux.Handle("/metrics", serviceMetrics.Handler())
+		adminServer = &http.Server{
+			Addr:              ":" + cfg.MetricsPort,
+			Handler:           adminMux,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			logger.Info("Admin server listening", "port", cfg.MetricsPort)
+			if err := adminServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error("Admin server failed to serve", "error", err)
+			}
+		}()
+	}
+
 	// Wait for OS signal for gracful shutdown
 	sig := <-quit
 	logger.Info("Shutting down gRPC server...", "signal", sig)
+	// Report NOT_SERVING so that clients stop sending calls while draining
+	healthChecker.Shutdown()
+	stopJobs()
+	// End WatchTasks streams, which would otherwise keep GracefulStop waiting
+	broker.Close()
 
 	// Graceful shutdown with a timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
@@ -102,6 +200,17 @@ func main() {
 		server.Stop()
 	}
 
+	if adminServer != nil {
+		if err := adminServer.Shutdown(ctx); err != nil {
+			logger.Error("Admin server did not stop gracefully", "error", err)
+		}
+	}
+
+	// Flush the spans of the last calls
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Error("Failed to flush traces", "error", err)
+	}
+
 	logger.Info("Storage service exited.")
 
 }
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sahidhossen/todo/storage-service/internal/config"
+	"github.com/sahidhossen/todo/storage-service/internal/db"
+	"github.com/sahidhossen/todo/storage-service/internal/migrations"
+	"github.com/sahidhossen/todo/storage-service/internal/store"
+)
+
+const migrateUsage = `usage: server migrate <command>
+
+commands:
+  up        apply all pending migrations
+  down      roll back the most recent migration
+  status    list migrations and whether they are applied
+  to N      migrate up or down to version N (0 rolls back everything)
+  adopt EMAIL
+            give the tasks, labels and projects created before user
+            accounts existed to the registered user with this email
+`
+
+// openDatabase connects to the configured database, creating its directory if needed.
+func openDatabase(cfg *config.Config, logger *slog.Logger) (*sql.DB, error) {
+	dbDir := filepath.Dir(cfg.DBPath)
+	if err := os.MkdirAll(dbDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create database directory %s: %w", dbDir, err)
+	}
+	return db.NewConnection(cfg.DBPath, logger)
+}
+
+// runMigrate implements the "migrate" subcommand and returns the process exit code.
+func runMigrate(cfg *config.Config, logger *slog.Logger, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	database, err := openDatabase(cfg, logger)
+	if err != nil {
+		logger.Error("Failed to establish database connection", "error", err)
+		return 1
+	}
+	defer database.Close()
+
+	migrator, err := migrations.New(database, logger)
+	if err != nil {
+		logger.Error("Failed to load migrations", "error", err)
+		return 1
+	}
+
+	ctx := context.Background()
+	switch args[0] {
+	case "up":
+		err = migrator.Up(ctx)
+	case "down":
+		err = migrator.Down(ctx)
+	case "to":
+		if len(args) != 2 {
+			fmt.Fprint(os.Stderr, migrateUsage)
+			return 2
+		}
+		version, convErr := strconv.Atoi(args[1])
+		if convErr != nil || version < 0 {
+			fmt.Fprintf(os.Stderr, "invalid migration version %q\n", args[1])
+			return 2
+		}
+		err = migrator.To(ctx, version)
+	case "status":
+		err = printStatus(ctx, migrator)
+	case "adopt":
+		if len(args) != 2 {
+			fmt.Fprint(os.Stderr, migrateUsage)
+			return 2
+		}
+		var adopted map[string]int64
+		adopted, err = store.NewSQLiteStore(database, logger).AdoptUnowned(ctx, args[1])
+		if err == nil {
+			fmt.Printf("adopted %d tasks, %d labels and %d projects\n", adopted["tasks"], adopted["labels"], adopted["projects"])
+		}
+	default:
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	if err != nil {
+		logger.Error("Migration failed", "command", args[0], "error", err)
+		return 1
+	}
+	return 0
+}
+
+func printStatus(ctx context.Context, migrator *migrations.Migrator) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, st := range statuses {
+		state, appliedAt := "pending", ""
+		if st.Applied {
+			state = "applied"
+			appliedAt = st.AppliedAt.Format(time.RFC3339)
+		}
+		if st.Dirty {
+			state = "modified"
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", st.Version, st.Name, state, appliedAt)
+	}
+	return w.Flush()
+}
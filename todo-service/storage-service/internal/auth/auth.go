@@ -0,0 +1,106 @@
+// Package auth identifies the user a request is made for and hashes user
+// passwords and API tokens.
+//
+// The API gateway authenticates clients and passes the caller's user ID in
+// the UserIDMetadataKey gRPC metadata. Interceptors move it into the request
+// context, where the store reads it to scope every query to the caller. The
+// metadata is only believed from callers with a verified TLS client
+// certificate or the shared secret in SecretMetadataKey.
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+)
+
+// UserIDMetadataKey is the gRPC metadata key carrying the caller's user ID.
+const UserIDMetadataKey = "x-user-id"
+
+type userIDKey struct{}
+
+// WithUserID returns a copy of ctx carrying the caller's user ID.
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// UserID returns the caller's user ID carried by ctx. The error wraps
+// domain.ErrUnauthenticated when there is none.
+func UserID(ctx context.Context) (string, error) {
+	userID, _ := ctx.Value(userIDKey{}).(string)
+	if userID == "" {
+		return "", fmt.Errorf("no user ID in request context: %w", domain.ErrUnauthenticated)
+	}
+	return userID, nil
+}
+
+// bcryptCost is the work factor of password hashes.
+const bcryptCost = 12
+
+// MinPasswordLength and MaxPasswordLength bound passwords in bytes. bcrypt
+// ignores everything past 72 bytes, so longer passwords are refused.
+const (
+	MinPasswordLength = 8
+	MaxPasswordLength = 72
+)
+
+// HashPassword returns the bcrypt hash of password.
+func HashPassword(password string) (string, error) {
+	if len(password) < MinPasswordLength || len(password) > MaxPasswordLength {
+		return "", fmt.Errorf("password must be %d to %d bytes long: %w", MinPasswordLength, MaxPasswordLength, domain.ErrInvalidInput)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcryptCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return string(hash), nil
+}
+
+// dummyHash is compared against when a login names an unknown user, so that
+// the response time does not reveal which email addresses are registered.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("not a password"), bcryptCost)
+
+// CheckPassword reports whether password matches hash. An empty hash, as for
+// an unknown user, never matches but takes as long as checking a real one.
+func CheckPassword(hash, password string) (bool, error) {
+	if hash == "" {
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return false, nil
+	}
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check password: %w", err)
+	}
+	return true, nil
+}
+
+// apiTokenBytes is the number of random bytes in an API token.
+const apiTokenBytes = 32
+
+// NewApiToken returns a new random API token and its hash.
+func NewApiToken() (token, hash string, err error) {
+	b := make([]byte, apiTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("failed to generate API token: %w", err)
+	}
+	token = domain.ApiTokenPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return token, HashApiToken(token), nil
+}
+
+// HashApiToken returns the hash an API token is stored and looked up by. The
+// tokens are random, so unlike passwords they need no slow, salted hash.
+func HashApiToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"strings"
+	"testing"
+
+	pb "github.com/sahidhossen/todo/proto/task_service"
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestUserID(t *testing.T) {
+	_, err := UserID(context.Background())
+	assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+
+	userID, err := UserID(WithUserID(context.Background(), "user-1"))
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", userID)
+}
+
+func TestHashPassword(t *testing.T) {
+	hash, err := HashPassword("correct horse")
+	require.NoError(t, err)
+
+	ok, err := CheckPassword(hash, "correct horse")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = CheckPassword(hash, "wrong horse")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = CheckPassword("", "correct horse")
+	require.NoError(t, err)
+	assert.False(t, ok, "an empty hash never matches")
+}
+
+func TestHashPassword_Length(t *testing.T) {
+	_, err := HashPassword("short")
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+	_, err = HashPassword(strings.Repeat("x", MaxPasswordLength+1))
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor("secret")
+	var seen string
+	handler := func(ctx context.Context, req any) (any, error) {
+		seen, _ = UserID(ctx)
+		return nil, nil
+	}
+	call := func(ctx context.Context, method string) error {
+		seen = ""
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(UserIDMetadataKey, "user-1", SecretMetadataKey, "secret"))
+	require.NoError(t, call(ctx, pb.TaskService_ListTasks_FullMethodName))
+	assert.Equal(t, "user-1", seen)
+
+	err := call(context.Background(), pb.TaskService_ListTasks_FullMethodName)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	assert.NoError(t, call(context.Background(), pb.TaskService_Login_FullMethodName))
+	assert.NoError(t, call(context.Background(), "/grpc.reflection.v1.ServerReflection/ServerReflectionInfo"))
+}
+
+func TestUnaryServerInterceptor_UntrustedCaller(t *testing.T) {
+	handler := func(ctx context.Context, req any) (any, error) { return nil, nil }
+	info := &grpc.UnaryServerInfo{FullMethod: pb.TaskService_ListTasks_FullMethodName}
+
+	for name, pairs := range map[string][]string{
+		"no secret":    {UserIDMetadataKey, "user-1"},
+		"wrong secret": {UserIDMetadataKey, "user-1", SecretMetadataKey, "guess"},
+	} {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(pairs...))
+		_, err := UnaryServerInterceptor("secret")(ctx, nil, info, handler)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err), name)
+	}
+
+	// Without a secret configured only client certificates are trusted.
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(UserIDMetadataKey, "user-1", SecretMetadataKey, ""))
+	_, err := UnaryServerInterceptor("")(ctx, nil, info, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestUnaryServerInterceptor_ClientCertificate(t *testing.T) {
+	var seen string
+	handler := func(ctx context.Context, req any) (any, error) {
+		seen, _ = UserID(ctx)
+		return nil, nil
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(UserIDMetadataKey, "user-1"))
+	ctx = peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{
+		State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{}}}},
+	}})
+
+	_, err := UnaryServerInterceptor("")(ctx, nil, &grpc.UnaryServerInfo{FullMethod: pb.TaskService_ListTasks_FullMethodName}, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", seen)
+}
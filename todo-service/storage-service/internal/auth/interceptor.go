@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	pb "github.com/sahidhossen/todo/proto/task_service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// SecretMetadataKey is the gRPC metadata key carrying the shared secret that
+// callers without a TLS client certificate prove themselves with.
+const SecretMetadataKey = "x-gateway-secret"
+
+// PublicMethods are the TaskService methods callers may use without a user
+// ID: the ones that establish who the user is.
+var PublicMethods = map[string]bool{
+	pb.TaskService_Register_FullMethodName:       true,
+	pb.TaskService_Login_FullMethodName:          true,
+	pb.TaskService_VerifyApiToken_FullMethodName: true,
+}
+
+// taskServicePrefix starts the full method names of TaskService. Other
+// services, such as reflection, need no user ID.
+var taskServicePrefix = "/" + pb.TaskService_ServiceDesc.ServiceName + "/"
+
+// fromMetadata returns ctx carrying the user ID from the incoming metadata.
+// Calls to TaskService methods other than PublicMethods are refused without
+// one, and calls naming a user are refused unless the caller is trusted.
+func fromMetadata(ctx context.Context, method, secret string) (context.Context, error) {
+	if values := metadata.ValueFromIncomingContext(ctx, UserIDMetadataKey); len(values) == 1 && values[0] != "" {
+		if !trusted(ctx, secret) {
+			return nil, status.Errorf(codes.Unauthenticated, "%s metadata from an unauthenticated caller", UserIDMetadataKey)
+		}
+		return WithUserID(ctx, values[0]), nil
+	}
+	if PublicMethods[method] || !strings.HasPrefix(method, taskServicePrefix) {
+		return ctx, nil
+	}
+	return nil, status.Errorf(codes.Unauthenticated, "missing %s metadata", UserIDMetadataKey)
+}
+
+// trusted reports whether the caller may act for the user it names: it
+// presented a TLS client certificate the server verified, or it sent secret.
+// Anyone able to reach the port could otherwise act as any user.
+func trusted(ctx context.Context, secret string) bool {
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(info.State.VerifiedChains) > 0 {
+			return true
+		}
+	}
+	values := metadata.ValueFromIncomingContext(ctx, SecretMetadataKey)
+	return secret != "" && len(values) == 1 && subtle.ConstantTimeCompare([]byte(values[0]), []byte(secret)) == 1
+}
+
+// UnaryServerInterceptor puts the caller's user ID from the request metadata
+// into the context of unary calls. Callers without a verified TLS client
+// certificate must send secret in the SecretMetadataKey metadata.
+func UnaryServerInterceptor(secret string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := fromMetadata(ctx, info.FullMethod, secret)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor puts the caller's user ID from the request metadata
+// into the context of streaming calls, trusting callers like
+// UnaryServerInterceptor.
+func StreamServerInterceptor(secret string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := fromMetadata(ss.Context(), info.FullMethod, secret)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &userStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// userStream is a grpc.ServerStream with a replaced context.
+type userStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *userStream) Context() context.Context {
+	return s.ctx
+}
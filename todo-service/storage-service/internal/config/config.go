@@ -3,6 +3,8 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -11,6 +13,54 @@ import (
 type Config struct {
 	GRPCPort string
 	DBPath   string
+
+	// AutoMigrate applies pending schema migrations at startup. Disable it to
+	// run "server migrate up" as a separate deployment step instead.
+	AutoMigrate bool
+
+	// TrashRetention is how long deleted tasks stay in the trash before they
+	// are purged. Zero disables purging.
+	TrashRetention     time.Duration
+	TrashPurgeInterval time.Duration
+
+	// EventBacklog is the number of recent task events kept for WatchTasks
+	// clients that resume after a reconnect.
+	EventBacklog int
+
+	// LogRequests, RecoverPanics and ValidateRequests switch the gRPC server
+	// interceptors of the same purpose on or off.
+	LogRequests      bool
+	RecoverPanics    bool
+	ValidateRequests bool
+
+	// HealthCheckInterval is the time between the database pings behind the
+	// grpc.health.v1 serving status.
+	HealthCheckInterval time.Duration
+
+	// MetricsPort is the admin port serving Prometheus metrics at /metrics.
+	// Empty disables it.
+	MetricsPort string
+
+	// TracesExporter is where OpenTelemetry spans go: "otlp", "stdout",
+	// "file" (appending to TracesFile) or "none". The OTLP endpoint is set
+	// with the standard OTEL_EXPORTER_OTLP_* variables.
+	TracesExporter string
+	TracesFile     string
+	ServiceName    string
+
+	// TLS serves gRPC over TLS when TLSCertFile and TLSKeyFile are set.
+	// TLSClientCAFile turns on mutual TLS, requiring client certificates
+	// signed by its CAs. The files are reloaded when they change.
+	TLSCertFile     string
+	TLSKeyFile      string
+	TLSClientCAFile string
+
+	// GatewaySecret is the shared secret the api-gateway sends with every
+	// call. TaskService calls act for the user named in their x-user-id
+	// metadata, so the server only believes it from callers with a client
+	// certificate (mutual TLS) or this secret, and refuses to start with
+	// neither configured.
+	GatewaySecret string
 }
 
 // LoadConfig loads the configurations
@@ -24,6 +74,31 @@ func LoadConfig() *Config {
 	return &Config{
 		GRPCPort: getEnv("GRPC_PORT", "50051"),
 		DBPath:   getEnv("DB_PATH", "./data/todo.db"), // Default path
+
+		AutoMigrate: getEnvBool("DB_AUTO_MIGRATE", true),
+
+		TrashRetention:     getEnvDuration("TRASH_RETENTION", 30*24*time.Hour),
+		TrashPurgeInterval: getEnvDuration("TRASH_PURGE_INTERVAL", time.Hour),
+
+		EventBacklog: getEnvInt("EVENT_BACKLOG", 1000),
+
+		LogRequests:      getEnvBool("GRPC_LOG_REQUESTS", true),
+		RecoverPanics:    getEnvBool("GRPC_RECOVER_PANICS", true),
+		ValidateRequests: getEnvBool("GRPC_VALIDATE_REQUESTS", true),
+
+		HealthCheckInterval: getEnvDuration("HEALTH_CHECK_INTERVAL", 5*time.Second),
+
+		MetricsPort: getEnv("METRICS_PORT", "9090"),
+
+		TracesExporter: getEnv("TRACES_EXPORTER", "none"),
+		TracesFile:     getEnv("TRACES_FILE", "./data/traces.json"),
+		ServiceName:    getEnv("OTEL_SERVICE_NAME", "storage-service"),
+
+		TLSCertFile:     getEnv("TLS_CERT_FILE", ""),
+		TLSKeyFile:      getEnv("TLS_KEY_FILE", ""),
+		TLSClientCAFile: getEnv("TLS_CLIENT_CA_FILE", ""),
+
+		GatewaySecret: getEnv("GATEWAY_SECRET", ""),
 	}
 }
 
@@ -33,3 +108,42 @@ func getEnv(key string, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvBool(key string, defaultValue bool) bool {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Invalid boolean %q for %s, using default %t", value, key, defaultValue)
+		return defaultValue
+	}
+	return b
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Invalid integer %q for %s, using default %d", value, key, defaultValue)
+		return defaultValue
+	}
+	return n
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Invalid duration %q for %s, using default %s", value, key, defaultValue)
+		return defaultValue
+	}
+	return d
+}
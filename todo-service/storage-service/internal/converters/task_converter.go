@@ -1,8 +1,11 @@
 package converters
 
 import (
+	"time"
+
 	pb "github.com/sahidhossen/todo/proto/task_service"
 	"github.com/sahidhossen/todo/storage-service/internal/domain"
+	"github.com/sahidhossen/todo/storage-service/internal/events"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -11,13 +14,50 @@ func DomainToProtoTask(dTask *domain.Task) *pb.Task {
 	if dTask == nil {
 		return nil
 	}
-	return &pb.Task{
-		Id:          dTask.ID,
-		Title:       dTask.Title,
-		Description: dTask.Description,
-		Completed:   dTask.Completed,
-		CreatedAt:   timestamppb.New(dTask.CreatedAt),
-		UpdatedAt:   timestamppb.New(dTask.UpdatedAt),
+	pTask := &pb.Task{
+		Id:           dTask.ID,
+		Title:        dTask.Title,
+		Description:  dTask.Description,
+		Completed:    dTask.Completed,
+		CreatedAt:    timestamppb.New(dTask.CreatedAt),
+		UpdatedAt:    timestamppb.New(dTask.UpdatedAt),
+		AllDay:       dTask.AllDay,
+		Priority:     pb.Priority(dTask.Priority),
+		Labels:       dTask.Labels,
+		ProjectId:    dTask.ProjectID,
+		ParentId:     dTask.ParentID,
+		AutoComplete: dTask.AutoComplete,
+		Recurrence:   dTask.Recurrence,
+		SeriesId:     dTask.SeriesID,
+		OwnerId:      dTask.OwnerID,
+	}
+	for _, child := range dTask.Children {
+		pTask.Children = append(pTask.Children, DomainToProtoTask(child))
+	}
+	if dTask.DeletedAt != nil {
+		pTask.DeletedAt = timestamppb.New(*dTask.DeletedAt)
+	}
+	if dTask.DueAt != nil {
+		pTask.DueAt = timestamppb.New(*dTask.DueAt)
+	}
+	return pTask
+}
+
+// eventTypes maps event types to their protobuf counterparts.
+var eventTypes = map[events.Type]pb.TaskEvent_Type{
+	events.TypeCreated:   pb.TaskEvent_TYPE_CREATED,
+	events.TypeUpdated:   pb.TaskEvent_TYPE_UPDATED,
+	events.TypeCompleted: pb.TaskEvent_TYPE_COMPLETED,
+	events.TypeDeleted:   pb.TaskEvent_TYPE_DELETED,
+}
+
+// EventToProto converts an events.Event to a pb.TaskEvent.
+func EventToProto(event events.Event) *pb.TaskEvent {
+	return &pb.TaskEvent{
+		Sequence: event.Sequence,
+		Type:     eventTypes[event.Type],
+		Task:     DomainToProtoTask(event.Task),
+		Time:     timestamppb.New(event.Time),
 	}
 }
 
@@ -26,12 +66,125 @@ func ProtoToDomainTask(pTask *pb.Task) *domain.Task {
 	if pTask == nil {
 		return nil
 	}
-	return &domain.Task{
-		ID:          pTask.GetId(),
-		Title:       pTask.GetTitle(),
-		Description: pTask.GetDescription(),
-		Completed:   pTask.GetCompleted(),
-		CreatedAt:   pTask.GetCreatedAt().AsTime(),
-		UpdatedAt:   pTask.GetUpdatedAt().AsTime(),
+	dTask := &domain.Task{
+		ID:           pTask.GetId(),
+		Title:        pTask.GetTitle(),
+		Description:  pTask.GetDescription(),
+		Completed:    pTask.GetCompleted(),
+		CreatedAt:    pTask.GetCreatedAt().AsTime(),
+		UpdatedAt:    pTask.GetUpdatedAt().AsTime(),
+		DeletedAt:    OptionalTime(pTask.GetDeletedAt()),
+		DueAt:        OptionalTime(pTask.GetDueAt()),
+		AllDay:       pTask.GetAllDay(),
+		Priority:     domain.Priority(pTask.GetPriority()),
+		Labels:       pTask.GetLabels(),
+		ProjectID:    pTask.GetProjectId(),
+		ParentID:     pTask.GetParentId(),
+		AutoComplete: pTask.GetAutoComplete(),
+		Recurrence:   pTask.GetRecurrence(),
+		SeriesID:     pTask.GetSeriesId(),
+	}
+	return dTask
+}
+
+// ProtoToListOptions converts a pb.ListTasksRequest to domain.TaskListOptions.
+func ProtoToListOptions(req *pb.ListTasksRequest) domain.TaskListOptions {
+	return domain.TaskListOptions{
+		PageSize:      int(req.GetPageSize()),
+		PageToken:     req.GetPageToken(),
+		Completed:     req.Completed,
+		CreatedAfter:  OptionalTime(req.GetCreatedAfter()),
+		CreatedBefore: OptionalTime(req.GetCreatedBefore()),
+		UpdatedAfter:  OptionalTime(req.GetUpdatedAfter()),
+		UpdatedBefore: OptionalTime(req.GetUpdatedBefore()),
+		Overdue:       req.Overdue,
+		DueAfter:      OptionalTime(req.GetDueAfter()),
+		DueBefore:     OptionalTime(req.GetDueBefore()),
+		Labels:        req.GetLabels(),
+		ProjectID:     req.GetProjectId(),
+		OrderBy:       req.GetOrderBy(),
+	}
+}
+
+// DomainToProtoLabel converts a domain.Label to a pb.Label.
+func DomainToProtoLabel(label *domain.Label) *pb.Label {
+	if label == nil {
+		return nil
+	}
+	return &pb.Label{
+		Id:        label.ID,
+		Name:      label.Name,
+		TaskCount: label.TaskCount,
+		CreatedAt: timestamppb.New(label.CreatedAt),
+	}
+}
+
+// DomainToProtoProject converts a domain.Project to a pb.Project.
+func DomainToProtoProject(project *domain.Project) *pb.Project {
+	if project == nil {
+		return nil
+	}
+	return &pb.Project{
+		Id:        project.ID,
+		Name:      project.Name,
+		Color:     project.Color,
+		Archived:  project.Archived,
+		SortOrder: project.SortOrder,
+		CreatedAt: timestamppb.New(project.CreatedAt),
+		UpdatedAt: timestamppb.New(project.UpdatedAt),
+	}
+}
+
+// DomainToProtoUser converts a domain.User to a pb.User, leaving out the password hash.
+func DomainToProtoUser(user *domain.User) *pb.User {
+	if user == nil {
+		return nil
+	}
+	return &pb.User{
+		Id:        user.ID,
+		Email:     user.Email,
+		CreatedAt: timestamppb.New(user.CreatedAt),
+	}
+}
+
+// DomainToProtoSearchResult converts a domain.TaskSearchResult to a pb.SearchResult.
+func DomainToProtoSearchResult(result *domain.TaskSearchResult) *pb.SearchResult {
+	if result == nil {
+		return nil
+	}
+	return &pb.SearchResult{
+		Task:               DomainToProtoTask(result.Task),
+		Score:              result.Score,
+		TitleHighlight:     result.TitleHighlight,
+		DescriptionSnippet: result.DescriptionSnippet,
+	}
+}
+
+// OptionalTime converts an optional timestamp, returning nil when it is unset.
+func OptionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+// DomainToProtoApiToken converts an API token, which never carries its secret.
+func DomainToProtoApiToken(token *domain.ApiToken) *pb.ApiToken {
+	if token == nil {
+		return nil
+	}
+	pToken := &pb.ApiToken{
+		Id:        token.ID,
+		Name:      token.Name,
+		Scopes:    token.Scopes,
+		CreatedAt: timestamppb.New(token.CreatedAt),
+	}
+	if token.ExpiresAt != nil {
+		pToken.ExpiresAt = timestamppb.New(*token.ExpiresAt)
+	}
+	if token.LastUsedAt != nil {
+		pToken.LastUsedAt = timestamppb.New(*token.LastUsedAt)
 	}
+	return pToken
 }
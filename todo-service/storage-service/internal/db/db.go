@@ -5,14 +5,26 @@ import (
 	"database/sql"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3" // SQLite driver
 )
 
-// NewConnection opens and returns a *sql.DB connection.
+// NewConnection opens and returns a *sql.DB connection. Foreign key
+// enforcement is switched on for every connection in the pool, so ON DELETE
+// CASCADE clauses in the schema take effect.
 func NewConnection(dbPath string, logger *slog.Logger) (*sql.DB, error) {
-	db, err := sql.Open("sqlite3", dbPath)
+	dsn := dbPath
+	if !strings.Contains(dsn, "_foreign_keys=") {
+		if strings.Contains(dsn, "?") {
+			dsn += "&_foreign_keys=on"
+		} else {
+			dsn += "?_foreign_keys=on"
+		}
+	}
+
+	db, err := sql.Open("sqlite3", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database at %s: %w", dbPath, err)
 	}
@@ -32,26 +44,3 @@ func NewConnection(dbPath string, logger *slog.Logger) (*sql.DB, error) {
 	logger.Info("Database connection established", "path", dbPath)
 	return db, nil
 }
-
-// In a real project, this would be handled by a proper migration tool.
-func ApplySchema(db *sql.DB, logger *slog.Logger) error {
-	tasksTableSQL := `
-	CREATE TABLE IF NOT EXISTS tasks (
-		id TEXT PRIMARY KEY,
-		title TEXT NOT NULL,
-		description TEXT,
-		completed BOOLEAN NOT NULL DEFAULT FALSE,
-		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
-	);`
-
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	if _, err := db.ExecContext(ctx, tasksTableSQL); err != nil {
-		return fmt.Errorf("failed to create tasks table: %w", err)
-	}
-	logger.Debug("Tasks table ensured")
-
-	return nil
-}
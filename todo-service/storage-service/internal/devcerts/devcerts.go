@@ -0,0 +1,139 @@
+// Package devcerts generates a local CA and the certificates the storage
+// service and api-gateway need for mutual TLS, for development and tests.
+package devcerts
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// The files Generate writes.
+const (
+	CAFile         = "ca.pem"
+	CAKeyFile      = "ca-key.pem"
+	ServerCertFile = "storage.pem"
+	ServerKeyFile  = "storage-key.pem"
+	ClientCertFile = "gateway.pem"
+	ClientKeyFile  = "gateway-key.pem"
+)
+
+// DefaultHosts are the names the storage service is reached by locally and
+// in containers.
+var DefaultHosts = []string{"localhost", "127.0.0.1", "::1", "storage-service"}
+
+// Generate writes a new CA into dir, along with a server certificate for the
+// storage service, valid for hosts, and a client certificate for the
+// api-gateway, both signed by the CA. hosts may hold DNS names and IP
+// addresses. The certificates expire after validFor.
+func Generate(dir string, hosts []string, validFor time.Duration) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create certificate directory: %w", err)
+	}
+	notAfter := time.Now().Add(validFor)
+
+	caKey, err := writeKey(filepath.Join(dir, CAKeyFile))
+	if err != nil {
+		return err
+	}
+	caTemplate := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "todo dev CA"},
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caCert, err := writeCert(filepath.Join(dir, CAFile), caTemplate, caTemplate, caKey, caKey)
+	if err != nil {
+		return err
+	}
+
+	serverKey, err := writeKey(filepath.Join(dir, ServerKeyFile))
+	if err != nil {
+		return err
+	}
+	server := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "storage-service"},
+		NotAfter:    notAfter,
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			server.IPAddresses = append(server.IPAddresses, ip)
+		} else {
+			server.DNSNames = append(server.DNSNames, host)
+		}
+	}
+	if _, err := writeCert(filepath.Join(dir, ServerCertFile), server, caCert, serverKey, caKey); err != nil {
+		return err
+	}
+
+	clientKey, err := writeKey(filepath.Join(dir, ClientKeyFile))
+	if err != nil {
+		return err
+	}
+	client := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "api-gateway"},
+		NotAfter:    notAfter,
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if _, err := writeCert(filepath.Join(dir, ClientCertFile), client, caCert, clientKey, caKey); err != nil {
+		return err
+	}
+	return nil
+}
+
+// writeKey generates a P-256 key and writes it to file in PKCS #8 form.
+func writeKey(file string) (*ecdsa.PrivateKey, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode key: %w", err)
+	}
+	if err := writePEM(file, "PRIVATE KEY", der, 0o600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// writeCert signs template with the key of parent and writes the
+// certificate to file.
+func writeCert(file string, template, parent *x509.Certificate, key *ecdsa.PrivateKey, parentKey crypto.Signer) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+	template.SerialNumber = serial
+	template.NotBefore = time.Now().Add(-time.Hour) // tolerate clock skew
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate %s: %w", template.Subject.CommonName, err)
+	}
+	if err := writePEM(file, "CERTIFICATE", der, 0o644); err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(der)
+}
+
+func writePEM(file, blockType string, der []byte, perm os.FileMode) error {
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(file, data, perm); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	return nil
+}
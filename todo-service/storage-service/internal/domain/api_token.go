@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// API token scopes. Each scope includes the ones before it: a write token can
+// also read, and an admin token can also manage API tokens.
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+	ScopeAdmin = "admin"
+)
+
+var knownScopes = map[string]bool{ScopeRead: true, ScopeWrite: true, ScopeAdmin: true}
+
+// MaxApiTokenNameLength bounds API token names, in characters.
+const MaxApiTokenNameLength = 100
+
+// ApiTokenPrefix starts every API token, telling them apart from JWTs and
+// making leaked tokens easy to spot.
+const ApiTokenPrefix = "todo_pat_"
+
+// ApiToken is a personal access token a user's scripts authenticate with.
+// Only a hash of the token is stored.
+type ApiToken struct {
+	ID         string
+	OwnerID    string
+	Name       string
+	Scopes     []string
+	CreatedAt  time.Time
+	ExpiresAt  *time.Time // nil when the token does not expire
+	LastUsedAt *time.Time // nil until first used
+}
+
+// Expired reports whether the token has expired at now.
+func (t *ApiToken) Expired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
+
+// NormalizeApiTokenName trims a token name and checks its length.
+func NormalizeApiTokenName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("name cannot be empty: %w", ErrInvalidInput)
+	}
+	if len([]rune(name)) > MaxApiTokenNameLength {
+		return "", fmt.Errorf("name must be at most %d characters: %w", MaxApiTokenNameLength, ErrInvalidInput)
+	}
+	return name, nil
+}
+
+// NormalizeScopes checks that scopes is a non-empty list of known scopes and
+// returns it lower-cased, without duplicates, ordered by access.
+func NormalizeScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("at least one scope is required: %w", ErrInvalidInput)
+	}
+	seen := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if !knownScopes[scope] {
+			return nil, fmt.Errorf("unknown scope %q, want %s, %s or %s: %w", scope, ScopeRead, ScopeWrite, ScopeAdmin, ErrInvalidInput)
+		}
+		seen[scope] = true
+	}
+	normalized := make([]string, 0, len(seen))
+	for _, scope := range []string{ScopeRead, ScopeWrite, ScopeAdmin} {
+		if seen[scope] {
+			normalized = append(normalized, scope)
+		}
+	}
+	return normalized, nil
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when the requested entity does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidInput is returned when a request fails validation.
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrAlreadyExists is returned when an entity would violate a uniqueness rule.
+	ErrAlreadyExists = errors.New("already exists")
+	// ErrUnauthenticated is returned when the caller is not known.
+	ErrUnauthenticated = errors.New("unauthenticated")
+)
+
+// ParentError is returned when a task cannot be placed under ParentID. Err
+// wraps ErrNotFound when the parent does not exist and ErrInvalidInput when
+// the move would create a cycle or nest subtasks too deeply.
+type ParentError struct {
+	ParentID string
+	Err      error
+}
+
+func (e *ParentError) Error() string { return e.Err.Error() }
+
+func (e *ParentError) Unwrap() error { return e.Err }
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxLabelNameLength is the longest label name, in characters.
+const MaxLabelNameLength = 50
+
+// Label groups tasks by context. Each user's label names are unique, ignoring case.
+type Label struct {
+	ID        string
+	Name      string
+	TaskCount int32 // tasks outside the trash carrying the label
+	CreatedAt time.Time
+}
+
+// NormalizeLabelName trims a label name and checks that it is usable.
+func NormalizeLabelName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("label name cannot be empty: %w", ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(name) > MaxLabelNameLength {
+		return "", fmt.Errorf("label name cannot be longer than %d characters: %w", MaxLabelNameLength, ErrInvalidInput)
+	}
+	return name, nil
+}
+
+// NormalizeLabelNames normalizes names and drops duplicates, ignoring case.
+func NormalizeLabelNames(names []string) ([]string, error) {
+	normalized := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name, err := NormalizeLabelName(name)
+		if err != nil {
+			return nil, err
+		}
+		key := strings.ToLower(name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, name)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxProjectNameLength is the longest project name, in characters.
+const MaxProjectNameLength = 100
+
+var projectColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Project groups tasks into a separate board or list.
+type Project struct {
+	ID        string
+	Name      string
+	Color     string // "#rrggbb" or empty
+	Archived  bool
+	SortOrder int32
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Validate trims the project name and checks the name and color.
+func (p *Project) Validate() error {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return fmt.Errorf("project name cannot be empty: %w", ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(p.Name) > MaxProjectNameLength {
+		return fmt.Errorf("project name cannot be longer than %d characters: %w", MaxProjectNameLength, ErrInvalidInput)
+	}
+	if p.Color != "" && !projectColorPattern.MatchString(p.Color) {
+		return fmt.Errorf("project color must look like #rrggbb: %w", ErrInvalidInput)
+	}
+	p.Color = strings.ToLower(p.Color)
+	return nil
+}
@@ -0,0 +1,333 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the FREQ part of a recurrence rule.
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+	FrequencyYearly  Frequency = "YEARLY"
+)
+
+// RecurrenceDay is one BYDAY entry. Ordinal picks the nth such weekday of
+// the month, counting from the end when negative; zero means every one.
+type RecurrenceDay struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+// Recurrence is a parsed recurrence rule. It supports the RFC 5545 RRULE
+// parts FREQ, INTERVAL, BYDAY, COUNT and UNTIL. Dates are computed in UTC.
+type Recurrence struct {
+	Freq     Frequency
+	Interval int // at least 1
+	ByDay    []RecurrenceDay
+	Count    int        // total number of occurrences, 0 for no limit
+	Until    *time.Time // last moment an occurrence may fall on
+	// untilDate records that UNTIL was given as a date, so that String
+	// writes it back the same way.
+	untilDate bool
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// ParseRecurrence parses a recurrence rule such as
+// "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;COUNT=10". An optional "RRULE:"
+// prefix is accepted. Errors wrap ErrInvalidInput.
+func ParseRecurrence(rule string) (*Recurrence, error) {
+	rule = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(rule)), "RRULE:")
+	if rule == "" {
+		return nil, fmt.Errorf("recurrence rule is empty: %w", ErrInvalidInput)
+	}
+
+	r := &Recurrence{Interval: 1}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(rule, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid recurrence rule part %q: %w", part, ErrInvalidInput)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("recurrence rule part %s is repeated: %w", name, ErrInvalidInput)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			r.Freq = Frequency(value)
+			switch r.Freq {
+			case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+			default:
+				err = fmt.Errorf("unsupported recurrence frequency %s", value)
+			}
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+			if err != nil || r.Interval < 1 {
+				err = fmt.Errorf("recurrence INTERVAL must be a positive number")
+			}
+		case "COUNT":
+			r.Count, err = strconv.Atoi(value)
+			if err != nil || r.Count < 1 {
+				err = fmt.Errorf("recurrence COUNT must be a positive number")
+			}
+		case "UNTIL":
+			err = r.parseUntil(value)
+		case "BYDAY":
+			err = r.parseByDay(value)
+		default:
+			err = fmt.Errorf("unsupported recurrence rule part %s", name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", err, ErrInvalidInput)
+		}
+	}
+
+	if r.Freq == "" {
+		return nil, fmt.Errorf("recurrence rule needs a FREQ: %w", ErrInvalidInput)
+	}
+	if r.Count > 0 && r.Until != nil {
+		return nil, fmt.Errorf("recurrence rule cannot have both COUNT and UNTIL: %w", ErrInvalidInput)
+	}
+	if len(r.ByDay) > 0 && r.Freq == FrequencyYearly {
+		return nil, fmt.Errorf("recurrence BYDAY is not supported with FREQ=YEARLY: %w", ErrInvalidInput)
+	}
+	for _, day := range r.ByDay {
+		if day.Ordinal != 0 && r.Freq != FrequencyMonthly {
+			return nil, fmt.Errorf("recurrence BYDAY ordinals need FREQ=MONTHLY: %w", ErrInvalidInput)
+		}
+	}
+	return r, nil
+}
+
+func (r *Recurrence) parseUntil(value string) error {
+	if t, err := time.Parse("20060102", value); err == nil {
+		// A date includes the whole day.
+		until := t.Add(24*time.Hour - time.Second)
+		r.Until, r.untilDate = &until, true
+		return nil
+	}
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			r.Until = &t
+			return nil
+		}
+	}
+	return fmt.Errorf("recurrence UNTIL must be a date (YYYYMMDD) or a UTC time (YYYYMMDDTHHMMSSZ)")
+}
+
+func (r *Recurrence) parseByDay(value string) error {
+	for _, entry := range strings.Split(value, ",") {
+		if len(entry) < 2 {
+			return fmt.Errorf("invalid recurrence BYDAY entry %q", entry)
+		}
+		code, ordinal := entry[len(entry)-2:], entry[:len(entry)-2]
+		weekday, ok := weekdayCodes[code]
+		if !ok {
+			return fmt.Errorf("invalid recurrence BYDAY entry %q", entry)
+		}
+		day := RecurrenceDay{Weekday: weekday}
+		if ordinal != "" {
+			n, err := strconv.Atoi(ordinal)
+			if err != nil || n == 0 || n < -5 || n > 5 {
+				return fmt.Errorf("invalid recurrence BYDAY entry %q", entry)
+			}
+			day.Ordinal = n
+		}
+		if slices.Contains(r.ByDay, day) {
+			return fmt.Errorf("recurrence BYDAY entry %s is repeated", entry)
+		}
+		r.ByDay = append(r.ByDay, day)
+	}
+	return nil
+}
+
+// String formats the rule in canonical form, without the "RRULE:" prefix.
+func (r *Recurrence) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = weekdayNames[day.Weekday]
+			if day.Ordinal != 0 {
+				days[i] = strconv.Itoa(day.Ordinal) + days[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		if r.untilDate {
+			parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.Format("20060102T150405Z"))
+		}
+	}
+	return strings.Join(parts, ";")
+}
+
+// maxRecurrenceSteps bounds the search for the next matching period. Every
+// supported rule matches well within it; it only guards against looping.
+const maxRecurrenceSteps = 400
+
+// Next returns the occurrence that follows prev, keeping prev's time of day.
+// occurrences is the number of occurrences the series already has. Next
+// reports false when the series has ended because of COUNT or UNTIL.
+func (r *Recurrence) Next(prev time.Time, occurrences int) (time.Time, bool) {
+	if r.Count > 0 && occurrences >= r.Count {
+		return time.Time{}, false
+	}
+
+	prev = prev.UTC()
+	day := DateOf(prev)
+	clock := prev.Sub(day)
+
+	var next time.Time
+	switch r.Freq {
+	case FrequencyDaily:
+		next = r.nextDaily(day)
+	case FrequencyWeekly:
+		next = r.nextWeekly(day)
+	case FrequencyMonthly:
+		next = r.nextMonthly(day)
+	case FrequencyYearly:
+		next = r.nextYearly(day)
+	}
+	if next.IsZero() {
+		return time.Time{}, false
+	}
+
+	next = next.Add(clock)
+	if r.Until != nil && next.After(*r.Until) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+func (r *Recurrence) nextDaily(day time.Time) time.Time {
+	for step := 1; step <= maxRecurrenceSteps; step++ {
+		next := day.AddDate(0, 0, step*r.Interval)
+		if r.matchesWeekday(next) {
+			return next
+		}
+	}
+	return time.Time{}
+}
+
+func (r *Recurrence) nextWeekly(day time.Time) time.Time {
+	if len(r.ByDay) == 0 {
+		return day.AddDate(0, 0, 7*r.Interval)
+	}
+	// Weeks start on Monday. Only every Interval-th week, counted from the
+	// week of day, takes part.
+	weekStart := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	for offset := 1; offset <= 7*r.Interval+7; offset++ {
+		next := day.AddDate(0, 0, offset)
+		week := int(next.Sub(weekStart).Hours()) / (24 * 7)
+		if week%r.Interval == 0 && r.matchesWeekday(next) {
+			return next
+		}
+	}
+	return time.Time{}
+}
+
+func (r *Recurrence) nextMonthly(day time.Time) time.Time {
+	firstOfMonth := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if len(r.ByDay) == 0 {
+		// Months without the day of month, like the 31st in April, are skipped.
+		for step := 1; step <= maxRecurrenceSteps; step++ {
+			month := firstOfMonth.AddDate(0, step*r.Interval, 0)
+			next := month.AddDate(0, 0, day.Day()-1)
+			if next.Month() == month.Month() {
+				return next
+			}
+		}
+		return time.Time{}
+	}
+	for step := 0; step <= maxRecurrenceSteps; step++ {
+		month := firstOfMonth.AddDate(0, step*r.Interval, 0)
+		for _, next := range r.monthDays(month) {
+			if next.After(day) {
+				return next
+			}
+		}
+	}
+	return time.Time{}
+}
+
+func (r *Recurrence) nextYearly(day time.Time) time.Time {
+	// Years without the date, like February 29th, are skipped.
+	for step := 1; step <= maxRecurrenceSteps; step++ {
+		next := time.Date(day.Year()+step*r.Interval, day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+		if next.Month() == day.Month() {
+			return next
+		}
+	}
+	return time.Time{}
+}
+
+// matchesWeekday reports whether day is one of the BYDAY weekdays. Rules
+// without BYDAY match every day.
+func (r *Recurrence) matchesWeekday(day time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, byDay := range r.ByDay {
+		if byDay.Weekday == day.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// monthDays returns the days of the month starting at month that match
+// BYDAY, in order.
+func (r *Recurrence) monthDays(month time.Time) []time.Time {
+	var days []time.Time
+	for next := month; next.Month() == month.Month(); next = next.AddDate(0, 0, 1) {
+		for _, byDay := range r.ByDay {
+			if byDay.Weekday == next.Weekday() && byDay.matchesOrdinal(next) {
+				days = append(days, next)
+				break
+			}
+		}
+	}
+	return days
+}
+
+// matchesOrdinal reports whether day, which falls on d's weekday, is the
+// weekday's d.Ordinal-th occurrence in its month.
+func (d RecurrenceDay) matchesOrdinal(day time.Time) bool {
+	switch {
+	case d.Ordinal > 0:
+		return (day.Day()-1)/7+1 == d.Ordinal
+	case d.Ordinal < 0:
+		daysInMonth := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		return (daysInMonth-day.Day())/7+1 == -d.Ordinal
+	}
+	return true
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	r, err := ParseRecurrence("RRULE:freq=weekly;byday=mo,fr;interval=2;until=20261231")
+	assert.NoError(t, err)
+	assert.Equal(t, "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;UNTIL=20261231", r.String())
+
+	r, err = ParseRecurrence("FREQ=MONTHLY;BYDAY=-1FR;COUNT=3")
+	assert.NoError(t, err)
+	assert.Equal(t, "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3", r.String())
+
+	for _, bad := range []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=2;UNTIL=20261231",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;BYMONTH=3",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=WEEKLY;BYDAY=-1FR",
+		"FREQ=YEARLY;BYDAY=MO",
+		"FREQ=MONTHLY;BYDAY=6MO",
+		"FREQ=DAILY;UNTIL=tomorrow",
+	} {
+		_, err := ParseRecurrence(bad)
+		assert.True(t, errors.Is(err, ErrInvalidInput), bad)
+	}
+}
+
+func TestRecurrenceNext(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 9, 30, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		rule string
+		prev time.Time
+		want time.Time
+	}{
+		{"FREQ=DAILY;INTERVAL=3", date(2026, 1, 30), date(2026, 2, 2)},
+		{"FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR", date(2026, 10, 16), date(2026, 10, 19)},
+		{"FREQ=WEEKLY", date(2026, 10, 14), date(2026, 10, 21)},
+		// Friday 2026-10-16 is followed by Monday two weeks on.
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR", date(2026, 10, 16), date(2026, 10, 26)},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR", date(2026, 10, 12), date(2026, 10, 16)},
+		{"FREQ=MONTHLY", date(2026, 1, 31), date(2026, 3, 31)},
+		{"FREQ=MONTHLY;BYDAY=-1FR", date(2026, 10, 30), date(2026, 11, 27)},
+		{"FREQ=MONTHLY;BYDAY=2TU", date(2026, 10, 1), date(2026, 10, 13)},
+		{"FREQ=YEARLY", date(2024, 2, 29), date(2028, 2, 29)},
+	}
+	for _, tt := range tests {
+		r, err := ParseRecurrence(tt.rule)
+		if !assert.NoError(t, err, tt.rule) {
+			continue
+		}
+		next, ok := r.Next(tt.prev, 1)
+		assert.True(t, ok, tt.rule)
+		assert.Equal(t, tt.want, next, tt.rule)
+	}
+}
+
+func TestRecurrenceNext_Ends(t *testing.T) {
+	r, err := ParseRecurrence("FREQ=DAILY;COUNT=2")
+	assert.NoError(t, err)
+	_, ok := r.Next(time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), 1)
+	assert.True(t, ok)
+	_, ok = r.Next(time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), 2)
+	assert.False(t, ok)
+
+	r, err = ParseRecurrence("FREQ=WEEKLY;UNTIL=20261025")
+	assert.NoError(t, err)
+	_, ok = r.Next(time.Date(2026, 10, 18, 23, 0, 0, 0, time.UTC), 1)
+	assert.True(t, ok, "UNTIL includes the whole day")
+	_, ok = r.Next(time.Date(2026, 10, 25, 8, 0, 0, 0, time.UTC), 2)
+	assert.False(t, ok)
+}
@@ -1,27 +1,175 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // Task represents a task in the application's core domain.
 // This struct is independent of database or gRPC specific details.
 type Task struct {
-	ID          string
-	Title       string
-	Description string
-	Completed   bool
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID           string
+	Title        string
+	Description  string
+	Completed    bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    *time.Time // nil unless the task is in the trash
+	DueAt        *time.Time // nil when the task has no due date
+	AllDay       bool       // DueAt is a date, stored as midnight UTC
+	Priority     Priority
+	Labels       []string // label names, sorted
+	ProjectID    string   // empty when the task is in no project
+	ParentID     string   // empty for top-level tasks
+	AutoComplete bool     // completed automatically once all its subtasks are completed
+	Children     []*Task  // subtasks, only filled in when a task tree is loaded
+	Recurrence   string   // canonical recurrence rule, empty for one-off tasks
+	SeriesID     string   // ID of the first occurrence of a recurring task's series
+	OwnerID      string   // ID of the user the task belongs to
+}
+
+// MaxTaskDepth is the number of levels tasks can nest, counting the
+// top-level task as the first level.
+const MaxTaskDepth = 5
+
+// Priority ranks tasks. Higher values are more urgent.
+type Priority int
+
+const (
+	PriorityNone Priority = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+	PriorityUrgent
+)
+
+// Valid reports whether p is one of the defined priorities.
+func (p Priority) Valid() bool {
+	return p >= PriorityNone && p <= PriorityUrgent
+}
+
+// TaskStatsOptions selects the tasks counted by task statistics.
+type TaskStatsOptions struct {
+	ProjectID  string
+	LeavesOnly bool // count only tasks without subtasks
 }
 
 type TaskStats struct {
 	Total     int32
 	Completed int32
 	Pending   int32
+	Overdue   int32
+	DueToday  int32
+}
+
+// Totals counts the tasks and users of the whole service, for its metrics.
+// OpenTasks and CompletedTasks leave out the trash.
+type Totals struct {
+	OpenTasks      int64
+	CompletedTasks int64
+	TrashedTasks   int64
+	Users          int64
 }
 
+// IsDeleted reports whether the task has been moved to the trash.
+func (t *Task) IsDeleted() bool {
+	return t.DeletedAt != nil
+}
+
+// MarkComplete marks the task as completed. It is a no-op for completed tasks.
 func (t *Task) MarkComplete() {
 	if !t.Completed {
 		t.Completed = true
 		t.UpdatedAt = time.Now()
 	}
 }
+
+// Reopen marks the task as not completed. It is a no-op for open tasks.
+func (t *Task) Reopen() {
+	if t.Completed {
+		t.Completed = false
+		t.UpdatedAt = time.Now()
+	}
+}
+
+// ValidateDue checks the due date fields and truncates the due date of
+// all-day tasks to midnight UTC.
+func (t *Task) ValidateDue() error {
+	if t.DueAt == nil {
+		if t.AllDay {
+			return fmt.Errorf("all-day tasks need a due date: %w", ErrInvalidInput)
+		}
+		return nil
+	}
+	if t.AllDay {
+		date := DateOf(t.DueAt.UTC())
+		t.DueAt = &date
+	}
+	return nil
+}
+
+// IsOverdue reports whether the task is open and past its due date at now.
+// All-day tasks become overdue on the day after their due date.
+func (t *Task) IsOverdue(now time.Time) bool {
+	if t.Completed || t.DueAt == nil {
+		return false
+	}
+	if t.AllDay {
+		return t.DueAt.Before(DateOf(now))
+	}
+	return t.DueAt.Before(now)
+}
+
+// DateOf returns the calendar date of t, in t's location, as midnight UTC.
+// This is how all-day due dates are represented.
+func DateOf(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// TaskListOptions filters, sorts and paginates a task listing.
+// Nil or zero fields do not filter.
+type TaskListOptions struct {
+	PageSize      int
+	PageToken     string
+	Completed     *bool
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	UpdatedAfter  *time.Time
+	UpdatedBefore *time.Time
+	Overdue       *bool
+	DueAfter      *time.Time
+	DueBefore     *time.Time
+	Labels        []string // tasks must carry every label
+	ProjectID     string
+	OrderBy       string
+}
+
+// TaskPage is one page of a task listing.
+type TaskPage struct {
+	Tasks         []*Task
+	NextPageToken string
+	TotalSize     int32
+}
+
+// TaskSearchOptions describes a full-text search over tasks.
+type TaskSearchOptions struct {
+	Query     string
+	PageSize  int
+	PageToken string
+}
+
+// TaskSearchResult is a task matching a full-text search.
+type TaskSearchResult struct {
+	Task               *Task
+	Score              float64 // bm25, lower is more relevant
+	TitleHighlight     string
+	DescriptionSnippet string
+}
+
+// TaskSearchPage is one page of search results.
+type TaskSearchPage struct {
+	Results       []*TaskSearchResult
+	NextPageToken string
+	TotalSize     int32
+}
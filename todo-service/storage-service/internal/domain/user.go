@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// User is an account that owns tasks, labels and projects.
+type User struct {
+	ID           string
+	Email        string // unique, ignoring case
+	PasswordHash string
+	CreatedAt    time.Time
+}
+
+// NormalizeEmail trims an email address and checks that it is a plain address.
+func NormalizeEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return "", fmt.Errorf("email must be a valid address: %w", ErrInvalidInput)
+	}
+	return email, nil
+}
@@ -0,0 +1,196 @@
+// Package events distributes task change events to watchers in the same
+// process.
+package events
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+)
+
+// Type is the kind of change an event describes.
+type Type int
+
+const (
+	TypeCreated Type = iota + 1
+	TypeUpdated
+	TypeCompleted
+	TypeDeleted
+)
+
+// Event is one change to a task.
+type Event struct {
+	Sequence uint64
+	Type     Type
+	Task     *domain.Task // the task after the change
+	Time     time.Time
+}
+
+// ErrSequenceExpired is returned by Subscribe when the events after the
+// requested sequence are no longer buffered. The subscriber has to reload
+// its tasks and watch again from the latest sequence.
+var ErrSequenceExpired = errors.New("events after the requested sequence are no longer available")
+
+// ErrClosed is returned by Subscribe once the broker is closed.
+var ErrClosed = errors.New("event broker is closed")
+
+// DefaultBacklog is the number of recent events a broker keeps for resuming subscribers.
+const DefaultBacklog = 1000
+
+// subscriberBuffer is the number of events a subscriber may fall behind by
+// before it is disconnected.
+const subscriberBuffer = 256
+
+// Broker fans out published events to subscribers and keeps the most recent
+// ones so that subscribers can resume after a reconnect.
+//
+// Sequence numbers start from the broker's creation time in microseconds,
+// so they keep increasing across restarts and a sequence from before a
+// restart is reported as expired rather than silently reused.
+type Broker struct {
+	mu      sync.Mutex
+	backlog []Event // ring buffer of the last len(backlog) events
+	start   int     // index of the oldest event in backlog
+	size    int     // number of buffered events
+	last    uint64  // sequence of the latest event
+	subs    map[*Subscription]struct{}
+	closed  bool
+	logger  *slog.Logger
+}
+
+// NewBroker creates a Broker that keeps the last backlog events.
+func NewBroker(backlog int, logger *slog.Logger) *Broker {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if backlog <= 0 {
+		backlog = DefaultBacklog
+	}
+	return &Broker{
+		backlog: make([]Event, backlog),
+		last:    uint64(time.Now().UnixMicro()),
+		subs:    make(map[*Subscription]struct{}),
+		logger:  logger,
+	}
+}
+
+// Publish assigns the next sequence number to an event of type typ for task
+// and delivers it to all subscribers. Subscribers that have fallen too far
+// behind are disconnected. Publish never blocks on subscribers.
+func (b *Broker) Publish(typ Type, task *domain.Task) {
+	snapshot := *task
+	snapshot.Children = nil
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+
+	b.last++
+	event := Event{Sequence: b.last, Type: typ, Task: &snapshot, Time: time.Now()}
+	b.backlog[(b.start+b.size)%len(b.backlog)] = event
+	if b.size < len(b.backlog) {
+		b.size++
+	} else {
+		b.start = (b.start + 1) % len(b.backlog)
+	}
+
+	for sub := range b.subs {
+		select {
+		case sub.ch <- event:
+		default:
+			b.logger.Warn("Disconnecting lagging event subscriber", "sequence", event.Sequence)
+			sub.lagged = true
+			b.remove(sub)
+		}
+	}
+}
+
+// Subscribe registers a subscriber for the events published after the event
+// with sequence after, returning the buffered ones that it missed. An after
+// of 0 subscribes to new events only.
+func (b *Broker) Subscribe(after uint64) (*Subscription, []Event, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, nil, ErrClosed
+	}
+
+	var missed []Event
+	if after != 0 {
+		oldest := b.last + 1
+		if b.size > 0 {
+			oldest = b.backlog[b.start].Sequence
+		}
+		if after < oldest-1 || after > b.last {
+			return nil, nil, ErrSequenceExpired
+		}
+		for i := 0; i < b.size; i++ {
+			event := b.backlog[(b.start+i)%len(b.backlog)]
+			if event.Sequence > after {
+				missed = append(missed, event)
+			}
+		}
+	}
+
+	sub := &Subscription{ch: make(chan Event, subscriberBuffer), broker: b}
+	b.subs[sub] = struct{}{}
+	return sub, missed, nil
+}
+
+// LastSequence returns the sequence of the latest event.
+func (b *Broker) LastSequence() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.last
+}
+
+// Close disconnects all subscribers. Events published afterwards are dropped.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for sub := range b.subs {
+		b.remove(sub)
+	}
+}
+
+// remove unregisters sub and closes its channel. b.mu must be held.
+func (b *Broker) remove(sub *Subscription) {
+	if _, ok := b.subs[sub]; ok {
+		delete(b.subs, sub)
+		close(sub.ch)
+	}
+}
+
+// Subscription receives the events published after it was created.
+type Subscription struct {
+	ch     chan Event
+	lagged bool
+	broker *Broker
+}
+
+// Events returns the channel events are delivered on. It is closed when the
+// subscription is closed, the broker is closed or the subscriber lagged.
+func (s *Subscription) Events() <-chan Event {
+	return s.ch
+}
+
+// Lagged reports whether the subscription was disconnected because it fell
+// behind. It is only meaningful after the events channel is closed.
+func (s *Subscription) Lagged() bool {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	return s.lagged
+}
+
+// Close unregisters the subscription.
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.remove(s)
+}
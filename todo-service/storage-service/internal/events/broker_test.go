@@ -0,0 +1,96 @@
+package events
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBroker(backlog int) *Broker {
+	return NewBroker(backlog, slog.New(slog.DiscardHandler))
+}
+
+func TestBroker_DeliversLiveEvents(t *testing.T) {
+	b := newTestBroker(10)
+	sub, missed, err := b.Subscribe(0)
+	require.NoError(t, err)
+	defer sub.Close()
+	assert.Empty(t, missed)
+
+	task := &domain.Task{ID: "task-1", Title: "Bins"}
+	b.Publish(TypeCreated, task)
+	task.Title = "changed after publishing"
+
+	event := <-sub.Events()
+	assert.Equal(t, TypeCreated, event.Type)
+	assert.Equal(t, "Bins", event.Task.Title, "events carry a snapshot")
+	assert.Equal(t, b.LastSequence(), event.Sequence)
+}
+
+func TestBroker_ResumesFromSequence(t *testing.T) {
+	b := newTestBroker(10)
+	b.Publish(TypeCreated, &domain.Task{ID: "task-1"})
+	seen := b.LastSequence()
+	b.Publish(TypeUpdated, &domain.Task{ID: "task-1"})
+	b.Publish(TypeDeleted, &domain.Task{ID: "task-1"})
+
+	sub, missed, err := b.Subscribe(seen)
+	require.NoError(t, err)
+	defer sub.Close()
+
+	require.Len(t, missed, 2)
+	assert.Equal(t, seen+1, missed[0].Sequence)
+	assert.Equal(t, TypeDeleted, missed[1].Type)
+}
+
+func TestBroker_ExpiredSequence(t *testing.T) {
+	b := newTestBroker(2)
+	b.Publish(TypeCreated, &domain.Task{ID: "task-1"})
+	seen := b.LastSequence()
+	for i := 0; i < 3; i++ {
+		b.Publish(TypeUpdated, &domain.Task{ID: "task-1"})
+	}
+
+	_, _, err := b.Subscribe(seen)
+	assert.ErrorIs(t, err, ErrSequenceExpired)
+
+	// A new broker, as after a restart, starts past old sequences.
+	_, _, err = newTestBroker(2).Subscribe(1)
+	assert.ErrorIs(t, err, ErrSequenceExpired)
+}
+
+func TestBroker_DisconnectsLaggingSubscriber(t *testing.T) {
+	b := newTestBroker(10)
+	sub, _, err := b.Subscribe(0)
+	require.NoError(t, err)
+
+	for i := 0; i <= subscriberBuffer; i++ {
+		b.Publish(TypeUpdated, &domain.Task{ID: "task-1"})
+	}
+
+	received := 0
+	for range sub.Events() {
+		received++
+	}
+	assert.Equal(t, subscriberBuffer, received)
+	assert.True(t, sub.Lagged())
+}
+
+func TestBroker_Close(t *testing.T) {
+	b := newTestBroker(10)
+	sub, _, err := b.Subscribe(0)
+	require.NoError(t, err)
+
+	b.Close()
+
+	_, ok := <-sub.Events()
+	assert.False(t, ok)
+	assert.False(t, sub.Lagged())
+	sub.Close() // closing twice is harmless
+
+	_, _, err = b.Subscribe(0)
+	assert.ErrorIs(t, err, ErrClosed)
+}
@@ -0,0 +1,108 @@
+// Package grpcerr builds the gRPC status errors of the storage service with
+// google.rpc error details attached: an ErrorInfo with a reason code on
+// every error, BadRequest field violations on invalid arguments and a
+// ResourceInfo naming a missing or conflicting resource. The API gateway
+// renders them as problem details, so clients need not parse messages.
+package grpcerr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	pb "github.com/sahidhossen/todo/proto/task_service"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// Domain is the ErrorInfo domain of the storage service's errors.
+const Domain = "storage-service.todo"
+
+// Reason codes of the ErrorInfo details. NotFound and AlreadyExists build
+// theirs from the resource type, such as TASK_NOT_FOUND.
+const (
+	ReasonInvalidArgument    = "INVALID_ARGUMENT"
+	ReasonProjectArchived    = "PROJECT_ARCHIVED"
+	ReasonInvalidCredentials = "INVALID_CREDENTIALS"
+	ReasonInvalidApiToken    = "INVALID_API_TOKEN"
+)
+
+// New returns a status error with code and the formatted message, carrying
+// an ErrorInfo with reason followed by details.
+func New(code codes.Code, reason string, details []protoadapt.MessageV1, format string, args ...any) error {
+	st := status.Newf(code, format, args...)
+	info := &errdetails.ErrorInfo{Reason: reason, Domain: Domain}
+	withDetails, err := st.WithDetails(append([]protoadapt.MessageV1{info}, details...)...)
+	if err != nil {
+		// Only possible for code OK, which is no error to describe.
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// InvalidArgument returns an InvalidArgument error about the request as a
+// whole, not naming a field.
+func InvalidArgument(format string, args ...any) error {
+	return New(codes.InvalidArgument, ReasonInvalidArgument, nil, format, args...)
+}
+
+// InvalidField returns an InvalidArgument error whose BadRequest details name
+// the invalid field by its path in the request message, such as "title" or
+// "task.due_at". The message is also the violation's description.
+func InvalidField(field string, format string, args ...any) error {
+	description := fmt.Sprintf(format, args...)
+	violation := &errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+		{Field: field, Description: description},
+	}}
+	return New(codes.InvalidArgument, ReasonInvalidArgument, []protoadapt.MessageV1{violation}, "%s", description)
+}
+
+// Invalid returns the InvalidArgument error for err, the failed Validate
+// method of a request, naming the field when err is a pb.FieldError.
+func Invalid(err error) error {
+	var fieldErr *pb.FieldError
+	if errors.As(err, &fieldErr) {
+		return InvalidField(fieldErr.Field, "%v", err)
+	}
+	return InvalidArgument("%v", err)
+}
+
+// NotFound returns a NotFound error for the resource of resourceType named
+// name, with reason RESOURCETYPE_NOT_FOUND.
+func NotFound(resourceType, name string, format string, args ...any) error {
+	return New(codes.NotFound, reason(resourceType, "NOT_FOUND"), ResourceInfo(resourceType, name), format, args...)
+}
+
+// AlreadyExists returns an AlreadyExists error for a resource of
+// resourceType conflicting with the one named name, with reason
+// RESOURCETYPE_ALREADY_EXISTS.
+func AlreadyExists(resourceType, name string, format string, args ...any) error {
+	return New(codes.AlreadyExists, reason(resourceType, "ALREADY_EXISTS"), ResourceInfo(resourceType, name), format, args...)
+}
+
+// ResourceInfo returns the details naming a resource of resourceType, such
+// as "task" or "api_token", for New.
+func ResourceInfo(resourceType, name string) []protoadapt.MessageV1 {
+	return []protoadapt.MessageV1{&errdetails.ResourceInfo{ResourceType: resourceType, ResourceName: name}}
+}
+
+// reason returns the reason code for what happened to a resource of
+// resourceType, such as API_TOKEN_NOT_FOUND.
+func reason(resourceType, what string) string {
+	return strings.ToUpper(resourceType) + "_" + what
+}
+
+// Detail returns the first detail of type T, such as *errdetails.ErrorInfo,
+// attached to the status error err, wherever it is among the details.
+func Detail[T any](err error) (T, bool) {
+	for _, detail := range status.Convert(err).Details() {
+		if d, ok := detail.(T); ok {
+			return d, true
+		}
+	}
+	var zero T
+	return zero, false
+}
@@ -0,0 +1,71 @@
+package grpcerr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sahidhossen/todo/proto/task_service"
+)
+
+func TestInvalidField(t *testing.T) {
+	err := InvalidField("task.title", "title cannot be %s", "empty")
+	st := status.Convert(err)
+
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "title cannot be empty", st.Message())
+	info, ok := Detail[*errdetails.ErrorInfo](err)
+	require.True(t, ok, "ErrorInfo detail")
+	assert.Equal(t, ReasonInvalidArgument, info.Reason)
+	assert.Equal(t, Domain, info.Domain)
+	badRequest, ok := Detail[*errdetails.BadRequest](err)
+	require.True(t, ok, "BadRequest detail")
+	require.Len(t, badRequest.FieldViolations, 1)
+	assert.Equal(t, "task.title", badRequest.FieldViolations[0].Field)
+	assert.Equal(t, "title cannot be empty", badRequest.FieldViolations[0].Description)
+}
+
+func TestNotFound(t *testing.T) {
+	err := NotFound("api_token", "tok-1", "API token with ID %s not found", "tok-1")
+	st := status.Convert(err)
+
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Equal(t, "API token with ID tok-1 not found", st.Message())
+	info, ok := Detail[*errdetails.ErrorInfo](err)
+	require.True(t, ok, "ErrorInfo detail")
+	assert.Equal(t, "API_TOKEN_NOT_FOUND", info.Reason)
+	resource, ok := Detail[*errdetails.ResourceInfo](err)
+	require.True(t, ok, "ResourceInfo detail")
+	assert.Equal(t, "api_token", resource.ResourceType)
+	assert.Equal(t, "tok-1", resource.ResourceName)
+}
+
+func TestInvalidArgument(t *testing.T) {
+	err := InvalidArgument("invalid page token")
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	info, ok := Detail[*errdetails.ErrorInfo](err)
+	require.True(t, ok, "ErrorInfo detail")
+	assert.Equal(t, ReasonInvalidArgument, info.Reason)
+	_, ok = Detail[*errdetails.BadRequest](err)
+	assert.False(t, ok, "no field is named")
+}
+
+func TestInvalid(t *testing.T) {
+	err := Invalid((&pb.GetTaskRequest{}).Validate())
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "task ID cannot be empty", status.Convert(err).Message())
+	badRequest, ok := Detail[*errdetails.BadRequest](err)
+	require.True(t, ok, "BadRequest detail")
+	assert.Equal(t, "id", badRequest.FieldViolations[0].Field)
+}
+
+func TestDetail_NotAStatus(t *testing.T) {
+	_, ok := Detail[*errdetails.ErrorInfo](nil)
+	assert.False(t, ok)
+}
@@ -0,0 +1,110 @@
+// Package healthcheck reports the health of the storage service over the
+// standard grpc.health.v1 service, from periodic database pings.
+package healthcheck
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	pb "github.com/sahidhossen/todo/proto/task_service"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Pinger is the database being checked, such as a *sql.DB.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// DefaultInterval is the time between database pings when New is given none.
+const DefaultInterval = 5 * time.Second
+
+// services are the names whose status is reported: the server as a whole
+// and TaskService.
+var services = []string{"", pb.TaskService_ServiceDesc.ServiceName}
+
+// Checker pings the database every interval and reports the services
+// SERVING while the pings succeed and NOT_SERVING while they fail.
+type Checker struct {
+	db       Pinger
+	server   *health.Server
+	interval time.Duration
+	timeout  time.Duration
+	logger   *slog.Logger
+	status   healthpb.HealthCheckResponse_ServingStatus // of the last check, logged when it changes
+}
+
+// New creates a Checker pinging db every interval, or DefaultInterval when
+// it is not positive. The services are NOT_SERVING until the first ping
+// succeeds.
+func New(db Pinger, interval time.Duration, logger *slog.Logger) *Checker {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	c := &Checker{
+		db:       db,
+		server:   health.NewServer(),
+		interval: interval,
+		timeout:  interval,
+		logger:   logger,
+	}
+	for _, service := range services {
+		c.server.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	return c
+}
+
+// Server returns the health service to register with the gRPC server.
+func (c *Checker) Server() healthpb.HealthServer {
+	return c.server
+}
+
+// Run checks the database once immediately and then every interval until
+// ctx is cancelled.
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.CheckOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckOnce pings the database and updates the serving status.
+func (c *Checker) CheckOnce(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	err := c.db.PingContext(ctx)
+
+	status := healthpb.HealthCheckResponse_SERVING
+	if err != nil {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	if status != c.status {
+		if err != nil {
+			c.logger.Error("Health: Database ping failed, not serving", "error", err)
+		} else {
+			c.logger.Info("Health: Database reachable, serving")
+		}
+		c.status = status
+	}
+	for _, service := range services {
+		c.server.SetServingStatus(service, status)
+	}
+}
+
+// Shutdown reports every service NOT_SERVING for good, so that clients stop
+// sending calls while the server drains. Later checks change nothing.
+func (c *Checker) Shutdown() {
+	c.server.Shutdown()
+}
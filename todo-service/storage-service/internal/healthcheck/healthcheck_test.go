@@ -0,0 +1,50 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	pb "github.com/sahidhossen/todo/proto/task_service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type fakeDB struct {
+	err error
+}
+
+func (db *fakeDB) PingContext(ctx context.Context) error {
+	return db.err
+}
+
+func status(t *testing.T, c *Checker, service string) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	resp, err := c.Server().Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	require.NoError(t, err)
+	return resp.Status
+}
+
+func TestChecker(t *testing.T) {
+	db := &fakeDB{}
+	c := New(db, 0, slog.New(slog.DiscardHandler))
+	service := pb.TaskService_ServiceDesc.ServiceName
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, status(t, c, service), "not serving before the first check")
+
+	c.CheckOnce(context.Background())
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, status(t, c, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, status(t, c, service))
+
+	db.err = errors.New("disk I/O error")
+	c.CheckOnce(context.Background())
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, status(t, c, service), "failed pings flip the status")
+
+	db.err = nil
+	c.CheckOnce(context.Background())
+	c.Shutdown()
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, status(t, c, service), "not serving once shutting down")
+	c.CheckOnce(context.Background())
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, status(t, c, service), "checks after shutdown change nothing")
+}
@@ -0,0 +1,85 @@
+// Package interceptors holds the gRPC server interceptors every storage
+// service call passes through.
+package interceptors
+
+import (
+	"log/slog"
+
+	"github.com/sahidhossen/todo/storage-service/internal/auth"
+	"github.com/sahidhossen/todo/storage-service/internal/metrics"
+	"github.com/sahidhossen/todo/storage-service/internal/requestid"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+)
+
+// Config selects the interceptors ServerOptions installs.
+type Config struct {
+	// Logging logs every call with its method, status code and duration.
+	Logging bool
+	// Recovery turns panics in handlers into Internal errors instead of
+	// crashing the server.
+	Recovery bool
+	// Auth puts the caller's user ID from the request metadata into the
+	// context, see auth.PublicMethods. TaskService calls that act for a user
+	// fail without it.
+	Auth bool
+	// AuthSecret is the shared secret callers without a verified TLS client
+	// certificate must send to have their user ID metadata believed.
+	AuthSecret string
+	// Validation refuses requests whose Validate method fails with
+	// InvalidArgument before they reach the handler, and stream messages
+	// whose Validate method fails. Unary handlers validate their requests
+	// either way.
+	Validation bool
+	// Metrics, when set, counts calls and their durations by method and
+	// status code.
+	Metrics *metrics.Metrics
+	// Tracing runs every call in an OpenTelemetry server span, continuing
+	// the trace in the caller's traceparent metadata.
+	Tracing bool
+}
+
+// ServerOptions returns the options installing the unary and stream
+// interceptors cfg selects. The span, when tracing, is started before any
+// interceptor runs, and the request ID from the metadata is always put into
+// the context first, so that every log line carries it. Calls are then
+// measured and logged, so metrics and logs also show recovered panics and
+// refused requests; then panics are recovered, the caller authenticated and
+// the request validated.
+func ServerOptions(cfg Config, logger *slog.Logger) []grpc.ServerOption {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	unary := []grpc.UnaryServerInterceptor{requestid.UnaryServerInterceptor()}
+	stream := []grpc.StreamServerInterceptor{requestid.StreamServerInterceptor()}
+	if cfg.Metrics != nil {
+		unary = append(unary, cfg.Metrics.UnaryServerInterceptor())
+		stream = append(stream, cfg.Metrics.StreamServerInterceptor())
+	}
+	if cfg.Logging {
+		unary = append(unary, UnaryLogging(logger))
+		stream = append(stream, StreamLogging(logger))
+	}
+	if cfg.Recovery {
+		unary = append(unary, UnaryRecovery(logger))
+		stream = append(stream, StreamRecovery(logger))
+	}
+	if cfg.Auth {
+		unary = append(unary, auth.UnaryServerInterceptor(cfg.AuthSecret))
+		stream = append(stream, auth.StreamServerInterceptor(cfg.AuthSecret))
+	}
+	if cfg.Validation {
+		unary = append(unary, UnaryValidation(logger))
+		stream = append(stream, StreamValidation(logger))
+	}
+
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+	if cfg.Tracing {
+		opts = append(opts, grpc.StatsHandler(otelgrpc.NewServerHandler()))
+	}
+	return opts
+}
@@ -0,0 +1,174 @@
+package interceptors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"testing"
+
+	"github.com/sahidhossen/todo/proto/ctxlog"
+	pb "github.com/sahidhossen/todo/proto/task_service"
+	"github.com/sahidhossen/todo/storage-service/internal/auth"
+	"github.com/sahidhossen/todo/storage-service/internal/grpcerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// panickyServer panics in GetTask and answers ListTasks with no tasks.
+type panickyServer struct {
+	pb.UnimplementedTaskServiceServer
+}
+
+func (panickyServer) GetTask(ctx context.Context, req *pb.GetTaskRequest) (*pb.GetTaskResponse, error) {
+	panic("boom")
+}
+
+func (panickyServer) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
+	return &pb.ListTasksResponse{}, nil
+}
+
+// startServer serves panickyServer with the interceptors cfg selects and
+// returns a client for it, dialed with opts.
+func startServer(t *testing.T, cfg Config, logger *slog.Logger, opts ...grpc.DialOption) pb.TaskServiceClient {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer(ServerOptions(cfg, logger)...)
+	pb.RegisterTaskServiceServer(server, panickyServer{})
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient(lis.Addr().String(), opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewTaskServiceClient(conn)
+}
+
+func userContext() context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), auth.UserIDMetadataKey, "user-1", auth.SecretMetadataKey, "secret")
+}
+
+func TestServerOptions(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+	client := startServer(t, Config{Logging: true, Recovery: true, Auth: true, AuthSecret: "secret", Validation: true}, logger)
+
+	_, err := client.GetTask(userContext(), &pb.GetTaskRequest{Id: "task-1"})
+	assert.Equal(t, codes.Internal, status.Code(err), "panics are recovered")
+
+	_, err = client.GetTask(userContext(), &pb.GetTaskRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "requests are validated")
+
+	_, err = client.ListTasks(context.Background(), &pb.ListTasksRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "callers are authenticated")
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), auth.UserIDMetadataKey, "user-1")
+	_, err = client.ListTasks(ctx, &pb.ListTasksRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "user IDs need the gateway secret")
+
+	_, err = client.ListTasks(userContext(), &pb.ListTasksRequest{})
+	assert.NoError(t, err)
+
+	assert.Contains(t, logs.String(), "Recovered from panic")
+	assert.Contains(t, logs.String(), `"code":"Internal"`)
+	assert.Contains(t, logs.String(), `"code":"OK"`)
+}
+
+func TestServerOptions_Disabled(t *testing.T) {
+	client := startServer(t, Config{Recovery: true}, nil)
+
+	_, err := client.ListTasks(context.Background(), &pb.ListTasksRequest{PageSize: -1})
+	assert.NoError(t, err, "neither authentication nor validation is installed")
+}
+
+func TestServerOptions_Tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	var logs bytes.Buffer
+	logger := slog.New(ctxlog.NewHandler(slog.NewJSONHandler(&logs, nil)))
+	client := startServer(t, Config{Logging: true, Tracing: true}, logger, grpc.WithStatsHandler(otelgrpc.NewClientHandler()))
+
+	ctx, span := otel.Tracer("test").Start(context.Background(), "request")
+	_, err := client.ListTasks(ctx, &pb.ListTasksRequest{})
+	require.NoError(t, err)
+	span.End()
+
+	var server sdktrace.ReadOnlySpan
+	for _, s := range recorder.Ended() {
+		if s.SpanKind() == trace.SpanKindServer {
+			server = s
+		}
+	}
+	require.NotNil(t, server, "calls run in a server span")
+	assert.Equal(t, "task_service.TaskService/ListTasks", server.Name())
+	assert.Equal(t, span.SpanContext().TraceID(), server.SpanContext().TraceID(), "the trace is propagated in the metadata")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+	assert.Equal(t, server.SpanContext().TraceID().String(), entry["trace_id"], "call logs carry the trace ID")
+}
+
+func TestUnaryLogging(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+	interceptor := UnaryLogging(logger)
+	info := &grpc.UnaryServerInfo{FullMethod: pb.TaskService_GetTask_FullMethodName}
+
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.NotFound, "no such task")
+	})
+	require.Error(t, err)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+	assert.Equal(t, "WARN", entry["level"])
+	assert.Equal(t, pb.TaskService_GetTask_FullMethodName, entry["method"])
+	assert.Equal(t, "NotFound", entry["code"])
+	assert.Contains(t, entry, "duration")
+}
+
+// fakeStream is a grpc.ServerStream receiving RevokeApiTokenRequests
+// without an ID.
+type fakeStream struct {
+	grpc.ServerStream
+}
+
+func (s *fakeStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *fakeStream) RecvMsg(m any) error {
+	*m.(*pb.RevokeApiTokenRequest) = pb.RevokeApiTokenRequest{}
+	return nil
+}
+
+func TestStreamValidation(t *testing.T) {
+	interceptor := StreamValidation(slog.New(slog.DiscardHandler))
+	info := &grpc.StreamServerInfo{FullMethod: "/test/Stream"}
+
+	err := interceptor(nil, &fakeStream{}, info, func(srv any, ss grpc.ServerStream) error {
+		return ss.RecvMsg(&pb.RevokeApiTokenRequest{})
+	})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	badRequest, ok := grpcerr.Detail[*errdetails.BadRequest](err)
+	require.True(t, ok, "BadRequest detail")
+	require.Len(t, badRequest.FieldViolations, 1)
+	assert.Equal(t, "id", badRequest.FieldViolations[0].Field, "the invalid field is named")
+}
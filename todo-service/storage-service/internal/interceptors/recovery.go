@@ -0,0 +1,43 @@
+package interceptors
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryRecovery turns a panic in a unary handler into an Internal error.
+func UnaryRecovery(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				resp, err = nil, recovered(ctx, logger, info.FullMethod, p)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecovery turns a panic in a stream handler into an Internal error.
+// Panics in goroutines the handler starts are not caught.
+func StreamRecovery(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = recovered(ss.Context(), logger, info.FullMethod, p)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// recovered logs a recovered panic with its stack and returns the error sent
+// to the client, which does not reveal the panic.
+func recovered(ctx context.Context, logger *slog.Logger, method string, p any) error {
+	logger.ErrorContext(ctx, "gRPC: Recovered from panic", "method", method, "panic", p, "stack", string(debug.Stack()))
+	return status.Errorf(codes.Internal, "internal error")
+}
@@ -0,0 +1,62 @@
+package interceptors
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/sahidhossen/todo/storage-service/internal/grpcerr"
+	"google.golang.org/grpc"
+)
+
+// Validator is implemented by request messages that check their own shape,
+// see the Validate methods of the task_service package.
+type Validator interface {
+	Validate() error
+}
+
+// UnaryValidation refuses unary requests whose Validate method fails.
+func UnaryValidation(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := validate(ctx, logger, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamValidation refuses the messages clients send on a stream whose
+// Validate method fails.
+func StreamValidation(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatingStream{ServerStream: ss, logger: logger, method: info.FullMethod})
+	}
+}
+
+// validate returns an InvalidArgument error when req is a Validator and
+// fails validation, see grpcerr.Invalid.
+func validate(ctx context.Context, logger *slog.Logger, method string, req any) error {
+	v, ok := req.(Validator)
+	if !ok {
+		return nil
+	}
+	if err := v.Validate(); err != nil {
+		logger.WarnContext(ctx, "Invalid request", "method", method, "error", err)
+		return grpcerr.Invalid(err)
+	}
+	return nil
+}
+
+// validatingStream is a grpc.ServerStream validating the messages it
+// receives.
+type validatingStream struct {
+	grpc.ServerStream
+	logger *slog.Logger
+	method string
+}
+
+func (s *validatingStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return validate(s.Context(), s.logger, s.method, m)
+}
@@ -0,0 +1,72 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/sahidhossen/todo/storage-service/internal/store"
+)
+
+// TrashPurger periodically removes tasks that have been in the trash for
+// longer than the retention window.
+type TrashPurger struct {
+	store     store.Store
+	retention time.Duration
+	interval  time.Duration
+	logger    *slog.Logger
+	now       func() time.Time
+}
+
+// NewTrashPurger creates a new TrashPurger.
+func NewTrashPurger(store store.Store, retention, interval time.Duration, logger *slog.Logger) *TrashPurger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &TrashPurger{
+		store:     store,
+		retention: retention,
+		interval:  interval,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+// Run purges expired tasks once immediately and then every interval until ctx is cancelled.
+// It returns straight away when retention or interval is not positive.
+func (p *TrashPurger) Run(ctx context.Context) {
+	if p.retention <= 0 || p.interval <= 0 {
+		p.logger.Info("Trash purging disabled", "retention", p.retention, "interval", p.interval)
+		return
+	}
+
+	p.logger.Info("Trash purger started", "retention", p.retention, "interval", p.interval)
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.PurgeOnce(ctx); err != nil && ctx.Err() == nil {
+			p.logger.Error("Failed to purge trash", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			p.logger.Info("Trash purger stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeOnce removes every task deleted more than the retention window ago.
+func (p *TrashPurger) PurgeOnce(ctx context.Context) (int64, error) {
+	cutoff := p.now().Add(-p.retention)
+	purged, err := p.store.PurgeDeletedBefore(ctx, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	if purged > 0 {
+		p.logger.Info("Purged expired tasks from trash", "count", purged, "cutoff", cutoff)
+	}
+	return purged, nil
+}
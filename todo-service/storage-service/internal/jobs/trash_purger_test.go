@@ -0,0 +1,38 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/sahidhossen/todo/storage-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTrashPurger_PurgeOnceUsesRetentionCutoff(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	purger := NewTrashPurger(mockStore, 48*time.Hour, time.Hour, slog.New(slog.NewTextHandler(os.Stdout, nil)))
+
+	now := time.Date(2025, 7, 10, 12, 0, 0, 0, time.UTC)
+	purger.now = func() time.Time { return now }
+
+	mockStore.On("PurgeDeletedBefore", mock.Anything, now.Add(-48*time.Hour)).Return(int64(3), nil).Once()
+
+	purged, err := purger.PurgeOnce(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+	mockStore.AssertExpectations(t)
+}
+
+func TestTrashPurger_RunDisabled(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	purger := NewTrashPurger(mockStore, 0, time.Hour, slog.New(slog.NewTextHandler(os.Stdout, nil)))
+
+	purger.Run(context.Background()) // must return without touching the store
+
+	mockStore.AssertNotCalled(t, "PurgeDeletedBefore", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,165 @@
+// Package metrics collects the Prometheus metrics of the storage service:
+// gRPC calls, store queries, database connections and task totals.
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Metrics holds the collectors of the storage service in their own
+// registry.
+type Metrics struct {
+	registry *prometheus.Registry
+	logger   *slog.Logger
+
+	grpcHandled  *prometheus.CounterVec
+	grpcDuration *prometheus.HistogramVec
+	queryLatency *prometheus.HistogramVec
+	queryErrors  *prometheus.CounterVec
+}
+
+// New returns Metrics with the gRPC and store collectors and the Go runtime
+// and process collectors registered.
+func New(logger *slog.Logger) *Metrics {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		logger:   logger,
+		grpcHandled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_handled_total",
+			Help: "gRPC calls completed by the server, by method and status code.",
+		}, []string{"grpc_service", "grpc_method", "grpc_code"}),
+		grpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "grpc_server_handling_seconds",
+			Help:    "Time the server took to handle gRPC calls, by method.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"grpc_service", "grpc_method"}),
+		queryLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "store_query_duration_seconds",
+			Help:    "Time Store methods took, by method.",
+			Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+		}, []string{"method"}),
+		queryErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "store_query_errors_total",
+			Help: "Store method calls that returned an error, by method.",
+		}, []string{"method"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.grpcHandled, m.grpcDuration, m.queryLatency, m.queryErrors,
+	)
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// RegisterDB adds the connection pool statistics of db.
+func (m *Metrics) RegisterDB(db *sql.DB, name string) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// TotalsSource counts the tasks and users of the whole service.
+type TotalsSource interface {
+	Totals(ctx context.Context) (*domain.Totals, error)
+}
+
+// RegisterTotals adds the todo_tasks and todo_users gauges, counted by
+// source on every scrape.
+func (m *Metrics) RegisterTotals(source TotalsSource) {
+	m.registry.MustRegister(&totalsCollector{
+		source: source,
+		logger: m.logger,
+		tasks:  prometheus.NewDesc("todo_tasks", "Tasks of all users, by state: open, completed or trashed.", []string{"state"}, nil),
+		users:  prometheus.NewDesc("todo_users", "Registered users.", nil, nil),
+	})
+}
+
+// ObserveQuery records a Store method call; it implements store.QueryObserver.
+func (m *Metrics) ObserveQuery(method string, duration time.Duration, err error) {
+	m.queryLatency.WithLabelValues(method).Observe(duration.Seconds())
+	if err != nil {
+		m.queryErrors.WithLabelValues(method).Inc()
+	}
+}
+
+// UnaryServerInterceptor records unary calls.
+func (m *Metrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.observeCall(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor records streaming calls once they end.
+func (m *Metrics) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		m.observeCall(info.FullMethod, start, err)
+		return err
+	}
+}
+
+func (m *Metrics) observeCall(fullMethod string, start time.Time, err error) {
+	service, method := splitMethod(fullMethod)
+	m.grpcHandled.WithLabelValues(service, method, status.Code(err).String()).Inc()
+	m.grpcDuration.WithLabelValues(service, method).Observe(time.Since(start).Seconds())
+}
+
+// splitMethod splits a full method name, "/package.Service/Method", into
+// its service and method.
+func splitMethod(fullMethod string) (string, string) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return "unknown", fullMethod
+	}
+	return service, method
+}
+
+// totalsCollector reports the totals of a TotalsSource as gauges.
+type totalsCollector struct {
+	source TotalsSource
+	logger *slog.Logger
+	tasks  *prometheus.Desc
+	users  *prometheus.Desc
+}
+
+func (c *totalsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.tasks
+	ch <- c.users
+}
+
+func (c *totalsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	totals, err := c.source.Totals(ctx)
+	if err != nil {
+		c.logger.Warn("Failed to count totals for metrics", "error", err)
+		ch <- prometheus.NewInvalidMetric(c.tasks, err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.tasks, prometheus.GaugeValue, float64(totals.OpenTasks), "open")
+	ch <- prometheus.MustNewConstMetric(c.tasks, prometheus.GaugeValue, float64(totals.CompletedTasks), "completed")
+	ch <- prometheus.MustNewConstMetric(c.tasks, prometheus.GaugeValue, float64(totals.TrashedTasks), "trashed")
+	ch <- prometheus.MustNewConstMetric(c.users, prometheus.GaugeValue, float64(totals.Users))
+}
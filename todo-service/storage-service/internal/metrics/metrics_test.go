@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	pb "github.com/sahidhossen/todo/proto/task_service"
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	m := New(nil)
+	interceptor := m.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: pb.TaskService_GetTask_FullMethodName}
+
+	_, _ = interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return nil, nil
+	})
+	_, _ = interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.NotFound, "no such task")
+	})
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.grpcHandled.WithLabelValues("task_service.TaskService", "GetTask", "OK")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.grpcHandled.WithLabelValues("task_service.TaskService", "GetTask", "NotFound")))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.grpcDuration))
+}
+
+func TestObserveQuery(t *testing.T) {
+	m := New(nil)
+
+	m.ObserveQuery("GetTask", time.Millisecond, nil)
+	m.ObserveQuery("GetTask", time.Millisecond, domain.ErrNotFound)
+	m.ObserveQuery("ListTasks", time.Millisecond, nil)
+
+	assert.Equal(t, 2, testutil.CollectAndCount(m.queryLatency))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.queryErrors.WithLabelValues("GetTask")))
+}
+
+type fakeTotals struct {
+	totals *domain.Totals
+	err    error
+}
+
+func (f fakeTotals) Totals(ctx context.Context) (*domain.Totals, error) {
+	return f.totals, f.err
+}
+
+func TestRegisterTotals(t *testing.T) {
+	m := New(nil)
+	m.RegisterTotals(fakeTotals{totals: &domain.Totals{OpenTasks: 3, CompletedTasks: 2, TrashedTasks: 1, Users: 4}})
+
+	expected := `
+# HELP todo_tasks Tasks of all users, by state: open, completed or trashed.
+# TYPE todo_tasks gauge
+todo_tasks{state="completed"} 2
+todo_tasks{state="open"} 3
+todo_tasks{state="trashed"} 1
+# HELP todo_users Registered users.
+# TYPE todo_users gauge
+todo_users 4
+`
+	assert.NoError(t, testutil.GatherAndCompare(m.registry, strings.NewReader(expected), "todo_tasks", "todo_users"))
+}
+
+func TestRegisterTotals_Error(t *testing.T) {
+	m := New(nil)
+	m.RegisterTotals(fakeTotals{err: errors.New("database is locked")})
+
+	_, err := m.registry.Gather()
+	assert.Error(t, err)
+}
+
+func TestHandler(t *testing.T) {
+	m := New(nil)
+	m.ObserveQuery("GetTask", time.Millisecond, nil)
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	require.Equal(t, 200, rec.Code)
+	assert.Contains(t, rec.Body.String(), `store_query_duration_seconds_count{method="GetTask"} 1`)
+	assert.Contains(t, rec.Body.String(), "go_goroutines")
+}
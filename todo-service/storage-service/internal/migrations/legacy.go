@@ -0,0 +1,81 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// legacyVersion reports which of the embedded migrations a database created
+// by the schema setup that predates migrations already matches. That setup
+// created tasks, added deleted_at to tables that lacked it and built the
+// tasks_fts index, which are migrations 1 to 3. Running 0002 against such a
+// database would fail on the duplicate column, so those versions are recorded
+// instead of applied. A database without a tasks table is at version 0.
+func legacyVersion(ctx context.Context, db *sql.DB) (int, error) {
+	var tables int
+	if err := db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'tasks'`).Scan(&tables); err != nil {
+		return 0, fmt.Errorf("failed to inspect legacy schema: %w", err)
+	}
+	if tables == 0 {
+		return 0, nil
+	}
+
+	var deletedAt int
+	if err := db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM pragma_table_info('tasks') WHERE name = 'deleted_at'`).Scan(&deletedAt); err != nil {
+		return 0, fmt.Errorf("failed to inspect legacy schema: %w", err)
+	}
+	if deletedAt == 0 {
+		return 1, nil
+	}
+
+	// The index and its triggers were created together; a table without the
+	// triggers would never have been written by the old setup.
+	var search int
+	if err := db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM sqlite_master
+		WHERE (type = 'table' AND name = 'tasks_fts')
+			OR (type = 'trigger' AND name IN ('tasks_fts_ai', 'tasks_fts_ad', 'tasks_fts_au'))`).Scan(&search); err != nil {
+		return 0, fmt.Errorf("failed to inspect legacy schema: %w", err)
+	}
+	if search < 4 {
+		return 2, nil
+	}
+	return 3, nil
+}
+
+// baseline records the migrations an unversioned database already matches,
+// without running their scripts. It only acts when nothing has been recorded
+// yet, so a database is baselined at most once.
+func (m *Migrator) baseline(ctx context.Context, applied map[int]appliedMigration) error {
+	if m.detect == nil || len(applied) > 0 {
+		return nil
+	}
+	version, err := m.detect(ctx, m.db)
+	if err != nil || version == 0 {
+		return err
+	}
+
+	err = m.inTx(ctx, func(tx *sql.Tx) error {
+		for _, mig := range m.migrations {
+			if mig.Version > version {
+				break
+			}
+			if _, err := tx.ExecContext(ctx,
+				`INSERT INTO schema_migrations (version, name, checksum, applied_at) VALUES (?, ?, ?, ?)`,
+				mig.Version, mig.Name, mig.Checksum, time.Now()); err != nil {
+				return err
+			}
+			applied[mig.Version] = appliedMigration{checksum: mig.Checksum}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record existing schema: %w", err)
+	}
+	m.logger.Info("Recorded existing schema", "version", version)
+	return nil
+}
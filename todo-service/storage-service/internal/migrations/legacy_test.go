@@ -0,0 +1,82 @@
+//go:build sqlite_fts5
+
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// legacySchema is the schema the storage service created before migrations
+// existed, in the order it was introduced.
+var legacySchema = []string{
+	`CREATE TABLE IF NOT EXISTS tasks (
+		id TEXT PRIMARY KEY,
+		title TEXT NOT NULL,
+		description TEXT,
+		completed BOOLEAN NOT NULL DEFAULT FALSE,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`,
+	`ALTER TABLE tasks ADD COLUMN deleted_at DATETIME;
+	CREATE INDEX IF NOT EXISTS idx_tasks_deleted_at ON tasks (deleted_at);`,
+	`CREATE VIRTUAL TABLE IF NOT EXISTS tasks_fts USING fts5(
+		title,
+		description,
+		content='tasks',
+		content_rowid='rowid',
+		tokenize='unicode61 remove_diacritics 2'
+	);
+	CREATE TRIGGER IF NOT EXISTS tasks_fts_ai AFTER INSERT ON tasks BEGIN
+		INSERT INTO tasks_fts(rowid, title, description) VALUES (new.rowid, new.title, new.description);
+	END;
+	CREATE TRIGGER IF NOT EXISTS tasks_fts_ad AFTER DELETE ON tasks BEGIN
+		INSERT INTO tasks_fts(tasks_fts, rowid, title, description) VALUES ('delete', old.rowid, old.title, old.description);
+	END;
+	CREATE TRIGGER IF NOT EXISTS tasks_fts_au AFTER UPDATE OF title, description ON tasks BEGIN
+		INSERT INTO tasks_fts(tasks_fts, rowid, title, description) VALUES ('delete', old.rowid, old.title, old.description);
+		INSERT INTO tasks_fts(rowid, title, description) VALUES (new.rowid, new.title, new.description);
+	END;
+	INSERT INTO tasks_fts(tasks_fts) VALUES ('rebuild');`,
+}
+
+func TestMigrator_UpFromLegacySchema(t *testing.T) {
+	for stage := 1; stage <= len(legacySchema); stage++ {
+		ctx := context.Background()
+		database := newTestDB(t)
+		for _, stmt := range legacySchema[:stage] {
+			_, err := database.Exec(stmt)
+			require.NoError(t, err)
+		}
+		_, err := database.Exec(`INSERT INTO tasks (id, title, description) VALUES ('t1', 'Buy milk', 'semi-skimmed')`)
+		require.NoError(t, err)
+
+		m, err := New(database, slog.New(slog.DiscardHandler))
+		require.NoError(t, err)
+		version, err := legacyVersion(ctx, database)
+		require.NoError(t, err)
+		assert.Equal(t, stage, version)
+
+		require.NoError(t, m.Up(ctx), "legacy schema at version %d", stage)
+		statuses, err := m.Status(ctx)
+		require.NoError(t, err)
+		for _, st := range statuses {
+			assert.True(t, st.Applied, "migration %d", st.Version)
+			assert.False(t, st.Dirty, "migration %d", st.Version)
+		}
+
+		var id string
+		require.NoError(t, database.QueryRow(`SELECT t.id FROM tasks_fts JOIN tasks t ON t.seq = tasks_fts.rowid WHERE tasks_fts MATCH 'milk'`).Scan(&id))
+		assert.Equal(t, "t1", id)
+	}
+}
+
+func TestLegacyVersion_EmptyDatabase(t *testing.T) {
+	version, err := legacyVersion(context.Background(), newTestDB(t))
+	require.NoError(t, err)
+	assert.Equal(t, 0, version)
+}
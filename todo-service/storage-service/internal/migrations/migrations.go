@@ -0,0 +1,441 @@
+// Package migrations applies the versioned storage-service schema.
+//
+// Migrations are SQL scripts embedded from sql/ and named
+// NNNN_description.up.sql / NNNN_description.down.sql. Applied versions are
+// recorded in schema_migrations together with a checksum of the up script, so
+// editing a migration after it has shipped is detected instead of silently
+// diverging. Databases created before migrations existed are recognised and
+// the versions their schema already matches are recorded. A row in
+// schema_migrations_lock keeps two processes from migrating the same database
+// at once.
+package migrations
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed sql/*.sql
+var embedded embed.FS
+
+var (
+	// ErrChecksumMismatch is returned when an applied migration no longer matches its script.
+	ErrChecksumMismatch = errors.New("migration checksum mismatch")
+	// ErrUnknownVersion is returned when the database has a version this binary does not know.
+	ErrUnknownVersion = errors.New("unknown migration version")
+	// ErrLocked is returned when another process holds the migration lock for longer than LockTimeout.
+	ErrLocked = errors.New("migrations are locked by another process")
+)
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration is one versioned schema change.
+type Migration struct {
+	Version  int
+	Name     string
+	Up       string
+	Down     string
+	Checksum string // hex SHA-256 of Up
+}
+
+// Status describes a migration and whether it has been applied.
+type Status struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+	// Dirty is set when the applied checksum differs from the embedded script.
+	Dirty bool
+}
+
+// Migrator applies migrations to a database.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	logger     *slog.Logger
+	// detect reports the version an unversioned database already matches.
+	detect func(ctx context.Context, db *sql.DB) (int, error)
+
+	// LockTimeout bounds how long Up, Down and To wait for another run to finish.
+	LockTimeout time.Duration
+	// LockRetryInterval is the delay between attempts to take the lock.
+	LockRetryInterval time.Duration
+}
+
+// New creates a Migrator for the migrations embedded in the binary.
+func New(db *sql.DB, logger *slog.Logger) (*Migrator, error) {
+	sub, err := fs.Sub(embedded, "sql")
+	if err != nil {
+		return nil, err
+	}
+	m, err := NewFromFS(db, sub, logger)
+	if err != nil {
+		return nil, err
+	}
+	m.detect = legacyVersion
+	return m, nil
+}
+
+// NewFromFS creates a Migrator for the migration scripts at the root of fsys.
+func NewFromFS(db *sql.DB, fsys fs.FS, logger *slog.Logger) (*Migrator, error) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	migrations, err := load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		db:                db,
+		migrations:        migrations,
+		logger:            logger,
+		LockTimeout:       30 * time.Second,
+		LockRetryInterval: 250 * time.Millisecond,
+	}, nil
+}
+
+// load reads and orders the migration scripts in fsys.
+func load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q", entry.Name())
+		}
+		version, _ := strconv.Atoi(match[1])
+		if version <= 0 {
+			return nil, fmt.Errorf("invalid migration version in %q", entry.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, m.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			m.Up = string(content)
+			sum := sha256.Sum256(content)
+			m.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Latest returns the highest known migration version, or 0 when there are none.
+func (m *Migrator) Latest() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.To(ctx, m.Latest())
+}
+
+// Down rolls back the most recently applied migration.
+func (m *Migrator) Down(ctx context.Context) error {
+	return m.withLock(ctx, func() error {
+		applied, err := m.verify(ctx)
+		if err != nil {
+			return err
+		}
+		current := currentVersion(applied)
+		if current == 0 {
+			m.logger.Info("No migrations to roll back")
+			return nil
+		}
+		return m.rollback(ctx, m.find(current))
+	})
+}
+
+// To migrates up or down until version is the latest applied migration.
+// Version 0 rolls back every migration.
+func (m *Migrator) To(ctx context.Context, version int) error {
+	if version != 0 && m.find(version) == nil {
+		return fmt.Errorf("migration %d: %w", version, ErrUnknownVersion)
+	}
+
+	return m.withLock(ctx, func() error {
+		applied, err := m.verify(ctx)
+		if err != nil {
+			return err
+		}
+		if err := m.baseline(ctx, applied); err != nil {
+			return err
+		}
+
+		for _, mig := range m.migrations {
+			if mig.Version > version {
+				break
+			}
+			if _, ok := applied[mig.Version]; ok {
+				continue
+			}
+			if err := m.apply(ctx, &mig); err != nil {
+				return err
+			}
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			mig := &m.migrations[i]
+			if mig.Version <= version {
+				break
+			}
+			if _, ok := applied[mig.Version]; !ok {
+				continue
+			}
+			if err := m.rollback(ctx, mig); err != nil {
+				return err
+			}
+		}
+
+		m.logger.Info("Database schema is up to date", "version", version)
+		return nil
+	})
+}
+
+// Status reports every known migration and whether it has been applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	if err := m.ensureTables(ctx); err != nil {
+		return nil, err
+	}
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		st := Status{Version: mig.Version, Name: mig.Name}
+		if rec, ok := applied[mig.Version]; ok {
+			st.Applied = true
+			st.AppliedAt = rec.appliedAt
+			st.Dirty = rec.checksum != mig.Checksum
+		}
+		statuses = append(statuses, st)
+	}
+	return statuses, nil
+}
+
+// appliedMigration is a row of schema_migrations.
+type appliedMigration struct {
+	checksum  string
+	appliedAt time.Time
+}
+
+func (m *Migrator) ensureTables(ctx context.Context) error {
+	stmts := []string{
+		`CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			checksum TEXT NOT NULL,
+			applied_at DATETIME NOT NULL
+		);`,
+		`CREATE TABLE IF NOT EXISTS schema_migrations_lock (
+			id INTEGER PRIMARY KEY CHECK (id = 1),
+			owner TEXT NOT NULL,
+			locked_at DATETIME NOT NULL
+		);`,
+	}
+	for _, stmt := range stmts {
+		if _, err := m.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to create migration tables: %w", err)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[int]appliedMigration, error) {
+	rows, err := m.db.QueryContext(ctx, `SELECT version, checksum, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]appliedMigration)
+	for rows.Next() {
+		var version int
+		var rec appliedMigration
+		if err := rows.Scan(&version, &rec.checksum, &rec.appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan applied migration: %w", err)
+		}
+		applied[version] = rec
+	}
+	return applied, rows.Err()
+}
+
+// verify loads the applied migrations and checks them against the known scripts.
+func (m *Migrator) verify(ctx context.Context) (map[int]appliedMigration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for version, rec := range applied {
+		mig := m.find(version)
+		if mig == nil {
+			return nil, fmt.Errorf("database has migration %d applied: %w", version, ErrUnknownVersion)
+		}
+		if rec.checksum != mig.Checksum {
+			return nil, fmt.Errorf("migration %d_%s was modified after it was applied: %w", mig.Version, mig.Name, ErrChecksumMismatch)
+		}
+	}
+	return applied, nil
+}
+
+func (m *Migrator) find(version int) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+func currentVersion(applied map[int]appliedMigration) int {
+	current := 0
+	for version := range applied {
+		if version > current {
+			current = version
+		}
+	}
+	return current
+}
+
+// apply runs an up script and records it in one transaction.
+func (m *Migrator) apply(ctx context.Context, mig *Migration) error {
+	start := time.Now()
+	err := m.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, mig.Up); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO schema_migrations (version, name, checksum, applied_at) VALUES (?, ?, ?, ?)`,
+			mig.Version, mig.Name, mig.Checksum, time.Now())
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply migration %d_%s: %w", mig.Version, mig.Name, err)
+	}
+	m.logger.Info("Applied migration", "version", mig.Version, "name", mig.Name, "duration", time.Since(start))
+	return nil
+}
+
+// rollback runs a down script and forgets the migration in one transaction.
+func (m *Migrator) rollback(ctx context.Context, mig *Migration) error {
+	if mig.Down == "" {
+		return fmt.Errorf("migration %d_%s has no down script", mig.Version, mig.Name)
+	}
+	start := time.Now()
+	err := m.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, mig.Down); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = ?`, mig.Version)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to roll back migration %d_%s: %w", mig.Version, mig.Name, err)
+	}
+	m.logger.Info("Rolled back migration", "version", mig.Version, "name", mig.Name, "duration", time.Since(start))
+	return nil
+}
+
+func (m *Migrator) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// withLock runs fn while holding the migration lock.
+func (m *Migrator) withLock(ctx context.Context, fn func() error) error {
+	if err := m.ensureTables(ctx); err != nil {
+		return err
+	}
+
+	owner := lockOwner()
+	deadline := time.Now().Add(m.LockTimeout)
+	for {
+		_, err := m.db.ExecContext(ctx,
+			`INSERT INTO schema_migrations_lock (id, owner, locked_at) VALUES (1, ?, ?)`, owner, time.Now())
+		if err == nil {
+			break
+		}
+		if !strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return fmt.Errorf("failed to take migration lock: %w", err)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%w; delete the row in schema_migrations_lock if no migration is running", ErrLocked)
+		}
+		m.logger.Info("Waiting for migration lock")
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(m.LockRetryInterval):
+		}
+	}
+
+	defer func() {
+		// Release with a fresh context so a cancelled run does not leave the lock behind.
+		releaseCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := m.db.ExecContext(releaseCtx, `DELETE FROM schema_migrations_lock WHERE id = 1 AND owner = ?`, owner); err != nil {
+			m.logger.Error("Failed to release migration lock", "error", err)
+		}
+	}()
+
+	return fn()
+}
+
+// lockOwner identifies this process in schema_migrations_lock.
+func lockOwner() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s:%d:%d", host, os.Getpid(), time.Now().UnixNano())
+}
@@ -0,0 +1,147 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/sahidhossen/todo/storage-service/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"0001_create_items.up.sql":   {Data: []byte(`CREATE TABLE items (id TEXT PRIMARY KEY);`)},
+		"0001_create_items.down.sql": {Data: []byte(`DROP TABLE items;`)},
+		"0002_add_name.up.sql":       {Data: []byte(`ALTER TABLE items ADD COLUMN name TEXT;`)},
+		"0002_add_name.down.sql":     {Data: []byte(`ALTER TABLE items DROP COLUMN name;`)},
+	}
+}
+
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	database, err := db.NewConnection(filepath.Join(t.TempDir(), "test.db"), logger)
+	require.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+	return database
+}
+
+func newTestMigrator(t *testing.T, database *sql.DB, fsys fstest.MapFS) *Migrator {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	m, err := NewFromFS(database, fsys, logger)
+	require.NoError(t, err)
+	return m
+}
+
+func appliedVersions(t *testing.T, m *Migrator) []int {
+	t.Helper()
+	statuses, err := m.Status(context.Background())
+	require.NoError(t, err)
+	var versions []int
+	for _, st := range statuses {
+		if st.Applied {
+			versions = append(versions, st.Version)
+		}
+	}
+	return versions
+}
+
+func TestEmbeddedMigrationsLoad(t *testing.T) {
+	m, err := New(nil, nil)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, m.Latest(), 1)
+	for _, mig := range m.migrations {
+		assert.NotEmpty(t, mig.Down, "migration %d has no down script", mig.Version)
+	}
+}
+
+func TestMigrator_UpDownTo(t *testing.T) {
+	ctx := context.Background()
+	database := newTestDB(t)
+	m := newTestMigrator(t, database, testFS())
+
+	require.NoError(t, m.Up(ctx))
+	assert.Equal(t, []int{1, 2}, appliedVersions(t, m))
+	_, err := database.Exec(`INSERT INTO items (id, name) VALUES ('a', 'first')`)
+	require.NoError(t, err)
+
+	// Running again is a no-op
+	require.NoError(t, m.Up(ctx))
+
+	require.NoError(t, m.Down(ctx))
+	assert.Equal(t, []int{1}, appliedVersions(t, m))
+
+	require.NoError(t, m.To(ctx, 0))
+	assert.Empty(t, appliedVersions(t, m))
+	_, err = database.Exec(`SELECT 1 FROM items`)
+	assert.Error(t, err)
+
+	require.NoError(t, m.To(ctx, 2))
+	assert.Equal(t, []int{1, 2}, appliedVersions(t, m))
+
+	assert.ErrorIs(t, m.To(ctx, 7), ErrUnknownVersion)
+}
+
+func TestMigrator_DetectsModifiedMigration(t *testing.T) {
+	ctx := context.Background()
+	database := newTestDB(t)
+	require.NoError(t, newTestMigrator(t, database, testFS()).Up(ctx))
+
+	modified := testFS()
+	modified["0002_add_name.up.sql"] = &fstest.MapFile{Data: []byte(`ALTER TABLE items ADD COLUMN title TEXT;`)}
+	m := newTestMigrator(t, database, modified)
+
+	assert.ErrorIs(t, m.Up(ctx), ErrChecksumMismatch)
+
+	statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, statuses[1].Dirty)
+}
+
+func TestMigrator_RejectsUnknownAppliedVersion(t *testing.T) {
+	ctx := context.Background()
+	database := newTestDB(t)
+	require.NoError(t, newTestMigrator(t, database, testFS()).Up(ctx))
+
+	older := testFS()
+	delete(older, "0002_add_name.up.sql")
+	delete(older, "0002_add_name.down.sql")
+
+	assert.ErrorIs(t, newTestMigrator(t, database, older).Up(ctx), ErrUnknownVersion)
+}
+
+func TestMigrator_Lock(t *testing.T) {
+	ctx := context.Background()
+	database := newTestDB(t)
+	m := newTestMigrator(t, database, testFS())
+	m.LockTimeout = 50 * time.Millisecond
+	m.LockRetryInterval = 10 * time.Millisecond
+
+	require.NoError(t, m.ensureTables(ctx))
+	_, err := database.Exec(`INSERT INTO schema_migrations_lock (id, owner, locked_at) VALUES (1, 'other', ?)`, time.Now())
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, m.Up(ctx), ErrLocked)
+	assert.Empty(t, appliedVersions(t, m))
+
+	_, err = database.Exec(`DELETE FROM schema_migrations_lock`)
+	require.NoError(t, err)
+	require.NoError(t, m.Up(ctx))
+
+	var locks int
+	require.NoError(t, database.QueryRow(`SELECT COUNT(*) FROM schema_migrations_lock`).Scan(&locks))
+	assert.Zero(t, locks, "lock should be released after a run")
+}
+
+func TestLoad_InvalidFileName(t *testing.T) {
+	_, err := load(fstest.MapFS{"create_items.sql": {Data: []byte(`SELECT 1;`)}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_tasks_deleted_at;
+
+-- Trashed tasks cannot be represented without deleted_at.
+DELETE FROM tasks WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE tasks DROP COLUMN deleted_at;
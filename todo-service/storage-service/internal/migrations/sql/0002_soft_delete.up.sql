@@ -0,0 +1,3 @@
+ALTER TABLE tasks ADD COLUMN deleted_at DATETIME;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_deleted_at ON tasks (deleted_at);
@@ -0,0 +1,27 @@
+-- Full-text search index over task titles and descriptions. tasks_fts is an
+-- external-content table: it stores only the index and reads the text from
+-- tasks by rowid. The triggers keep the index in sync.
+-- Requires SQLite built with FTS5 (go build -tags sqlite_fts5).
+CREATE VIRTUAL TABLE tasks_fts USING fts5(
+	title,
+	description,
+	content='tasks',
+	content_rowid='rowid',
+	tokenize='unicode61 remove_diacritics 2'
+);
+
+CREATE TRIGGER tasks_fts_ai AFTER INSERT ON tasks BEGIN
+	INSERT INTO tasks_fts(rowid, title, description) VALUES (new.rowid, new.title, new.description);
+END;
+
+CREATE TRIGGER tasks_fts_ad AFTER DELETE ON tasks BEGIN
+	INSERT INTO tasks_fts(tasks_fts, rowid, title, description) VALUES ('delete', old.rowid, old.title, old.description);
+END;
+
+CREATE TRIGGER tasks_fts_au AFTER UPDATE OF title, description ON tasks BEGIN
+	INSERT INTO tasks_fts(tasks_fts, rowid, title, description) VALUES ('delete', old.rowid, old.title, old.description);
+	INSERT INTO tasks_fts(rowid, title, description) VALUES (new.rowid, new.title, new.description);
+END;
+
+-- Index tasks that existed before the search index was created.
+INSERT INTO tasks_fts(tasks_fts) VALUES ('rebuild');
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_tasks_due_at;
+
+ALTER TABLE tasks DROP COLUMN all_day;
+ALTER TABLE tasks DROP COLUMN due_at;
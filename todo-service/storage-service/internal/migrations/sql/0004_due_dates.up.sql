@@ -0,0 +1,4 @@
+ALTER TABLE tasks ADD COLUMN due_at DATETIME;
+ALTER TABLE tasks ADD COLUMN all_day BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_due_at ON tasks (due_at);
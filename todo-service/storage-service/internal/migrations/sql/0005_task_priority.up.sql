@@ -0,0 +1,4 @@
+-- 0 = none, 1 = low, 2 = medium, 3 = high, 4 = urgent (see domain.Priority).
+ALTER TABLE tasks ADD COLUMN priority INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_priority ON tasks (priority);
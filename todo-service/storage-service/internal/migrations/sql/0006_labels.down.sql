@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_task_labels_label_id;
+DROP TABLE IF EXISTS task_labels;
+DROP TABLE IF EXISTS labels;
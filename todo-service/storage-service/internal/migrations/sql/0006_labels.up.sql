@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS labels (
+	id TEXT PRIMARY KEY,
+	name TEXT NOT NULL COLLATE NOCASE UNIQUE,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Rows disappear with their task or label; db.NewConnection enables foreign keys.
+CREATE TABLE IF NOT EXISTS task_labels (
+	task_id TEXT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+	label_id TEXT NOT NULL REFERENCES labels (id) ON DELETE CASCADE,
+	PRIMARY KEY (task_id, label_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_labels_label_id ON task_labels (label_id);
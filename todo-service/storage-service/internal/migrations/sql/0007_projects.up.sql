@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS projects (
+	id TEXT PRIMARY KEY,
+	name TEXT NOT NULL,
+	color TEXT NOT NULL DEFAULT '',
+	archived BOOLEAN NOT NULL DEFAULT FALSE,
+	sort_order INTEGER NOT NULL DEFAULT 0,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+-- tasks.project_id refers to projects.id. It has no REFERENCES clause because
+-- SQLite cannot drop a column used by a foreign key, which the down migration
+-- needs; 0013 adds the foreign key with a table rebuild.
+ALTER TABLE tasks ADD COLUMN project_id TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_project_id ON tasks (project_id);
@@ -0,0 +1,7 @@
+-- tasks.parent_id refers to tasks.id. Like project_id it has no REFERENCES
+-- clause so that the down migration can drop it. SQLiteStore keeps subtasks
+-- with their parent when tasks are trashed, restored and purged.
+ALTER TABLE tasks ADD COLUMN parent_id TEXT;
+ALTER TABLE tasks ADD COLUMN auto_complete BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_parent_id ON tasks (parent_id);
@@ -0,0 +1,6 @@
+-- tasks.recurrence holds the RRULE of a recurring task. Occurrences of one
+-- series share series_id, the ID of the series' first occurrence.
+ALTER TABLE tasks ADD COLUMN recurrence TEXT;
+ALTER TABLE tasks ADD COLUMN series_id TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_series_id ON tasks (series_id);
@@ -0,0 +1,30 @@
+-- Rolling back fails if two users have labels of the same name.
+CREATE TABLE labels_old (
+	id TEXT PRIMARY KEY,
+	name TEXT NOT NULL COLLATE NOCASE UNIQUE,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+INSERT INTO labels_old (id, name, created_at) SELECT id, name, created_at FROM labels;
+
+CREATE TABLE task_labels_old (
+	task_id TEXT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+	label_id TEXT NOT NULL REFERENCES labels_old (id) ON DELETE CASCADE,
+	PRIMARY KEY (task_id, label_id)
+);
+INSERT INTO task_labels_old (task_id, label_id) SELECT task_id, label_id FROM task_labels;
+
+DROP INDEX IF EXISTS idx_task_labels_label_id;
+DROP TABLE task_labels;
+DROP TABLE labels;
+ALTER TABLE labels_old RENAME TO labels;
+ALTER TABLE task_labels_old RENAME TO task_labels;
+
+CREATE INDEX IF NOT EXISTS idx_task_labels_label_id ON task_labels (label_id);
+
+DROP INDEX IF EXISTS idx_projects_owner_id;
+DROP INDEX IF EXISTS idx_tasks_owner_id;
+
+ALTER TABLE projects DROP COLUMN owner_id;
+ALTER TABLE tasks DROP COLUMN owner_id;
+
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,44 @@
+CREATE TABLE IF NOT EXISTS users (
+	id TEXT PRIMARY KEY,
+	email TEXT NOT NULL COLLATE NOCASE UNIQUE,
+	password_hash TEXT NOT NULL,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+-- owner_id refers to users.id on tasks, projects and labels. Rows created
+-- before accounts existed have none, and no user sees them, until an operator
+-- hands them to an account with `server migrate adopt EMAIL`, which runs
+-- SQLiteStore.AdoptUnowned.
+ALTER TABLE tasks ADD COLUMN owner_id TEXT;
+ALTER TABLE projects ADD COLUMN owner_id TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_owner_id ON tasks (owner_id);
+CREATE INDEX IF NOT EXISTS idx_projects_owner_id ON projects (owner_id);
+
+-- Label names become unique per owner. SQLite cannot change a column's
+-- constraints, so labels is rebuilt. task_labels is rebuilt along with it:
+-- dropping labels while task_labels refers to it would cascade the delete.
+CREATE TABLE labels_new (
+	id TEXT PRIMARY KEY,
+	owner_id TEXT,
+	name TEXT NOT NULL COLLATE NOCASE,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE (owner_id, name)
+);
+INSERT INTO labels_new (id, name, created_at) SELECT id, name, created_at FROM labels;
+
+CREATE TABLE task_labels_new (
+	task_id TEXT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+	label_id TEXT NOT NULL REFERENCES labels_new (id) ON DELETE CASCADE,
+	PRIMARY KEY (task_id, label_id)
+);
+INSERT INTO task_labels_new (task_id, label_id) SELECT task_id, label_id FROM task_labels;
+
+DROP INDEX IF EXISTS idx_task_labels_label_id;
+DROP TABLE task_labels;
+DROP TABLE labels;
+-- Renaming labels_new also updates the reference in task_labels_new.
+ALTER TABLE labels_new RENAME TO labels;
+ALTER TABLE task_labels_new RENAME TO task_labels;
+
+CREATE INDEX IF NOT EXISTS idx_task_labels_label_id ON task_labels (label_id);
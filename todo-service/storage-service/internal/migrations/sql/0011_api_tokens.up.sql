@@ -0,0 +1,14 @@
+-- token_hash is the SHA-256 of the token; the token itself is never stored.
+-- scopes is a comma-separated list.
+CREATE TABLE IF NOT EXISTS api_tokens (
+	id TEXT PRIMARY KEY,
+	owner_id TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	name TEXT NOT NULL,
+	token_hash TEXT NOT NULL UNIQUE,
+	scopes TEXT NOT NULL,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	expires_at DATETIME,
+	last_used_at DATETIME
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_tokens_owner_id ON api_tokens (owner_id);
@@ -0,0 +1,77 @@
+-- tasks_fts found its rows through the implicit rowid of tasks, which VACUUM
+-- may renumber because tasks has a TEXT primary key. tasks is rebuilt with
+-- seq, an INTEGER PRIMARY KEY that aliases rowid and so stays stable, and the
+-- index is pointed at it. task_labels is rebuilt along with it: dropping tasks
+-- while task_labels refers to it would cascade the delete.
+DROP TRIGGER IF EXISTS tasks_fts_au;
+DROP TRIGGER IF EXISTS tasks_fts_ad;
+DROP TRIGGER IF EXISTS tasks_fts_ai;
+DROP TABLE IF EXISTS tasks_fts;
+
+CREATE TABLE tasks_new (
+	seq INTEGER PRIMARY KEY,
+	id TEXT NOT NULL UNIQUE,
+	title TEXT NOT NULL,
+	description TEXT,
+	completed BOOLEAN NOT NULL DEFAULT FALSE,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	deleted_at DATETIME,
+	due_at DATETIME,
+	all_day BOOLEAN NOT NULL DEFAULT FALSE,
+	priority INTEGER NOT NULL DEFAULT 0,
+	project_id TEXT,
+	parent_id TEXT,
+	auto_complete BOOLEAN NOT NULL DEFAULT FALSE,
+	recurrence TEXT,
+	series_id TEXT,
+	owner_id TEXT
+);
+INSERT INTO tasks_new (seq, id, title, description, completed, created_at, updated_at, deleted_at, due_at, all_day, priority, project_id, parent_id, auto_complete, recurrence, series_id, owner_id)
+	SELECT rowid, id, title, description, completed, created_at, updated_at, deleted_at, due_at, all_day, priority, project_id, parent_id, auto_complete, recurrence, series_id, owner_id FROM tasks;
+
+CREATE TABLE task_labels_new (
+	task_id TEXT NOT NULL REFERENCES tasks_new (id) ON DELETE CASCADE,
+	label_id TEXT NOT NULL REFERENCES labels (id) ON DELETE CASCADE,
+	PRIMARY KEY (task_id, label_id)
+);
+INSERT INTO task_labels_new (task_id, label_id) SELECT task_id, label_id FROM task_labels;
+
+DROP INDEX IF EXISTS idx_task_labels_label_id;
+DROP TABLE task_labels;
+DROP TABLE tasks;
+-- Renaming tasks_new also updates the reference in task_labels_new.
+ALTER TABLE tasks_new RENAME TO tasks;
+ALTER TABLE task_labels_new RENAME TO task_labels;
+
+CREATE INDEX IF NOT EXISTS idx_task_labels_label_id ON task_labels (label_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_deleted_at ON tasks (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_tasks_due_at ON tasks (due_at);
+CREATE INDEX IF NOT EXISTS idx_tasks_priority ON tasks (priority);
+CREATE INDEX IF NOT EXISTS idx_tasks_project_id ON tasks (project_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_parent_id ON tasks (parent_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_series_id ON tasks (series_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_owner_id ON tasks (owner_id);
+
+CREATE VIRTUAL TABLE tasks_fts USING fts5(
+	title,
+	description,
+	content='tasks',
+	content_rowid='seq',
+	tokenize='unicode61 remove_diacritics 2'
+);
+
+CREATE TRIGGER tasks_fts_ai AFTER INSERT ON tasks BEGIN
+	INSERT INTO tasks_fts(rowid, title, description) VALUES (new.seq, new.title, new.description);
+END;
+
+CREATE TRIGGER tasks_fts_ad AFTER DELETE ON tasks BEGIN
+	INSERT INTO tasks_fts(tasks_fts, rowid, title, description) VALUES ('delete', old.seq, old.title, old.description);
+END;
+
+CREATE TRIGGER tasks_fts_au AFTER UPDATE OF title, description ON tasks BEGIN
+	INSERT INTO tasks_fts(tasks_fts, rowid, title, description) VALUES ('delete', old.seq, old.title, old.description);
+	INSERT INTO tasks_fts(rowid, title, description) VALUES (new.seq, new.title, new.description);
+END;
+
+INSERT INTO tasks_fts(tasks_fts) VALUES ('rebuild');
@@ -0,0 +1,75 @@
+// Package requestid carries the ID of the request a call serves. The API
+// gateway sends the ID of the HTTP request in the MetadataKey gRPC
+// metadata; interceptors move it into the request context, where
+// ctxlog.Handler adds it to every record, so log lines of both services can
+// be matched up.
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/sahidhossen/todo/proto/ctxlog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataKey is the gRPC metadata key carrying the request ID.
+const MetadataKey = "x-request-id"
+
+// maxLength bounds the length of request IDs accepted from callers.
+const maxLength = 128
+
+// New returns a random request ID.
+func New() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// NewContext returns a copy of ctx carrying the request ID.
+func NewContext(ctx context.Context, id string) context.Context {
+	return ctxlog.WithRequestID(ctx, id)
+}
+
+// FromContext returns the request ID carried by ctx, or "" when there is none.
+func FromContext(ctx context.Context) string {
+	return ctxlog.RequestID(ctx)
+}
+
+// fromMetadata returns ctx carrying the request ID from the incoming
+// metadata. Calls without one, such as those not made by the gateway, get a
+// new ID so that their log lines can still be matched up.
+func fromMetadata(ctx context.Context) context.Context {
+	if values := metadata.ValueFromIncomingContext(ctx, MetadataKey); len(values) == 1 && values[0] != "" && len(values[0]) <= maxLength {
+		return NewContext(ctx, values[0])
+	}
+	return NewContext(ctx, New())
+}
+
+// UnaryServerInterceptor puts the request ID from the request metadata into
+// the context of unary calls.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(fromMetadata(ctx), req)
+	}
+}
+
+// StreamServerInterceptor puts the request ID from the request metadata into
+// the context of streaming calls.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &requestStream{ServerStream: ss, ctx: fromMetadata(ss.Context())})
+	}
+}
+
+// requestStream is a grpc.ServerStream with a replaced context.
+type requestStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *requestStream) Context() context.Context {
+	return s.ctx
+}
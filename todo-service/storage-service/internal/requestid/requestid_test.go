@@ -0,0 +1,30 @@
+package requestid
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	var seen string
+	handler := func(ctx context.Context, req any) (any, error) {
+		seen = FromContext(ctx)
+		return nil, nil
+	}
+	interceptor := UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/m"}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "req-1"))
+	_, err := interceptor(ctx, nil, info, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "req-1", seen)
+
+	_, err = interceptor(context.Background(), nil, info, handler)
+	require.NoError(t, err)
+	assert.Len(t, seen, 32, "calls without an ID get a new one")
+}
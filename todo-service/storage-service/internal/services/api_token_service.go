@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sahidhossen/todo/storage-service/internal/auth"
+	"github.com/sahidhossen/todo/storage-service/internal/converters"
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+	"github.com/sahidhossen/todo/storage-service/internal/grpcerr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sahidhossen/todo/proto/task_service"
+)
+
+// CreateApiToken handles the gRPC request to create a personal API token.
+func (s *TaskServiceServer) CreateApiToken(ctx context.Context, req *pb.CreateApiTokenRequest) (*pb.CreateApiTokenResponse, error) {
+	name, err := domain.NormalizeApiTokenName(req.Name)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Invalid CreateApiToken request", "error", err)
+		return nil, grpcerr.InvalidField("name", "%v", err)
+	}
+	scopes, err := domain.NormalizeScopes(req.Scopes)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Invalid CreateApiToken request", "error", err)
+		return nil, grpcerr.InvalidField("scopes", "%v", err)
+	}
+	token := &domain.ApiToken{Name: name, Scopes: scopes}
+	if req.ExpiresAt != nil {
+		expires := req.ExpiresAt.AsTime()
+		if !expires.After(time.Now()) {
+			s.logger.WarnContext(ctx, "Invalid CreateApiToken request", "expires_at", expires)
+			return nil, grpcerr.InvalidField("expires_at", "expires_at must be in the future")
+		}
+		token.ExpiresAt = &expires
+	}
+
+	secret, hash, err := auth.NewApiToken()
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: Failed to generate API token", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to create API token: %v", err)
+	}
+	if err := s.store.CreateApiToken(ctx, token, hash); err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: Failed to save API token", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to create API token: %v", err)
+	}
+
+	s.logger.InfoContext(ctx, "gRPC: API token created", "id", token.ID, "scopes", token.Scopes)
+	return &pb.CreateApiTokenResponse{ApiToken: converters.DomainToProtoApiToken(token), Token: secret}, nil
+}
+
+// ListApiTokens handles the gRPC request to list the caller's API tokens.
+func (s *TaskServiceServer) ListApiTokens(ctx context.Context, req *pb.ListApiTokensRequest) (*pb.ListApiTokensResponse, error) {
+	tokens, err := s.store.ListApiTokens(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to list API tokens from store", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to list API tokens: %v", err)
+	}
+
+	pbTokens := make([]*pb.ApiToken, len(tokens))
+	for i, token := range tokens {
+		pbTokens[i] = converters.DomainToProtoApiToken(token)
+	}
+	s.logger.InfoContext(ctx, "gRPC: Listed API tokens", "count", len(pbTokens))
+	return &pb.ListApiTokensResponse{ApiTokens: pbTokens}, nil
+}
+
+// RevokeApiToken handles the gRPC request to revoke one of the caller's API tokens.
+func (s *TaskServiceServer) RevokeApiToken(ctx context.Context, req *pb.RevokeApiTokenRequest) (*pb.RevokeApiTokenResponse, error) {
+	if err := s.validate(ctx, req); err != nil {
+		return nil, err
+	}
+	if err := s.store.RevokeApiToken(ctx, req.Id); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			s.logger.WarnContext(ctx, "gRPC: API token not found for revoking", "id", req.Id)
+			return nil, grpcerr.NotFound("api_token", req.Id, "API token with ID %s not found", req.Id)
+		}
+		s.logger.ErrorContext(ctx, "gRPC: Failed to revoke API token", "id", req.Id, "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to revoke API token: %v", err)
+	}
+
+	s.logger.InfoContext(ctx, "gRPC: API token revoked", "id", req.Id)
+	return &pb.RevokeApiTokenResponse{}, nil
+}
+
+// VerifyApiToken handles the gRPC request to resolve an API token to the user
+// it acts for. Unknown and expired tokens get the same error.
+func (s *TaskServiceServer) VerifyApiToken(ctx context.Context, req *pb.VerifyApiTokenRequest) (*pb.VerifyApiTokenResponse, error) {
+	now := time.Now()
+	token, err := s.store.UseApiToken(ctx, auth.HashApiToken(req.Token), now)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		s.logger.ErrorContext(ctx, "gRPC: Failed to get API token", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to verify API token: %v", err)
+	}
+	if token == nil || token.Expired(now) {
+		s.logger.WarnContext(ctx, "gRPC: API token rejected")
+		return nil, grpcerr.New(codes.Unauthenticated, grpcerr.ReasonInvalidApiToken, nil, "invalid or expired API token")
+	}
+
+	return &pb.VerifyApiTokenResponse{UserId: token.OwnerID, Scopes: token.Scopes}, nil
+}
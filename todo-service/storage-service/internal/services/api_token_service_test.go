@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/sahidhossen/todo/proto/task_service"
+	"github.com/sahidhossen/todo/storage-service/internal/auth"
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+	"github.com/sahidhossen/todo/storage-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestCreateApiToken_Success(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	var storedHash string
+	mockStore.On("CreateApiToken", mock.Anything, mock.MatchedBy(func(token *domain.ApiToken) bool {
+		return token.Name == "CI" && assert.ObjectsAreEqual([]string{"read", "write"}, token.Scopes)
+	}), mock.AnythingOfType("string")).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.ApiToken).ID = "token-1"
+		storedHash = args.String(2)
+	}).Return(nil).Once()
+
+	resp, err := service.CreateApiToken(context.Background(), &pb.CreateApiTokenRequest{
+		Name:      " CI ",
+		Scopes:    []string{"WRITE", "read", "write"},
+		ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", resp.ApiToken.Id)
+	assert.NotNil(t, resp.ApiToken.ExpiresAt)
+	assert.True(t, strings.HasPrefix(resp.Token, domain.ApiTokenPrefix))
+	assert.Equal(t, auth.HashApiToken(resp.Token), storedHash, "only the hash is stored")
+	mockStore.AssertExpectations(t)
+}
+
+func TestCreateApiToken_InvalidInput(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	for name, req := range map[string]*pb.CreateApiTokenRequest{
+		"no name":       {Scopes: []string{"read"}},
+		"no scopes":     {Name: "CI"},
+		"unknown scope": {Name: "CI", Scopes: []string{"delete"}},
+		"expired":       {Name: "CI", Scopes: []string{"read"}, ExpiresAt: timestamppb.New(time.Now().Add(-time.Hour))},
+	} {
+		_, err := service.CreateApiToken(context.Background(), req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), name)
+	}
+	mockStore.AssertNotCalled(t, "CreateApiToken", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRevokeApiToken_NotFound(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	mockStore.On("RevokeApiToken", mock.Anything, "token-1").Return(fmt.Errorf("API token: %w", domain.ErrNotFound)).Once()
+
+	_, err := service.RevokeApiToken(context.Background(), &pb.RevokeApiTokenRequest{Id: "token-1"})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	mockStore.AssertExpectations(t)
+}
+
+func TestVerifyApiToken(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	past := time.Now().Add(-time.Minute)
+	mockStore.On("UseApiToken", mock.Anything, auth.HashApiToken("todo_pat_good"), mock.Anything).
+		Return(&domain.ApiToken{ID: "token-1", OwnerID: "user-1", Scopes: []string{"read"}}, nil)
+	mockStore.On("UseApiToken", mock.Anything, auth.HashApiToken("todo_pat_expired"), mock.Anything).
+		Return(&domain.ApiToken{ID: "token-2", OwnerID: "user-1", Scopes: []string{"read"}, ExpiresAt: &past}, nil)
+	mockStore.On("UseApiToken", mock.Anything, auth.HashApiToken("todo_pat_unknown"), mock.Anything).
+		Return(nil, fmt.Errorf("API token: %w", domain.ErrNotFound))
+
+	resp, err := service.VerifyApiToken(context.Background(), &pb.VerifyApiTokenRequest{Token: "todo_pat_good"})
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", resp.UserId)
+	assert.Equal(t, []string{"read"}, resp.Scopes)
+
+	_, expired := service.VerifyApiToken(context.Background(), &pb.VerifyApiTokenRequest{Token: "todo_pat_expired"})
+	_, unknown := service.VerifyApiToken(context.Background(), &pb.VerifyApiTokenRequest{Token: "todo_pat_unknown"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(expired))
+	assert.Equal(t, codes.Unauthenticated, status.Code(unknown))
+}
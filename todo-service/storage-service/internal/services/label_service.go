@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sahidhossen/todo/storage-service/internal/converters"
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+	"github.com/sahidhossen/todo/storage-service/internal/grpcerr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sahidhossen/todo/proto/task_service"
+)
+
+// CreateLabel handles the gRPC request to create a label.
+func (s *TaskServiceServer) CreateLabel(ctx context.Context, req *pb.CreateLabelRequest) (*pb.CreateLabelResponse, error) {
+	name, err := domain.NormalizeLabelName(req.Name)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Invalid CreateLabel request", "error", err)
+		return nil, grpcerr.InvalidField("name", "%v", err)
+	}
+
+	label, err := s.store.CreateLabel(ctx, name)
+	if err != nil {
+		s.logger.WarnContext(ctx, "gRPC: Failed to create label", "name", name, "error", err)
+		return nil, labelError(err, "", "create label")
+	}
+
+	s.logger.InfoContext(ctx, "gRPC: Label created", "id", label.ID, "name", label.Name)
+	return &pb.CreateLabelResponse{Label: converters.DomainToProtoLabel(label)}, nil
+}
+
+// ListLabels handles the gRPC request to list all labels.
+func (s *TaskServiceServer) ListLabels(ctx context.Context, req *pb.ListLabelsRequest) (*pb.ListLabelsResponse, error) {
+	labels, err := s.store.ListLabels(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to list labels from store", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to list labels: %v", err)
+	}
+
+	pbLabels := make([]*pb.Label, len(labels))
+	for i, label := range labels {
+		pbLabels[i] = converters.DomainToProtoLabel(label)
+	}
+	s.logger.InfoContext(ctx, "gRPC: Listed labels", "count", len(pbLabels))
+	return &pb.ListLabelsResponse{Labels: pbLabels}, nil
+}
+
+// RenameLabel handles the gRPC request to rename a label.
+func (s *TaskServiceServer) RenameLabel(ctx context.Context, req *pb.RenameLabelRequest) (*pb.RenameLabelResponse, error) {
+	if err := s.validate(ctx, req); err != nil {
+		return nil, err
+	}
+	name, err := domain.NormalizeLabelName(req.Name)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Invalid RenameLabel request", "error", err)
+		return nil, grpcerr.InvalidField("name", "%v", err)
+	}
+
+	label, err := s.store.RenameLabel(ctx, req.Id, name)
+	if err != nil {
+		s.logger.WarnContext(ctx, "gRPC: Failed to rename label", "id", req.Id, "error", err)
+		return nil, labelError(err, req.Id, "rename label")
+	}
+
+	s.logger.InfoContext(ctx, "gRPC: Label renamed", "id", label.ID, "name", label.Name)
+	return &pb.RenameLabelResponse{Label: converters.DomainToProtoLabel(label)}, nil
+}
+
+// DeleteLabel handles the gRPC request to delete a label and detach it from its tasks.
+func (s *TaskServiceServer) DeleteLabel(ctx context.Context, req *pb.DeleteLabelRequest) (*pb.DeleteLabelResponse, error) {
+	if err := s.validate(ctx, req); err != nil {
+		return nil, err
+	}
+	if err := s.store.DeleteLabel(ctx, req.Id); err != nil {
+		s.logger.WarnContext(ctx, "gRPC: Failed to delete label", "id", req.Id, "error", err)
+		return nil, labelError(err, req.Id, "delete label")
+	}
+
+	s.logger.InfoContext(ctx, "gRPC: Label deleted", "id", req.Id)
+	return &pb.DeleteLabelResponse{}, nil
+}
+
+// AddLabels handles the gRPC request to attach labels to a task.
+func (s *TaskServiceServer) AddLabels(ctx context.Context, req *pb.AddLabelsRequest) (*pb.AddLabelsResponse, error) {
+	if err := s.validate(ctx, req); err != nil {
+		return nil, err
+	}
+	names, err := s.labelNames(req.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.store.AddLabels(ctx, req.TaskId, names)
+	if err != nil {
+		s.logger.WarnContext(ctx, "gRPC: Failed to add labels", "id", req.TaskId, "error", err)
+		return nil, storeError(err, req.TaskId, "add labels")
+	}
+
+	s.logger.InfoContext(ctx, "gRPC: Labels added to task", "id", task.ID, "labels", names)
+	return &pb.AddLabelsResponse{Task: converters.DomainToProtoTask(task)}, nil
+}
+
+// RemoveLabels handles the gRPC request to detach labels from a task.
+func (s *TaskServiceServer) RemoveLabels(ctx context.Context, req *pb.RemoveLabelsRequest) (*pb.RemoveLabelsResponse, error) {
+	if err := s.validate(ctx, req); err != nil {
+		return nil, err
+	}
+	names, err := s.labelNames(req.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.store.RemoveLabels(ctx, req.TaskId, names)
+	if err != nil {
+		s.logger.WarnContext(ctx, "gRPC: Failed to remove labels", "id", req.TaskId, "error", err)
+		return nil, storeError(err, req.TaskId, "remove labels")
+	}
+
+	s.logger.InfoContext(ctx, "gRPC: Labels removed from task", "id", task.ID, "labels", names)
+	return &pb.RemoveLabelsResponse{Task: converters.DomainToProtoTask(task)}, nil
+}
+
+// labelNames normalizes the label names of AddLabels and RemoveLabels.
+func (s *TaskServiceServer) labelNames(labels []string) ([]string, error) {
+	names, err := domain.NormalizeLabelNames(labels)
+	if err != nil {
+		s.logger.Warn("Invalid label names", "error", err)
+		return nil, grpcerr.InvalidField("labels", "%v", err)
+	}
+	return names, nil
+}
+
+// labelError maps an error returned by the store for a label operation to a gRPC status error.
+func labelError(err error, id string, action string) error {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return grpcerr.NotFound("label", id, "label with ID %s not found", id)
+	case errors.Is(err, domain.ErrAlreadyExists):
+		return grpcerr.AlreadyExists("label", "", "a label with that name already exists")
+	default:
+		return status.Errorf(codes.Internal, "failed to %s: %v", action, err)
+	}
+}
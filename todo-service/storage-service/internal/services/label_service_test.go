@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	pb "github.com/sahidhossen/todo/proto/task_service"
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+	"github.com/sahidhossen/todo/storage-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateLabel_TrimsName(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	mockStore.On("CreateLabel", mock.Anything, "work").Return(&domain.Label{ID: "label-1", Name: "work"}, nil).Once()
+
+	resp, err := service.CreateLabel(context.Background(), &pb.CreateLabelRequest{Name: "  work "})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "work", resp.Label.Name)
+	mockStore.AssertExpectations(t)
+}
+
+func TestCreateLabel_DuplicateName(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	mockStore.On("CreateLabel", mock.Anything, "work").Return(nil, fmt.Errorf("label %q: %w", "work", domain.ErrAlreadyExists)).Once()
+
+	resp, err := service.CreateLabel(context.Background(), &pb.CreateLabelRequest{Name: "work"})
+
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	mockStore.AssertExpectations(t)
+}
+
+func TestDeleteLabel_NotFound(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	mockStore.On("DeleteLabel", mock.Anything, "missing").Return(fmt.Errorf("label: %w", domain.ErrNotFound)).Once()
+
+	_, err := service.DeleteLabel(context.Background(), &pb.DeleteLabelRequest{Id: "missing"})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	mockStore.AssertExpectations(t)
+}
+
+func TestAddLabels_DeduplicatesNames(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	task := &domain.Task{ID: "task-1", Title: "Buy milk", Labels: []string{"errands", "home"}}
+	mockStore.On("AddLabels", mock.Anything, "task-1", []string{"home", "errands"}).Return(task, nil).Once()
+
+	resp, err := service.AddLabels(context.Background(), &pb.AddLabelsRequest{TaskId: "task-1", Labels: []string{"home", "errands", "Home "}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"errands", "home"}, resp.Task.Labels)
+	mockStore.AssertExpectations(t)
+}
+
+func TestRemoveLabels_RequiresLabels(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	_, err := service.RemoveLabels(context.Background(), &pb.RemoveLabelsRequest{TaskId: "task-1"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	mockStore.AssertNotCalled(t, "RemoveLabels", mock.Anything, mock.Anything, mock.Anything)
+}
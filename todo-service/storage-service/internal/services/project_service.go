@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sahidhossen/todo/storage-service/internal/converters"
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+	"github.com/sahidhossen/todo/storage-service/internal/grpcerr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sahidhossen/todo/proto/task_service"
+)
+
+// updatableProjectFields lists the Project fields that UpdateProject accepts in its field mask.
+var updatableProjectFields = map[string]bool{
+	"name":       true,
+	"color":      true,
+	"archived":   true,
+	"sort_order": true,
+}
+
+// CreateProject handles the gRPC request to create a project.
+func (s *TaskServiceServer) CreateProject(ctx context.Context, req *pb.CreateProjectRequest) (*pb.CreateProjectResponse, error) {
+	project := &domain.Project{
+		Name:      req.Name,
+		Color:     req.Color,
+		SortOrder: req.SortOrder,
+	}
+	if err := project.Validate(); err != nil {
+		s.logger.WarnContext(ctx, "Invalid CreateProject request", "error", err)
+		return nil, grpcerr.InvalidArgument("%v", err)
+	}
+
+	if err := s.store.SaveProject(ctx, project); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to save project to store", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to save project: %v", err)
+	}
+
+	s.logger.InfoContext(ctx, "gRPC: Project created", "id", project.ID, "name", project.Name)
+	return &pb.CreateProjectResponse{Project: converters.DomainToProtoProject(project)}, nil
+}
+
+// GetProject handles the gRPC request to get a project by ID.
+func (s *TaskServiceServer) GetProject(ctx context.Context, req *pb.GetProjectRequest) (*pb.GetProjectResponse, error) {
+	if err := s.validate(ctx, req); err != nil {
+		return nil, err
+	}
+	project, err := s.store.GetProject(ctx, req.Id)
+	if err != nil {
+		s.logger.WarnContext(ctx, "gRPC: Failed to get project", "id", req.Id, "error", err)
+		return nil, projectError(err, req.Id, "get project")
+	}
+
+	s.logger.InfoContext(ctx, "gRPC: Project retrieved", "id", project.ID)
+	return &pb.GetProjectResponse{Project: converters.DomainToProtoProject(project)}, nil
+}
+
+// ListProjects handles the gRPC request to list projects.
+func (s *TaskServiceServer) ListProjects(ctx context.Context, req *pb.ListProjectsRequest) (*pb.ListProjectsResponse, error) {
+	projects, err := s.store.ListProjects(ctx, req.IncludeArchived)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to list projects from store", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to list projects: %v", err)
+	}
+
+	pbProjects := make([]*pb.Project, len(projects))
+	for i, project := range projects {
+		pbProjects[i] = converters.DomainToProtoProject(project)
+	}
+	s.logger.InfoContext(ctx, "gRPC: Listed projects", "count", len(pbProjects))
+	return &pb.ListProjectsResponse{Projects: pbProjects}, nil
+}
+
+// UpdateProject handles the gRPC request to change the fields of a project named in the update mask.
+func (s *TaskServiceServer) UpdateProject(ctx context.Context, req *pb.UpdateProjectRequest) (*pb.UpdateProjectResponse, error) {
+	if err := s.validate(ctx, req); err != nil {
+		return nil, err
+	}
+	id := req.GetProject().GetId()
+	paths := req.GetUpdateMask().GetPaths()
+	for _, path := range paths {
+		if !updatableProjectFields[path] {
+			return nil, grpcerr.InvalidField("update_mask", "field %q cannot be updated", path)
+		}
+	}
+
+	project, err := s.store.GetProject(ctx, id)
+	if err != nil {
+		s.logger.WarnContext(ctx, "gRPC: Project not found for update", "id", id, "error", err)
+		return nil, projectError(err, id, "get project")
+	}
+
+	for _, path := range paths {
+		switch path {
+		case "name":
+			project.Name = req.Project.GetName()
+		case "color":
+			project.Color = req.Project.GetColor()
+		case "archived":
+			project.Archived = req.Project.GetArchived()
+		case "sort_order":
+			project.SortOrder = req.Project.GetSortOrder()
+		}
+	}
+	if err := project.Validate(); err != nil {
+		return nil, grpcerr.InvalidArgument("%v", err)
+	}
+
+	if err := s.store.SaveProject(ctx, project); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to update project in store", "id", id, "error", err)
+		return nil, projectError(err, id, "update project")
+	}
+
+	s.logger.InfoContext(ctx, "gRPC: Project updated", "id", id, "fields", paths)
+	return &pb.UpdateProjectResponse{Project: converters.DomainToProtoProject(project)}, nil
+}
+
+// DeleteProject handles the gRPC request to delete a project. Its tasks are kept outside any project.
+func (s *TaskServiceServer) DeleteProject(ctx context.Context, req *pb.DeleteProjectRequest) (*pb.DeleteProjectResponse, error) {
+	if err := s.validate(ctx, req); err != nil {
+		return nil, err
+	}
+	if err := s.store.DeleteProject(ctx, req.Id); err != nil {
+		s.logger.WarnContext(ctx, "gRPC: Failed to delete project", "id", req.Id, "error", err)
+		return nil, projectError(err, req.Id, "delete project")
+	}
+
+	s.logger.InfoContext(ctx, "gRPC: Project deleted", "id", req.Id)
+	return &pb.DeleteProjectResponse{}, nil
+}
+
+// checkProject verifies that a project filter names a project of the caller,
+// so that listing a missing project is reported instead of looking empty. An
+// empty ID means no filter.
+func (s *TaskServiceServer) checkProject(ctx context.Context, projectID string) error {
+	if projectID == "" {
+		return nil
+	}
+	if _, err := s.store.GetProject(ctx, projectID); err != nil {
+		s.logger.WarnContext(ctx, "gRPC: Project not found for filter", "project_id", projectID, "error", err)
+		return projectError(err, projectID, "get project")
+	}
+	return nil
+}
+
+// checkTaskProject verifies that a task can be placed in a project: the
+// project must exist and must not be archived. An empty ID means no project.
+func (s *TaskServiceServer) checkTaskProject(ctx context.Context, projectID string) error {
+	if projectID == "" {
+		return nil
+	}
+	project, err := s.store.GetProject(ctx, projectID)
+	if err != nil {
+		s.logger.WarnContext(ctx, "gRPC: Project not found for task", "project_id", projectID, "error", err)
+		return projectError(err, projectID, "get project")
+	}
+	if project.Archived {
+		return grpcerr.New(codes.FailedPrecondition, grpcerr.ReasonProjectArchived, grpcerr.ResourceInfo("project", projectID), "project with ID %s is archived", projectID)
+	}
+	return nil
+}
+
+// projectError maps an error returned by the store for a project operation to a gRPC status error.
+func projectError(err error, id string, action string) error {
+	if errors.Is(err, domain.ErrNotFound) {
+		return grpcerr.NotFound("project", id, "project with ID %s not found", id)
+	}
+	return status.Errorf(codes.Internal, "failed to %s: %v", action, err)
+}
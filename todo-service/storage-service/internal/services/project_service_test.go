@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	pb "github.com/sahidhossen/todo/proto/task_service"
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+	"github.com/sahidhossen/todo/storage-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestCreateProject_NormalizesColor(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	mockStore.On("SaveProject", mock.Anything, mock.MatchedBy(func(p *domain.Project) bool {
+		return p.Name == "Home" && p.Color == "#ff8800"
+	})).Return(nil).Once()
+
+	resp, err := service.CreateProject(context.Background(), &pb.CreateProjectRequest{Name: " Home ", Color: "#FF8800"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "#ff8800", resp.Project.Color)
+	mockStore.AssertExpectations(t)
+}
+
+func TestCreateProject_InvalidColor(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	_, err := service.CreateProject(context.Background(), &pb.CreateProjectRequest{Name: "Home", Color: "orange"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	mockStore.AssertNotCalled(t, "SaveProject", mock.Anything, mock.Anything)
+}
+
+func TestUpdateProject_ArchivesProject(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	mockStore.On("GetProject", mock.Anything, "project-1").Return(&domain.Project{ID: "project-1", Name: "Home"}, nil).Once()
+	mockStore.On("SaveProject", mock.Anything, mock.MatchedBy(func(p *domain.Project) bool {
+		return p.Archived && p.Name == "Home"
+	})).Return(nil).Once()
+
+	resp, err := service.UpdateProject(context.Background(), &pb.UpdateProjectRequest{
+		Project:    &pb.Project{Id: "project-1", Name: "ignored", Archived: true},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"archived"}},
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, resp.Project.Archived)
+	mockStore.AssertExpectations(t)
+}
+
+func TestDeleteProject_NotFound(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	mockStore.On("DeleteProject", mock.Anything, "missing").Return(fmt.Errorf("project: %w", domain.ErrNotFound)).Once()
+
+	_, err := service.DeleteProject(context.Background(), &pb.DeleteProjectRequest{Id: "missing"})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	mockStore.AssertExpectations(t)
+}
+
+func TestCreateTask_ArchivedProject(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	mockStore.On("GetProject", mock.Anything, "project-1").Return(&domain.Project{ID: "project-1", Name: "Old", Archived: true}, nil).Once()
+
+	_, err := service.CreateTask(context.Background(), &pb.CreateTaskRequest{Title: "Task", ProjectId: "project-1"})
+
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	mockStore.AssertNotCalled(t, "SaveTask", mock.Anything, mock.Anything)
+}
+
+func TestGetTaskStats_ScopedToProject(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	mockStore.On("GetProject", mock.Anything, "project-1").Return(&domain.Project{ID: "project-1", Name: "Work"}, nil).Once()
+	mockStore.On("GetTaskStats", mock.Anything, domain.TaskStatsOptions{ProjectID: "project-1"}).Return(&domain.TaskStats{Total: 3, Completed: 1, Pending: 2}, nil).Once()
+
+	resp, err := service.GetTaskStats(context.Background(), &pb.GetTaskStatsRequest{ProjectId: "project-1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), resp.TotalTasks)
+	assert.Equal(t, int32(2), resp.PendingTasks)
+	mockStore.AssertExpectations(t)
+}
+
+func TestGetTaskStats_MissingProject(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	mockStore.On("GetProject", mock.Anything, "missing").Return(nil, fmt.Errorf("project: %w", domain.ErrNotFound)).Once()
+
+	_, err := service.GetTaskStats(context.Background(), &pb.GetTaskStatsRequest{ProjectId: "missing"})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	mockStore.AssertNotCalled(t, "GetTaskStats", mock.Anything, mock.Anything)
+}
+
+func TestListTasks_MissingProject(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	mockStore.On("GetProject", mock.Anything, "missing").Return(nil, fmt.Errorf("project: %w", domain.ErrNotFound)).Once()
+
+	_, err := service.ListTasks(context.Background(), &pb.ListTasksRequest{ProjectId: "missing"})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	mockStore.AssertNotCalled(t, "ListTasks", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sahidhossen/todo/storage-service/internal/converters"
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+	"github.com/sahidhossen/todo/storage-service/internal/grpcerr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sahidhossen/todo/proto/task_service"
+)
+
+// updatableSeriesFields lists the Task fields that UpdateTaskSeries accepts in its field mask.
+var updatableSeriesFields = map[string]bool{
+	"title":       true,
+	"description": true,
+	"priority":    true,
+	"project_id":  true,
+	"recurrence":  true,
+}
+
+// UpdateTaskSeries handles the gRPC request to change every open occurrence
+// of a recurring task series. Clearing the recurrence ends the series.
+func (s *TaskServiceServer) UpdateTaskSeries(ctx context.Context, req *pb.UpdateTaskSeriesRequest) (*pb.UpdateTaskSeriesResponse, error) {
+	if err := s.validate(ctx, req); err != nil {
+		return nil, err
+	}
+	paths := req.GetUpdateMask().GetPaths()
+	maskHas := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		if !updatableSeriesFields[path] {
+			return nil, grpcerr.InvalidField("update_mask", "field %q cannot be updated", path)
+		}
+		maskHas[path] = true
+	}
+	if maskHas["title"] && req.Task.GetTitle() == "" {
+		return nil, grpcerr.InvalidField("task.title", "title cannot be empty")
+	}
+	if priority := domain.Priority(req.Task.GetPriority()); maskHas["priority"] && !priority.Valid() {
+		return nil, grpcerr.InvalidField("task.priority", "unknown priority %d", priority)
+	}
+	if maskHas["project_id"] {
+		if err := s.checkTaskProject(ctx, req.Task.GetProjectId()); err != nil {
+			return nil, err
+		}
+	}
+
+	series, err := s.store.ListSeries(ctx, req.SeriesId)
+	if err != nil {
+		s.logger.WarnContext(ctx, "gRPC: Series not found for update", "series_id", req.SeriesId, "error", err)
+		return nil, seriesError(err, req.SeriesId, "list series")
+	}
+
+	var open []*domain.Task
+	for _, task := range series {
+		if task.IsDeleted() || task.Completed {
+			continue
+		}
+		for _, path := range paths {
+			switch path {
+			case "title":
+				task.Title = req.Task.GetTitle()
+			case "description":
+				task.Description = req.Task.GetDescription()
+			case "priority":
+				task.Priority = domain.Priority(req.Task.GetPriority())
+			case "project_id":
+				task.ProjectID = req.Task.GetProjectId()
+			case "recurrence":
+				if err := setRecurrence(task, req.Task.GetRecurrence()); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if err := checkRecurringDue(task); err != nil {
+			return nil, err
+		}
+		open = append(open, task)
+	}
+
+	// All open occurrences change together or, on failure, none of them does.
+	if err := s.store.UpdateTasks(ctx, open); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to update series occurrences in store", "series_id", req.SeriesId, "error", err)
+		return nil, seriesError(err, req.SeriesId, "update series")
+	}
+	pbTasks := make([]*pb.Task, len(open))
+	for i, task := range open {
+		pbTasks[i] = converters.DomainToProtoTask(task)
+	}
+
+	s.logger.InfoContext(ctx, "gRPC: Task series updated", "series_id", req.SeriesId, "fields", paths, "count", len(pbTasks))
+	return &pb.UpdateTaskSeriesResponse{Tasks: pbTasks}, nil
+}
+
+// nextOccurrence creates the occurrence that follows a completed recurring
+// task and returns it. It returns nil when the task does not recur, its
+// series has ended, or a later occurrence exists already, e.g. because the
+// task was completed, reopened and completed again. Failures are logged
+// rather than returned: the completion has already been saved, and
+// completing the task again retries.
+func (s *TaskServiceServer) nextOccurrence(ctx context.Context, task *domain.Task) *domain.Task {
+	if !task.Completed || task.Recurrence == "" || task.DueAt == nil {
+		return nil
+	}
+	rule, err := domain.ParseRecurrence(task.Recurrence)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to parse stored recurrence", "id", task.ID, "recurrence", task.Recurrence, "error", err)
+		return nil
+	}
+	series, err := s.store.ListSeries(ctx, task.SeriesID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to load task series", "id", task.ID, "series_id", task.SeriesID, "error", err)
+		return nil
+	}
+	for _, occurrence := range series {
+		if !occurrence.IsDeleted() && occurrence.DueAt != nil && occurrence.DueAt.After(*task.DueAt) {
+			return nil
+		}
+	}
+
+	dueAt, ok := rule.Next(*task.DueAt, len(series))
+	if !ok {
+		s.logger.InfoContext(ctx, "gRPC: Task series ended", "id", task.ID, "series_id", task.SeriesID)
+		return nil
+	}
+
+	next := &domain.Task{
+		Title:        task.Title,
+		Description:  task.Description,
+		DueAt:        &dueAt,
+		AllDay:       task.AllDay,
+		Priority:     task.Priority,
+		ProjectID:    task.ProjectID,
+		ParentID:     task.ParentID,
+		AutoComplete: task.AutoComplete,
+		Recurrence:   task.Recurrence,
+		SeriesID:     task.SeriesID,
+	}
+	if err := s.store.SaveTask(ctx, next); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to save next occurrence", "id", task.ID, "series_id", task.SeriesID, "error", err)
+		return nil
+	}
+	if len(task.Labels) > 0 {
+		labeled, err := s.store.AddLabels(ctx, next.ID, task.Labels)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "Failed to copy labels to next occurrence", "id", next.ID, "error", err)
+		} else {
+			next = labeled
+		}
+	}
+	// The new open occurrence reopens an auto-complete parent.
+	s.rollUpParents(ctx, next.ParentID)
+
+	s.logger.InfoContext(ctx, "gRPC: Next occurrence created", "id", next.ID, "series_id", next.SeriesID, "due_at", dueAt)
+	return next
+}
+
+// setRecurrence validates rule and stores it on task in canonical form. An
+// empty rule makes the task a one-off task.
+func setRecurrence(task *domain.Task, rule string) error {
+	if rule == "" {
+		task.Recurrence = ""
+		return nil
+	}
+	recurrence, err := domain.ParseRecurrence(rule)
+	if err != nil {
+		return grpcerr.InvalidField("recurrence", "%v", err)
+	}
+	task.Recurrence = recurrence.String()
+	return nil
+}
+
+// checkRecurringDue rejects recurring tasks without a due date, which the
+// next occurrence's due date is computed from.
+func checkRecurringDue(task *domain.Task) error {
+	if task.Recurrence != "" && task.DueAt == nil {
+		return grpcerr.InvalidField("due_at", "recurring tasks need a due date")
+	}
+	return nil
+}
+
+// seriesError is storeError for operations on a whole task series.
+func seriesError(err error, seriesID string, action string) error {
+	if errors.Is(err, domain.ErrNotFound) {
+		return grpcerr.NotFound("series", seriesID, "series with ID %s not found", seriesID)
+	}
+	return status.Errorf(codes.Internal, "failed to %s: %v", action, err)
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	pb "github.com/sahidhossen/todo/proto/task_service"
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+	"github.com/sahidhossen/todo/storage-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestCreateTask_CanonicalRecurrence(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	mockStore.On("SaveTask", mock.Anything, mock.MatchedBy(func(task *domain.Task) bool {
+		return task.Recurrence == "FREQ=WEEKLY;BYDAY=MO"
+	})).Return(nil).Once()
+
+	resp, err := service.CreateTask(context.Background(), &pb.CreateTaskRequest{
+		Title:      "Take out the bins",
+		DueAt:      timestamppb.New(time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)),
+		Recurrence: "RRULE:freq=weekly;byday=mo",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "FREQ=WEEKLY;BYDAY=MO", resp.Task.Recurrence)
+	mockStore.AssertExpectations(t)
+}
+
+func TestCreateTask_RecurrenceNeedsDueDate(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	_, err := service.CreateTask(context.Background(), &pb.CreateTaskRequest{Title: "Water plants", Recurrence: "FREQ=DAILY"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	mockStore.AssertNotCalled(t, "SaveTask", mock.Anything, mock.Anything)
+}
+
+func TestCompleteTask_CreatesNextOccurrence(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	dueAt := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+	task := &domain.Task{ID: "task-1", Title: "Bins", DueAt: &dueAt, Recurrence: "FREQ=WEEKLY", SeriesID: "task-1", Labels: []string{"home"}}
+	mockStore.On("GetTask", mock.Anything, "task-1").Return(task, nil).Once()
+	mockStore.On("SaveTask", mock.Anything, task).Return(nil).Once()
+	mockStore.On("ListSeries", mock.Anything, "task-1").Return([]*domain.Task{task}, nil).Once()
+	mockStore.On("SaveTask", mock.Anything, mock.MatchedBy(func(next *domain.Task) bool {
+		return next != task && next.SeriesID == "task-1" && next.DueAt.Equal(dueAt.AddDate(0, 0, 7)) && !next.Completed
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.Task).ID = "task-2"
+	}).Return(nil).Once()
+	mockStore.On("AddLabels", mock.Anything, "task-2", []string{"home"}).
+		Return(&domain.Task{ID: "task-2", Title: "Bins", Labels: []string{"home"}, SeriesID: "task-1"}, nil).Once()
+
+	resp, err := service.CompleteTask(context.Background(), &pb.CompleteTaskRequest{Id: "task-1"})
+
+	assert.NoError(t, err)
+	assert.True(t, resp.Task.Completed)
+	assert.Equal(t, "task-2", resp.NextOccurrence.GetId())
+	mockStore.AssertExpectations(t)
+}
+
+func TestCompleteTask_SeriesEnded(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	dueAt := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+	first := &domain.Task{ID: "task-1", Completed: true, DueAt: &dueAt, Recurrence: "FREQ=DAILY;COUNT=2", SeriesID: "task-1"}
+	secondDue := dueAt.AddDate(0, 0, 1)
+	second := &domain.Task{ID: "task-2", DueAt: &secondDue, Recurrence: "FREQ=DAILY;COUNT=2", SeriesID: "task-1"}
+	mockStore.On("ToggleTaskCompletion", mock.Anything, "task-2").Return(&domain.Task{
+		ID: "task-2", Completed: true, DueAt: &secondDue, Recurrence: second.Recurrence, SeriesID: "task-1",
+	}, nil).Once()
+	mockStore.On("ListSeries", mock.Anything, "task-1").Return([]*domain.Task{first, second}, nil).Once()
+
+	resp, err := service.ToggleTaskCompletion(context.Background(), &pb.ToggleTaskCompletionRequest{Id: "task-2"})
+
+	assert.NoError(t, err)
+	assert.Nil(t, resp.NextOccurrence)
+	mockStore.AssertNotCalled(t, "SaveTask", mock.Anything, mock.Anything)
+	mockStore.AssertExpectations(t)
+}
+
+func TestUpdateTaskSeries_EndsSeries(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	dueAt := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+	done := &domain.Task{ID: "task-1", Completed: true, DueAt: &dueAt, Recurrence: "FREQ=WEEKLY", SeriesID: "task-1"}
+	open := &domain.Task{ID: "task-2", DueAt: &dueAt, Recurrence: "FREQ=WEEKLY", SeriesID: "task-1"}
+	mockStore.On("ListSeries", mock.Anything, "task-1").Return([]*domain.Task{done, open}, nil).Once()
+	mockStore.On("UpdateTasks", mock.Anything, []*domain.Task{open}).Return(nil).Once()
+
+	resp, err := service.UpdateTaskSeries(context.Background(), &pb.UpdateTaskSeriesRequest{
+		SeriesId:   "task-1",
+		Task:       &pb.Task{},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"recurrence"}},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Tasks, 1)
+	assert.Empty(t, open.Recurrence)
+	assert.Equal(t, "FREQ=WEEKLY", done.Recurrence)
+	mockStore.AssertExpectations(t)
+}
+
+func TestUpdateTaskSeries_NotFound(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	mockStore.On("ListSeries", mock.Anything, "missing").Return(nil, fmt.Errorf("series: %w", domain.ErrNotFound)).Once()
+
+	_, err := service.UpdateTaskSeries(context.Background(), &pb.UpdateTaskSeriesRequest{
+		SeriesId:   "missing",
+		Task:       &pb.Task{Title: "Renamed"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title"}},
+	})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	mockStore.AssertExpectations(t)
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sahidhossen/todo/storage-service/internal/converters"
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+	"github.com/sahidhossen/todo/storage-service/internal/grpcerr"
+
+	pb "github.com/sahidhossen/todo/proto/task_service"
+)
+
+// ListSubtasks handles the gRPC request to list the direct subtasks of a task.
+func (s *TaskServiceServer) ListSubtasks(ctx context.Context, req *pb.ListSubtasksRequest) (*pb.ListSubtasksResponse, error) {
+	if err := s.validate(ctx, req); err != nil {
+		return nil, err
+	}
+	tasks, err := s.store.ListSubtasks(ctx, req.ParentId)
+	if err != nil {
+		s.logger.WarnContext(ctx, "gRPC: Failed to list subtasks", "parent_id", req.ParentId, "error", err)
+		return nil, storeError(err, req.ParentId, "list subtasks")
+	}
+
+	pbTasks := make([]*pb.Task, len(tasks))
+	for i, task := range tasks {
+		pbTasks[i] = converters.DomainToProtoTask(task)
+	}
+	s.logger.InfoContext(ctx, "gRPC: Listed subtasks", "parent_id", req.ParentId, "count", len(pbTasks))
+	return &pb.ListSubtasksResponse{Tasks: pbTasks}, nil
+}
+
+// parentError maps a *domain.ParentError returned by SaveTask for the task
+// with ID taskID to a gRPC status error. Other errors map to nil.
+func (s *TaskServiceServer) parentError(ctx context.Context, taskID string, err error) error {
+	var perr *domain.ParentError
+	if !errors.As(err, &perr) {
+		return nil
+	}
+	if errors.Is(err, domain.ErrNotFound) {
+		s.logger.WarnContext(ctx, "gRPC: Parent task not found", "parent_id", perr.ParentID)
+		return grpcerr.NotFound("task", perr.ParentID, "parent task with ID %s not found", perr.ParentID)
+	}
+	s.logger.WarnContext(ctx, "gRPC: Invalid parent task", "id", taskID, "parent_id", perr.ParentID, "error", err)
+	return grpcerr.InvalidField("parent_id", "%v", err)
+}
+
+// rollUp sets the completion of an auto-complete task from its subtasks: it
+// is completed when all of them are and open otherwise. Tasks without
+// subtasks are left alone. rollUp reports whether the task changed.
+func (s *TaskServiceServer) rollUp(ctx context.Context, task *domain.Task) (bool, error) {
+	if !task.AutoComplete {
+		return false, nil
+	}
+	children, err := s.store.ListSubtasks(ctx, task.ID)
+	if err != nil || len(children) == 0 {
+		return false, err
+	}
+
+	done := true
+	for _, child := range children {
+		if !child.Completed {
+			done = false
+			break
+		}
+	}
+	if task.Completed == done {
+		return false, nil
+	}
+	if done {
+		task.MarkComplete()
+	} else {
+		task.Reopen()
+	}
+	return true, nil
+}
+
+// rollUpParents applies rollUp to the task with ID parentID and then to its
+// ancestors, for as long as their completion changes. Failures are logged
+// rather than returned: the change that triggered the roll-up has already
+// been saved.
+func (s *TaskServiceServer) rollUpParents(ctx context.Context, parentID string) {
+	for level := 0; parentID != "" && level < domain.MaxTaskDepth; level++ {
+		parent, err := s.store.GetTask(ctx, parentID)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "Failed to load parent task for roll-up", "id", parentID, "error", err)
+			return
+		}
+		changed, err := s.rollUp(ctx, parent)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "Failed to roll up parent task completion", "id", parentID, "error", err)
+			return
+		}
+		if !changed {
+			return
+		}
+		if err := s.store.SaveTask(ctx, parent); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to save rolled up parent task", "id", parentID, "error", err)
+			return
+		}
+		s.logger.InfoContext(ctx, "gRPC: Parent task completion rolled up", "id", parent.ID, "completed", parent.Completed)
+		parentID = parent.ParentID
+	}
+}
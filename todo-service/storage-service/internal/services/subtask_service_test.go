@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	pb "github.com/sahidhossen/todo/proto/task_service"
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+	"github.com/sahidhossen/todo/storage-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestCreateTask_SubtaskInheritsProject(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	parent := &domain.Task{ID: "parent-1", Title: "Parent", ProjectID: "project-1"}
+	mockStore.On("GetTask", mock.Anything, "parent-1").Return(parent, nil).Twice()
+	mockStore.On("GetProject", mock.Anything, "project-1").Return(&domain.Project{ID: "project-1", Name: "Home"}, nil).Once()
+	mockStore.On("SaveTask", mock.Anything, mock.MatchedBy(func(task *domain.Task) bool {
+		return task.ParentID == "parent-1" && task.ProjectID == "project-1"
+	})).Return(nil).Once()
+
+	resp, err := service.CreateTask(context.Background(), &pb.CreateTaskRequest{Title: "Child", ParentId: "parent-1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "project-1", resp.Task.ProjectId)
+	mockStore.AssertExpectations(t)
+}
+
+func TestCreateTask_TooDeep(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	mockStore.On("GetTask", mock.Anything, "parent-1").Return(&domain.Task{ID: "parent-1", Title: "Parent"}, nil).Once()
+	mockStore.On("SaveTask", mock.Anything, mock.AnythingOfType("*domain.Task")).Return(&domain.ParentError{
+		ParentID: "parent-1",
+		Err:      fmt.Errorf("subtasks cannot be nested more than %d levels deep: %w", domain.MaxTaskDepth, domain.ErrInvalidInput),
+	}).Once()
+
+	_, err := service.CreateTask(context.Background(), &pb.CreateTaskRequest{Title: "Child", ParentId: "parent-1"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	mockStore.AssertExpectations(t)
+}
+
+func TestUpdateTask_ParentNotFound(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	mockStore.On("GetTask", mock.Anything, "task-1").Return(&domain.Task{ID: "task-1", Title: "Task"}, nil).Once()
+	mockStore.On("SaveTask", mock.Anything, mock.AnythingOfType("*domain.Task")).Return(&domain.ParentError{
+		ParentID: "missing",
+		Err:      fmt.Errorf("parent task with ID missing not found: %w", domain.ErrNotFound),
+	}).Once()
+
+	_, err := service.UpdateTask(context.Background(), &pb.UpdateTaskRequest{
+		Task:       &pb.Task{Id: "task-1", ParentId: "missing"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"parent_id"}},
+	})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "parent task with ID missing")
+	mockStore.AssertExpectations(t)
+}
+
+func TestCompleteTask_CompletesAutoCompleteParent(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	child := &domain.Task{ID: "child-1", Title: "Child", ParentID: "parent-1"}
+	parent := &domain.Task{ID: "parent-1", Title: "Parent", AutoComplete: true}
+	mockStore.On("GetTask", mock.Anything, "child-1").Return(child, nil).Once()
+	mockStore.On("SaveTask", mock.Anything, child).Return(nil).Once()
+	mockStore.On("GetTask", mock.Anything, "parent-1").Return(parent, nil).Once()
+	mockStore.On("ListSubtasks", mock.Anything, "parent-1").
+		Return([]*domain.Task{child, {ID: "child-2", ParentID: "parent-1", Completed: true}}, nil).Once()
+	mockStore.On("SaveTask", mock.Anything, parent).Return(nil).Once()
+
+	resp, err := service.CompleteTask(context.Background(), &pb.CompleteTaskRequest{Id: "child-1"})
+
+	assert.NoError(t, err)
+	assert.True(t, resp.Task.Completed)
+	assert.True(t, parent.Completed)
+	mockStore.AssertExpectations(t)
+}
+
+func TestGetTask_IncludeChildren(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	tree := &domain.Task{ID: "parent-1", Title: "Parent", Children: []*domain.Task{{ID: "child-1", Title: "Child", ParentID: "parent-1"}}}
+	mockStore.On("GetTaskTree", mock.Anything, "parent-1").Return(tree, nil).Once()
+
+	resp, err := service.GetTask(context.Background(), &pb.GetTaskRequest{Id: "parent-1", IncludeChildren: true})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Task.Children, 1)
+	assert.Equal(t, "parent-1", resp.Task.Children[0].ParentId)
+	mockStore.AssertExpectations(t)
+}
+
+func TestListSubtasks_ParentNotFound(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	mockStore.On("ListSubtasks", mock.Anything, "missing").Return(nil, fmt.Errorf("task: %w", domain.ErrNotFound)).Once()
+
+	_, err := service.ListSubtasks(context.Background(), &pb.ListSubtasksRequest{ParentId: "missing"})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	mockStore.AssertExpectations(t)
+}
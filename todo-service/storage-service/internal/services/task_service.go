@@ -2,13 +2,17 @@ package services
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
 	"github.com/sahidhossen/todo/storage-service/internal/converters"
 	"github.com/sahidhossen/todo/storage-service/internal/domain"
+	"github.com/sahidhossen/todo/storage-service/internal/events"
+	"github.com/sahidhossen/todo/storage-service/internal/grpcerr"
 	"github.com/sahidhossen/todo/storage-service/internal/store"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/sahidhossen/todo/proto/task_service"
 )
@@ -17,9 +21,24 @@ import (
 type TaskServiceServer struct {
 	pb.UnimplementedTaskServiceServer // Must be embedded for forward compatibility
 	store                             store.Store
+	events                            *events.Broker // nil when WatchTasks is not available
 	logger                            *slog.Logger
 }
 
+// updatableTaskFields lists the Task fields that UpdateTask accepts in its field mask.
+var updatableTaskFields = map[string]bool{
+	"title":         true,
+	"description":   true,
+	"completed":     true,
+	"due_at":        true,
+	"all_day":       true,
+	"priority":      true,
+	"project_id":    true,
+	"parent_id":     true,
+	"auto_complete": true,
+	"recurrence":    true,
+}
+
 // NewTaskServiceServer creates a new TaskServiceServer.
 func NewTaskServiceServer(store store.Store, logger *slog.Logger) *TaskServiceServer {
 	if logger == nil {
@@ -31,24 +50,73 @@ func NewTaskServiceServer(store store.Store, logger *slog.Logger) *TaskServiceSe
 	}
 }
 
+// validate refuses req with InvalidArgument when its Validate method fails.
+// Handlers call it themselves: the validation interceptor, which refuses
+// such requests before they reach a handler, can be switched off.
+func (s *TaskServiceServer) validate(ctx context.Context, req interface{ Validate() error }) error {
+	if err := req.Validate(); err != nil {
+		s.logger.WarnContext(ctx, "Invalid request", "error", err)
+		return grpcerr.Invalid(err)
+	}
+	return nil
+}
+
 // CreateTask handles the gRPC request to create a new task.
 func (s *TaskServiceServer) CreateTask(ctx context.Context, req *pb.CreateTaskRequest) (*pb.CreateTaskResponse, error) {
-	if req.Title == "" {
-		s.logger.Warn("CreateTask request missing title")
-		return nil, status.Errorf(codes.InvalidArgument, "title cannot be empty")
+	if err := s.validate(ctx, req); err != nil {
+		return nil, err
+	}
+	if err := checkTimestamp("due_at", req.DueAt); err != nil {
+		return nil, err
 	}
 
 	domainTask := &domain.Task{
-		Title:       req.Title,
-		Description: req.Description,
-		Completed:   false,
+		Title:        req.Title,
+		Description:  req.Description,
+		Completed:    false,
+		DueAt:        converters.OptionalTime(req.DueAt),
+		AllDay:       req.AllDay,
+		Priority:     domain.Priority(req.Priority),
+		ProjectID:    req.ProjectId,
+		ParentID:     req.ParentId,
+		AutoComplete: req.AutoComplete,
+	}
+	if err := setRecurrence(domainTask, req.Recurrence); err != nil {
+		s.logger.WarnContext(ctx, "CreateTask request has invalid recurrence", "recurrence", req.Recurrence, "error", err)
+		return nil, err
+	}
+	if !domainTask.Priority.Valid() {
+		s.logger.WarnContext(ctx, "CreateTask request has unknown priority", "priority", req.Priority)
+		return nil, grpcerr.InvalidField("priority", "unknown priority %d", req.Priority)
+	}
+	if err := domainTask.ValidateDue(); err != nil {
+		s.logger.WarnContext(ctx, "CreateTask request has invalid due date", "error", err)
+		return nil, grpcerr.InvalidField("due_at", "%v", err)
+	}
+	if err := checkRecurringDue(domainTask); err != nil {
+		return nil, err
+	}
+	if domainTask.ParentID != "" && domainTask.ProjectID == "" {
+		parent, err := s.store.GetTask(ctx, domainTask.ParentID)
+		if err != nil {
+			return nil, storeError(err, domainTask.ParentID, "get parent task")
+		}
+		domainTask.ProjectID = parent.ProjectID
+	}
+	if err := s.checkTaskProject(ctx, domainTask.ProjectID); err != nil {
+		return nil, err
 	}
 
 	err := s.store.SaveTask(ctx, domainTask)
+	if perr := s.parentError(ctx, "", err); perr != nil {
+		return nil, perr
+	}
 	if err != nil {
-		s.logger.Error("Failed to save task to store", "error", err)
+		s.logger.ErrorContext(ctx, "Failed to save task to store", "error", err)
 		return nil, status.Errorf(codes.Internal, "failed to save task: %v", err)
 	}
+	// A new open subtask reopens an auto-complete parent.
+	s.rollUpParents(ctx, domainTask.ParentID)
 
 	pbTaskResponse := converters.DomainToProtoTask(domainTask)
 	return &pb.CreateTaskResponse{Task: pbTaskResponse}, nil
@@ -56,65 +124,349 @@ func (s *TaskServiceServer) CreateTask(ctx context.Context, req *pb.CreateTaskRe
 
 // GetTask handles the gRPC request to get a task by ID.
 func (s *TaskServiceServer) GetTask(ctx context.Context, req *pb.GetTaskRequest) (*pb.GetTaskResponse, error) {
-	if req.Id == "" {
-		s.logger.Warn("GetTask request missing ID")
-		return nil, status.Errorf(codes.InvalidArgument, "task ID cannot be empty")
+	if err := s.validate(ctx, req); err != nil {
+		return nil, err
 	}
-
-	task, err := s.store.GetTask(ctx, req.Id)
+	getTask := s.store.GetTask
+	if req.IncludeChildren {
+		getTask = s.store.GetTaskTree
+	}
+	task, err := getTask(ctx, req.Id)
 	if err != nil {
-		s.logger.Warn("gRPC: Task not found", "id", req.Id)
-		return nil, status.Errorf(codes.NotFound, "task with ID %s not found", req.Id)
+		s.logger.WarnContext(ctx, "gRPC: Task not found", "id", req.Id)
+		return nil, grpcerr.NotFound("task", req.Id, "task with ID %s not found", req.Id)
 	}
-	s.logger.Info("gRPC: Task retrieved", "id", task.ID)
+	s.logger.InfoContext(ctx, "gRPC: Task retrieved", "id", task.ID, "include_children", req.IncludeChildren)
 	return &pb.GetTaskResponse{
 		Task: converters.DomainToProtoTask(task),
 	}, nil
 }
 
-// ListTasks handles the gRPC request to list all tasks.
+// ListTasks handles the gRPC request to list one page of tasks.
 func (s *TaskServiceServer) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
-	tasks, err := s.store.ListTasks(ctx)
+	if err := s.validate(ctx, req); err != nil {
+		return nil, err
+	}
+	opts := converters.ProtoToListOptions(req)
+	if len(opts.Labels) > 0 {
+		labels, err := domain.NormalizeLabelNames(opts.Labels)
+		if err != nil {
+			return nil, grpcerr.InvalidField("labels", "%v", err)
+		}
+		opts.Labels = labels
+	}
+	if err := s.checkProject(ctx, opts.ProjectID); err != nil {
+		return nil, err
+	}
+
+	page, err := s.store.ListTasks(ctx, opts)
 	if err != nil {
-		s.logger.Error("Failed to list tasks from store", "error", err)
+		if errors.Is(err, domain.ErrInvalidInput) {
+			s.logger.WarnContext(ctx, "Invalid ListTasks request", "error", err)
+			return nil, grpcerr.InvalidArgument("%v", err)
+		}
+		s.logger.ErrorContext(ctx, "Failed to list tasks from store", "error", err)
 		return nil, status.Errorf(codes.Internal, "failed to list tasks: %v", err)
 	}
-	pbTasks := make([]*pb.Task, len(tasks))
-	for i, task := range tasks {
+	pbTasks := make([]*pb.Task, len(page.Tasks))
+	for i, task := range page.Tasks {
 		pbTasks[i] = converters.DomainToProtoTask(task)
 	}
-	s.logger.Info("gRPC: Listed tasks", "count", len(pbTasks))
-	return &pb.ListTasksResponse{Tasks: pbTasks}, nil
+	s.logger.InfoContext(ctx, "gRPC: Listed tasks", "count", len(pbTasks), "total", page.TotalSize)
+	return &pb.ListTasksResponse{
+		Tasks:         pbTasks,
+		NextPageToken: page.NextPageToken,
+		TotalSize:     page.TotalSize,
+	}, nil
 }
 
-// ToggleTaskCompletion handles the gRPC request to mark a task as completed.
-func (s *TaskServiceServer) ToggleTaskCompletion(ctx context.Context, req *pb.ToggleTaskCompletionRequest) (*pb.ToggleTaskCompletionResponse, error) {
+// SearchTasks handles the gRPC request to run a full-text search over tasks.
+func (s *TaskServiceServer) SearchTasks(ctx context.Context, req *pb.SearchTasksRequest) (*pb.SearchTasksResponse, error) {
+	if err := s.validate(ctx, req); err != nil {
+		return nil, err
+	}
+	page, err := s.store.SearchTasks(ctx, domain.TaskSearchOptions{
+		Query:     req.Query,
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			s.logger.WarnContext(ctx, "Invalid SearchTasks request", "error", err)
+			return nil, grpcerr.InvalidArgument("%v", err)
+		}
+		s.logger.ErrorContext(ctx, "Failed to search tasks in store", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to search tasks: %v", err)
+	}
 
-	s.logger.Info("gRPC: Received ToggleTaskCompletion request", "id", req.Id)
+	results := make([]*pb.SearchResult, len(page.Results))
+	for i, result := range page.Results {
+		results[i] = converters.DomainToProtoSearchResult(result)
+	}
+	s.logger.InfoContext(ctx, "gRPC: Searched tasks", "count", len(results), "total", page.TotalSize)
+	return &pb.SearchTasksResponse{
+		Results:       results,
+		NextPageToken: page.NextPageToken,
+		TotalSize:     page.TotalSize,
+	}, nil
+}
 
-	if req.Id == "" {
-		s.logger.Warn("Task ID missing!")
-		return nil, status.Errorf(codes.InvalidArgument, "task ID cannot be empty")
+// ToggleTaskCompletion handles the gRPC request to mark a task as completed.
+func (s *TaskServiceServer) ToggleTaskCompletion(ctx context.Context, req *pb.ToggleTaskCompletionRequest) (*pb.ToggleTaskCompletionResponse, error) {
+	if err := s.validate(ctx, req); err != nil {
+		return nil, err
 	}
+	s.logger.InfoContext(ctx, "gRPC: Received ToggleTaskCompletion request", "id", req.Id)
 
 	task, err := s.store.ToggleTaskCompletion(ctx, req.Id)
 	if err != nil {
-		s.logger.Warn("gRPC: Task not found for changes", "id", req.Id)
-		return nil, status.Errorf(codes.NotFound, "task with ID %s not found", req.Id)
+		s.logger.WarnContext(ctx, "gRPC: Task not found for changes", "id", req.Id)
+		return nil, storeError(err, req.Id, "toggle task completion")
 	}
+	s.rollUpParents(ctx, task.ParentID)
+	next := s.nextOccurrence(ctx, task)
 
 	return &pb.ToggleTaskCompletionResponse{
-		Task: converters.DomainToProtoTask(task),
+		Task:           converters.DomainToProtoTask(task),
+		NextOccurrence: converters.DomainToProtoTask(next),
+	}, nil
+}
+
+// CompleteTask handles the gRPC request to mark a task as completed.
+// Unlike ToggleTaskCompletion it is idempotent: completing a completed task leaves it unchanged.
+func (s *TaskServiceServer) CompleteTask(ctx context.Context, req *pb.CompleteTaskRequest) (*pb.CompleteTaskResponse, error) {
+	if err := s.validate(ctx, req); err != nil {
+		return nil, err
+	}
+	task, err := s.setCompletion(ctx, req.Id, true)
+	if err != nil {
+		return nil, err
+	}
+	// Also runs for tasks that were completed already, which fills in an
+	// occurrence that failed to be created before.
+	next := s.nextOccurrence(ctx, task)
+	return &pb.CompleteTaskResponse{
+		Task:           converters.DomainToProtoTask(task),
+		NextOccurrence: converters.DomainToProtoTask(next),
 	}, nil
 }
 
+// ReopenTask handles the gRPC request to mark a task as not completed.
+// Like CompleteTask it is idempotent: reopening an open task leaves it unchanged.
+func (s *TaskServiceServer) ReopenTask(ctx context.Context, req *pb.ReopenTaskRequest) (*pb.ReopenTaskResponse, error) {
+	if err := s.validate(ctx, req); err != nil {
+		return nil, err
+	}
+	task, err := s.setCompletion(ctx, req.Id, false)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ReopenTaskResponse{Task: converters.DomainToProtoTask(task)}, nil
+}
+
+// setCompletion moves a task to the requested completion state, saving it only when the state changes.
+func (s *TaskServiceServer) setCompletion(ctx context.Context, id string, completed bool) (*domain.Task, error) {
+	task, err := s.store.GetTask(ctx, id)
+	if err != nil {
+		s.logger.WarnContext(ctx, "gRPC: Task not found for changes", "id", id, "error", err)
+		return nil, storeError(err, id, "get task")
+	}
+
+	if task.Completed == completed {
+		s.logger.InfoContext(ctx, "gRPC: Task completion unchanged", "id", id, "completed", completed)
+		return task, nil
+	}
+
+	if completed {
+		task.MarkComplete()
+	} else {
+		task.Reopen()
+	}
+
+	if err := s.store.SaveTask(ctx, task); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to save task completion", "id", id, "error", err)
+		return nil, storeError(err, id, "update task")
+	}
+	s.rollUpParents(ctx, task.ParentID)
+
+	s.logger.InfoContext(ctx, "gRPC: Task completion changed", "id", id, "completed", completed)
+	return task, nil
+}
+
+// UpdateTask handles the gRPC request to change the fields of a task named in the update mask.
+func (s *TaskServiceServer) UpdateTask(ctx context.Context, req *pb.UpdateTaskRequest) (*pb.UpdateTaskResponse, error) {
+	if err := s.validate(ctx, req); err != nil {
+		return nil, err
+	}
+	id := req.GetTask().GetId()
+	paths := req.GetUpdateMask().GetPaths()
+	maskHas := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		if !updatableTaskFields[path] {
+			return nil, grpcerr.InvalidField("update_mask", "field %q cannot be updated", path)
+		}
+		maskHas[path] = true
+	}
+	if maskHas["due_at"] {
+		if err := checkTimestamp("task.due_at", req.Task.GetDueAt()); err != nil {
+			return nil, err
+		}
+	}
+
+	task, err := s.store.GetTask(ctx, id)
+	if err != nil {
+		s.logger.WarnContext(ctx, "gRPC: Task not found for update", "id", id, "error", err)
+		return nil, storeError(err, id, "get task")
+	}
+	oldParentID := task.ParentID
+
+	for _, path := range paths {
+		switch path {
+		case "title":
+			task.Title = req.Task.GetTitle()
+		case "description":
+			task.Description = req.Task.GetDescription()
+		case "completed":
+			task.Completed = req.Task.GetCompleted()
+		case "due_at":
+			task.DueAt = converters.OptionalTime(req.Task.GetDueAt())
+		case "all_day":
+			task.AllDay = req.Task.GetAllDay()
+		case "priority":
+			task.Priority = domain.Priority(req.Task.GetPriority())
+		case "project_id":
+			task.ProjectID = req.Task.GetProjectId()
+		case "parent_id":
+			task.ParentID = req.Task.GetParentId()
+		case "auto_complete":
+			task.AutoComplete = req.Task.GetAutoComplete()
+		case "recurrence":
+			if err := setRecurrence(task, req.Task.GetRecurrence()); err != nil {
+				return nil, err
+			}
+		}
+	}
+	// Clearing the due date on its own also clears the all-day flag.
+	if maskHas["due_at"] && !maskHas["all_day"] && task.DueAt == nil {
+		task.AllDay = false
+	}
+
+	if task.Title == "" {
+		return nil, grpcerr.InvalidField("task.title", "title cannot be empty")
+	}
+	if !task.Priority.Valid() {
+		return nil, grpcerr.InvalidField("task.priority", "unknown priority %d", task.Priority)
+	}
+	if err := task.ValidateDue(); err != nil {
+		return nil, grpcerr.InvalidField("task.due_at", "%v", err)
+	}
+	if err := checkRecurringDue(task); err != nil {
+		return nil, err
+	}
+	if maskHas["project_id"] {
+		if err := s.checkTaskProject(ctx, task.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+	// Turning on auto-completion applies it right away.
+	if maskHas["auto_complete"] {
+		if _, err := s.rollUp(ctx, task); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to roll up task completion", "id", id, "error", err)
+			return nil, storeError(err, id, "list subtasks")
+		}
+	}
+
+	if err := s.store.SaveTask(ctx, task); err != nil {
+		if perr := s.parentError(ctx, id, err); perr != nil {
+			return nil, perr
+		}
+		s.logger.ErrorContext(ctx, "Failed to update task in store", "id", id, "error", err)
+		return nil, storeError(err, id, "update task")
+	}
+	if task.ParentID != oldParentID {
+		s.rollUpParents(ctx, oldParentID)
+	}
+	s.rollUpParents(ctx, task.ParentID)
+
+	s.logger.InfoContext(ctx, "gRPC: Task updated", "id", id, "fields", paths)
+	return &pb.UpdateTaskResponse{Task: converters.DomainToProtoTask(task)}, nil
+}
+
+// DeleteTask handles the gRPC request to move a task to the trash.
+func (s *TaskServiceServer) DeleteTask(ctx context.Context, req *pb.DeleteTaskRequest) (*pb.DeleteTaskResponse, error) {
+	if err := s.validate(ctx, req); err != nil {
+		return nil, err
+	}
+	task, err := s.store.DeleteTask(ctx, req.Id)
+	if err != nil {
+		s.logger.WarnContext(ctx, "gRPC: Failed to delete task", "id", req.Id, "error", err)
+		return nil, storeError(err, req.Id, "delete task")
+	}
+	// The remaining subtasks of the parent may now all be completed.
+	s.rollUpParents(ctx, task.ParentID)
+
+	s.logger.InfoContext(ctx, "gRPC: Task moved to trash", "id", task.ID)
+	return &pb.DeleteTaskResponse{Task: converters.DomainToProtoTask(task)}, nil
+}
+
+// RestoreTask handles the gRPC request to move a task out of the trash.
+func (s *TaskServiceServer) RestoreTask(ctx context.Context, req *pb.RestoreTaskRequest) (*pb.RestoreTaskResponse, error) {
+	if err := s.validate(ctx, req); err != nil {
+		return nil, err
+	}
+	task, err := s.store.RestoreTask(ctx, req.Id)
+	if err != nil {
+		s.logger.WarnContext(ctx, "gRPC: Failed to restore task", "id", req.Id, "error", err)
+		return nil, trashError(err, req.Id, "restore task")
+	}
+	s.rollUpParents(ctx, task.ParentID)
+
+	s.logger.InfoContext(ctx, "gRPC: Task restored from trash", "id", task.ID)
+	return &pb.RestoreTaskResponse{Task: converters.DomainToProtoTask(task)}, nil
+}
+
+// PurgeTask handles the gRPC request to permanently remove a task from the trash.
+func (s *TaskServiceServer) PurgeTask(ctx context.Context, req *pb.PurgeTaskRequest) (*pb.PurgeTaskResponse, error) {
+	if err := s.validate(ctx, req); err != nil {
+		return nil, err
+	}
+	if err := s.store.PurgeTask(ctx, req.Id); err != nil {
+		s.logger.WarnContext(ctx, "gRPC: Failed to purge task", "id", req.Id, "error", err)
+		return nil, trashError(err, req.Id, "purge task")
+	}
+
+	s.logger.InfoContext(ctx, "gRPC: Task purged", "id", req.Id)
+	return &pb.PurgeTaskResponse{}, nil
+}
+
+// ListTrash handles the gRPC request to list the tasks in the trash.
+func (s *TaskServiceServer) ListTrash(ctx context.Context, req *pb.ListTrashRequest) (*pb.ListTrashResponse, error) {
+	tasks, err := s.store.ListDeletedTasks(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to list deleted tasks from store", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to list trash: %v", err)
+	}
+	pbTasks := make([]*pb.Task, len(tasks))
+	for i, task := range tasks {
+		pbTasks[i] = converters.DomainToProtoTask(task)
+	}
+	s.logger.InfoContext(ctx, "gRPC: Listed trash", "count", len(pbTasks))
+	return &pb.ListTrashResponse{Tasks: pbTasks}, nil
+}
+
 // GetTaskStats implements the gRPC GetTaskStats method.
 func (s *TaskServiceServer) GetTaskStats(ctx context.Context, req *pb.GetTaskStatsRequest) (*pb.GetTaskStatsResponse, error) {
-	s.logger.Info("Received GetTaskStats request")
+	s.logger.InfoContext(ctx, "Received GetTaskStats request", "project_id", req.ProjectId, "leaves_only", req.LeavesOnly)
+	if err := s.checkProject(ctx, req.ProjectId); err != nil {
+		return nil, err
+	}
 
-	stats, err := s.store.GetTaskStats(ctx)
+	stats, err := s.store.GetTaskStats(ctx, domain.TaskStatsOptions{
+		ProjectID:  req.ProjectId,
+		LeavesOnly: req.LeavesOnly,
+	})
 	if err != nil {
-		s.logger.Error("Failed to retrieve task stats from store", "error", err)
+		s.logger.ErrorContext(ctx, "Failed to retrieve task stats from store", "error", err)
 		return nil, status.Errorf(codes.Internal, "failed to retrieve task stats: %v", err)
 	}
 
@@ -122,5 +474,34 @@ func (s *TaskServiceServer) GetTaskStats(ctx context.Context, req *pb.GetTaskSta
 		TotalTasks:     stats.Total,
 		CompletedTasks: stats.Completed,
 		PendingTasks:   stats.Pending,
+		OverdueTasks:   stats.Overdue,
+		DueTodayTasks:  stats.DueToday,
 	}, nil
 }
+
+// checkTimestamp rejects an out-of-range timestamp field. Unset fields are valid.
+func checkTimestamp(field string, ts *timestamppb.Timestamp) error {
+	if ts == nil {
+		return nil
+	}
+	if err := ts.CheckValid(); err != nil {
+		return grpcerr.InvalidField(field, "%s is not a valid timestamp: %v", field, err)
+	}
+	return nil
+}
+
+// storeError maps an error returned by the store to a gRPC status error.
+func storeError(err error, id string, action string) error {
+	if errors.Is(err, domain.ErrNotFound) {
+		return grpcerr.NotFound("task", id, "task with ID %s not found", id)
+	}
+	return status.Errorf(codes.Internal, "failed to %s: %v", action, err)
+}
+
+// trashError is storeError for operations that only apply to tasks in the trash.
+func trashError(err error, id string, action string) error {
+	if errors.Is(err, domain.ErrNotFound) {
+		return grpcerr.NotFound("task", id, "task with ID %s not found in trash", id)
+	}
+	return status.Errorf(codes.Internal, "failed to %s: %v", action, err)
+}
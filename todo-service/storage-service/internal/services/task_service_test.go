@@ -3,16 +3,23 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"os"
 	"testing"
+	"time"
 
 	pb "github.com/sahidhossen/todo/proto/task_service"
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+	"github.com/sahidhossen/todo/storage-service/internal/grpcerr"
 	"github.com/sahidhossen/todo/storage-service/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func NewNopLogger() *slog.Logger {
@@ -61,3 +68,316 @@ func TestCreateTask_StoreError(t *testing.T) {
 	assert.Equal(t, "failed to save task: db write error", s.Message())
 	mockStore.AssertExpectations(t)
 }
+
+func TestCreateTask_AllDayDueDateIsTruncated(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	mockStore.On("SaveTask", mock.Anything, mock.AnythingOfType("*domain.Task")).Return(nil).Once()
+
+	resp, err := service.CreateTask(context.Background(), &pb.CreateTaskRequest{
+		Title:  "Pay rent",
+		DueAt:  timestamppb.New(time.Date(2025, 7, 1, 18, 45, 0, 0, time.UTC)),
+		AllDay: true,
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, resp.Task.AllDay)
+	assert.Equal(t, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), resp.Task.DueAt.AsTime())
+	mockStore.AssertExpectations(t)
+}
+
+func TestCreateTask_InvalidDueDate(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	requests := []*pb.CreateTaskRequest{
+		{Title: "No date", AllDay: true},
+		{Title: "Out of range", DueAt: &timestamppb.Timestamp{Seconds: -1 << 62}},
+	}
+	for _, req := range requests {
+		resp, err := service.CreateTask(context.Background(), req)
+
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), req.Title)
+	}
+	mockStore.AssertNotCalled(t, "SaveTask", mock.Anything, mock.Anything)
+}
+
+func TestCreateTask_UnknownPriority(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	resp, err := service.CreateTask(context.Background(), &pb.CreateTaskRequest{Title: "Ship it", Priority: pb.Priority(42)})
+
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	mockStore.AssertNotCalled(t, "SaveTask", mock.Anything, mock.Anything)
+}
+
+func TestUpdateTask_Priority(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	existing := &domain.Task{ID: "task-1", Title: "Ship it"}
+	mockStore.On("GetTask", mock.Anything, "task-1").Return(existing, nil).Once()
+	mockStore.On("SaveTask", mock.Anything, existing).Return(nil).Once()
+
+	resp, err := service.UpdateTask(context.Background(), &pb.UpdateTaskRequest{
+		Task:       &pb.Task{Id: "task-1", Priority: pb.Priority_PRIORITY_URGENT},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"priority"}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, pb.Priority_PRIORITY_URGENT, resp.Task.Priority)
+	mockStore.AssertExpectations(t)
+}
+
+func TestUpdateTask_ClearingDueDateClearsAllDay(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	due := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	existing := &domain.Task{ID: "task-1", Title: "Pay rent", DueAt: &due, AllDay: true}
+	mockStore.On("GetTask", mock.Anything, "task-1").Return(existing, nil).Once()
+	mockStore.On("SaveTask", mock.Anything, existing).Return(nil).Once()
+
+	resp, err := service.UpdateTask(context.Background(), &pb.UpdateTaskRequest{
+		Task:       &pb.Task{Id: "task-1"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"due_at"}},
+	})
+
+	assert.NoError(t, err)
+	assert.Nil(t, resp.Task.DueAt)
+	assert.False(t, resp.Task.AllDay)
+	mockStore.AssertExpectations(t)
+}
+
+func TestUpdateTask_OnlyMaskedFieldsChange(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	existing := &domain.Task{ID: "task-1", Title: "Old title", Description: "Keep me", Completed: true}
+	mockStore.On("GetTask", mock.Anything, "task-1").Return(existing, nil).Once()
+	mockStore.On("SaveTask", mock.Anything, existing).Return(nil).Once()
+
+	req := &pb.UpdateTaskRequest{
+		Task:       &pb.Task{Id: "task-1", Title: "New title", Description: "ignored", Completed: false},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title"}},
+	}
+
+	resp, err := service.UpdateTask(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "New title", resp.Task.Title)
+	assert.Equal(t, "Keep me", resp.Task.Description)
+	assert.True(t, resp.Task.Completed)
+	mockStore.AssertExpectations(t)
+}
+
+func TestUpdateTask_InvalidMask(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	req := &pb.UpdateTaskRequest{
+		Task:       &pb.Task{Id: "task-1"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"created_at"}},
+	}
+
+	resp, err := service.UpdateTask(context.Background(), req)
+
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	mockStore.AssertNotCalled(t, "GetTask", mock.Anything, mock.Anything)
+}
+
+func TestUpdateTask_NotFound(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	mockStore.On("GetTask", mock.Anything, "missing").Return(nil, fmt.Errorf("task with ID missing not found: %w", domain.ErrNotFound)).Once()
+
+	req := &pb.UpdateTaskRequest{
+		Task:       &pb.Task{Id: "missing", Title: "x"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title"}},
+	}
+
+	resp, err := service.UpdateTask(context.Background(), req)
+
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	if info, ok := grpcerr.Detail[*errdetails.ErrorInfo](err); assert.True(t, ok, "ErrorInfo detail") {
+		assert.Equal(t, "TASK_NOT_FOUND", info.Reason)
+	}
+	if resource, ok := grpcerr.Detail[*errdetails.ResourceInfo](err); assert.True(t, ok, "ResourceInfo detail") {
+		assert.Equal(t, "missing", resource.ResourceName)
+	}
+	mockStore.AssertExpectations(t)
+}
+
+func TestDeleteTask_Success(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	deletedAt := time.Now()
+	mockStore.On("DeleteTask", mock.Anything, "task-1").Return(&domain.Task{ID: "task-1", Title: "Trash me", DeletedAt: &deletedAt}, nil).Once()
+
+	resp, err := service.DeleteTask(context.Background(), &pb.DeleteTaskRequest{Id: "task-1"})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, resp.Task.DeletedAt)
+	mockStore.AssertExpectations(t)
+}
+
+func TestRestoreTask_NotInTrash(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	mockStore.On("RestoreTask", mock.Anything, "task-1").Return(nil, fmt.Errorf("task with ID task-1 not found in trash: %w", domain.ErrNotFound)).Once()
+
+	resp, err := service.RestoreTask(context.Background(), &pb.RestoreTaskRequest{Id: "task-1"})
+
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	mockStore.AssertExpectations(t)
+}
+
+func TestCompleteTask_MarksOpenTaskComplete(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	task := &domain.Task{ID: "task-1", Title: "Open", Completed: false}
+	mockStore.On("GetTask", mock.Anything, "task-1").Return(task, nil).Once()
+	mockStore.On("SaveTask", mock.Anything, task).Return(nil).Once()
+
+	resp, err := service.CompleteTask(context.Background(), &pb.CompleteTaskRequest{Id: "task-1"})
+
+	assert.NoError(t, err)
+	assert.True(t, resp.Task.Completed)
+	mockStore.AssertExpectations(t)
+}
+
+func TestCompleteTask_IdempotentOnRetry(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	task := &domain.Task{ID: "task-1", Title: "Done", Completed: true}
+	mockStore.On("GetTask", mock.Anything, "task-1").Return(task, nil).Once()
+
+	resp, err := service.CompleteTask(context.Background(), &pb.CompleteTaskRequest{Id: "task-1"})
+
+	assert.NoError(t, err)
+	assert.True(t, resp.Task.Completed)
+	mockStore.AssertNotCalled(t, "SaveTask", mock.Anything, mock.Anything)
+	mockStore.AssertExpectations(t)
+}
+
+func TestReopenTask_IdempotentOnRetry(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	task := &domain.Task{ID: "task-1", Title: "Open", Completed: false}
+	mockStore.On("GetTask", mock.Anything, "task-1").Return(task, nil).Once()
+
+	resp, err := service.ReopenTask(context.Background(), &pb.ReopenTaskRequest{Id: "task-1"})
+
+	assert.NoError(t, err)
+	assert.False(t, resp.Task.Completed)
+	mockStore.AssertNotCalled(t, "SaveTask", mock.Anything, mock.Anything)
+}
+
+func TestListTasks_PassesOptionsAndReturnsPage(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	completed := true
+	expectedOpts := domain.TaskListOptions{PageSize: 2, PageToken: "abc", Completed: &completed, OrderBy: "title"}
+	page := &domain.TaskPage{
+		Tasks:         []*domain.Task{{ID: "1", Title: "a"}, {ID: "2", Title: "b"}},
+		NextPageToken: "next",
+		TotalSize:     5,
+	}
+	mockStore.On("ListTasks", mock.Anything, expectedOpts).Return(page, nil).Once()
+
+	resp, err := service.ListTasks(context.Background(), &pb.ListTasksRequest{
+		PageSize: 2, PageToken: "abc", Completed: &completed, OrderBy: "title",
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Tasks, 2)
+	assert.Equal(t, "next", resp.NextPageToken)
+	assert.Equal(t, int32(5), resp.TotalSize)
+	mockStore.AssertExpectations(t)
+}
+
+func TestListTasks_InvalidOrderBy(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	mockStore.On("ListTasks", mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("cannot order by %q: %w", "color", domain.ErrInvalidInput)).Once()
+
+	resp, err := service.ListTasks(context.Background(), &pb.ListTasksRequest{OrderBy: "color"})
+
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestSearchTasks_EmptyQuery(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	resp, err := service.SearchTasks(context.Background(), &pb.SearchTasksRequest{Query: "   "})
+
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	mockStore.AssertNotCalled(t, "SearchTasks", mock.Anything, mock.Anything)
+}
+
+func TestSearchTasks_Success(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	page := &domain.TaskSearchPage{
+		Results: []*domain.TaskSearchResult{{
+			Task:           &domain.Task{ID: "1", Title: "Buy milk"},
+			Score:          -1.5,
+			TitleHighlight: "Buy <mark>milk</mark>",
+		}},
+		TotalSize: 1,
+	}
+	mockStore.On("SearchTasks", mock.Anything, domain.TaskSearchOptions{Query: "milk", PageSize: 10}).Return(page, nil).Once()
+
+	resp, err := service.SearchTasks(context.Background(), &pb.SearchTasksRequest{Query: "milk", PageSize: 10})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Results, 1)
+	assert.Equal(t, "Buy <mark>milk</mark>", resp.Results[0].TitleHighlight)
+	assert.Equal(t, int32(1), resp.TotalSize)
+	mockStore.AssertExpectations(t)
+}
+
+func TestToggleTaskCompletion_Success(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	mockStore.On("ToggleTaskCompletion", mock.Anything, "task-1").Return(&domain.Task{ID: "task-1", Title: "Task", Completed: true}, nil).Once()
+
+	resp, err := service.ToggleTaskCompletion(context.Background(), &pb.ToggleTaskCompletionRequest{Id: "task-1"})
+
+	assert.NoError(t, err)
+	assert.True(t, resp.Task.Completed)
+	mockStore.AssertExpectations(t)
+}
+
+func TestToggleTaskCompletion_NotFound(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	mockStore.On("ToggleTaskCompletion", mock.Anything, "missing").Return(nil, fmt.Errorf("task: %w", domain.ErrNotFound)).Once()
+
+	_, err := service.ToggleTaskCompletion(context.Background(), &pb.ToggleTaskCompletionRequest{Id: "missing"})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	mockStore.AssertExpectations(t)
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sahidhossen/todo/storage-service/internal/auth"
+	"github.com/sahidhossen/todo/storage-service/internal/converters"
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+	"github.com/sahidhossen/todo/storage-service/internal/grpcerr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sahidhossen/todo/proto/task_service"
+)
+
+// Register handles the gRPC request to create a user account.
+func (s *TaskServiceServer) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
+	email, err := domain.NormalizeEmail(req.Email)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Invalid Register request", "error", err)
+		return nil, grpcerr.InvalidField("email", "%v", err)
+	}
+	hash, err := auth.HashPassword(req.Password)
+	if errors.Is(err, domain.ErrInvalidInput) {
+		s.logger.WarnContext(ctx, "Invalid Register request", "error", err)
+		return nil, grpcerr.InvalidField("password", "%v", err)
+	}
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: Failed to hash password", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to register user: %v", err)
+	}
+
+	user := &domain.User{Email: email, PasswordHash: hash}
+	if err := s.store.CreateUser(ctx, user); err != nil {
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			s.logger.WarnContext(ctx, "gRPC: Email already registered")
+			return nil, grpcerr.AlreadyExists("user", "", "a user with that email already exists")
+		}
+		s.logger.ErrorContext(ctx, "gRPC: Failed to create user", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to register user: %v", err)
+	}
+
+	s.logger.InfoContext(ctx, "gRPC: User registered", "id", user.ID)
+	return &pb.RegisterResponse{User: converters.DomainToProtoUser(user)}, nil
+}
+
+// Login handles the gRPC request to check a user's password. Unknown users
+// and wrong passwords get the same error.
+func (s *TaskServiceServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
+	user, err := s.store.GetUserByEmail(ctx, req.Email)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		s.logger.ErrorContext(ctx, "gRPC: Failed to get user", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to log in: %v", err)
+	}
+
+	var hash string
+	if user != nil {
+		hash = user.PasswordHash
+	}
+	ok, err := auth.CheckPassword(hash, req.Password)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "gRPC: Failed to check password", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to log in: %v", err)
+	}
+	if !ok {
+		s.logger.WarnContext(ctx, "gRPC: Login failed")
+		return nil, grpcerr.New(codes.Unauthenticated, grpcerr.ReasonInvalidCredentials, nil, "invalid email or password")
+	}
+
+	s.logger.InfoContext(ctx, "gRPC: User logged in", "id", user.ID)
+	return &pb.LoginResponse{User: converters.DomainToProtoUser(user)}, nil
+}
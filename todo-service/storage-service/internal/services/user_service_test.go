@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	pb "github.com/sahidhossen/todo/proto/task_service"
+	"github.com/sahidhossen/todo/storage-service/internal/auth"
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+	"github.com/sahidhossen/todo/storage-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRegister_Success(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	mockStore.On("CreateUser", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.Email == "ada@example.com" && u.PasswordHash != "" && u.PasswordHash != "correct horse"
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.User).ID = "user-1"
+	}).Return(nil).Once()
+
+	resp, err := service.Register(context.Background(), &pb.RegisterRequest{Email: " ada@example.com ", Password: "correct horse"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", resp.User.Id)
+	assert.Equal(t, "ada@example.com", resp.User.Email)
+	mockStore.AssertExpectations(t)
+}
+
+func TestRegister_InvalidInput(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	_, err := service.Register(context.Background(), &pb.RegisterRequest{Email: "not an email", Password: "correct horse"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = service.Register(context.Background(), &pb.RegisterRequest{Email: "ada@example.com", Password: "short"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	mockStore.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+}
+
+func TestRegister_EmailTaken(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	mockStore.On("CreateUser", mock.Anything, mock.Anything).Return(fmt.Errorf("user: %w", domain.ErrAlreadyExists)).Once()
+
+	_, err := service.Register(context.Background(), &pb.RegisterRequest{Email: "ada@example.com", Password: "correct horse"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestLogin(t *testing.T) {
+	mockStore := new(mocks.MockStore)
+	service := NewTaskServiceServer(mockStore, NewNopLogger())
+
+	hash, err := auth.HashPassword("correct horse")
+	require.NoError(t, err)
+	user := &domain.User{ID: "user-1", Email: "ada@example.com", PasswordHash: hash}
+	mockStore.On("GetUserByEmail", mock.Anything, "ada@example.com").Return(user, nil)
+	mockStore.On("GetUserByEmail", mock.Anything, "bob@example.com").Return(nil, fmt.Errorf("user: %w", domain.ErrNotFound))
+
+	resp, err := service.Login(context.Background(), &pb.LoginRequest{Email: "ada@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", resp.User.Id)
+
+	_, wrongPassword := service.Login(context.Background(), &pb.LoginRequest{Email: "ada@example.com", Password: "wrong horse"})
+	_, unknownUser := service.Login(context.Background(), &pb.LoginRequest{Email: "bob@example.com", Password: "correct horse"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(wrongPassword))
+	assert.Equal(t, status.Convert(wrongPassword).Message(), status.Convert(unknownUser).Message())
+}
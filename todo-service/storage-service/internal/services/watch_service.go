@@ -0,0 +1,80 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/sahidhossen/todo/storage-service/internal/auth"
+	"github.com/sahidhossen/todo/storage-service/internal/converters"
+	"github.com/sahidhossen/todo/storage-service/internal/events"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sahidhossen/todo/proto/task_service"
+)
+
+// WithEvents enables WatchTasks, which streams the events published to broker.
+func (s *TaskServiceServer) WithEvents(broker *events.Broker) *TaskServiceServer {
+	s.events = broker
+	return s
+}
+
+// WatchTasks handles the gRPC request to stream the events of the caller's
+// tasks. Buffered events after req.AfterSequence are sent before live ones.
+func (s *TaskServiceServer) WatchTasks(req *pb.WatchTasksRequest, stream grpc.ServerStreamingServer[pb.TaskEvent]) error {
+	if s.events == nil {
+		return status.Errorf(codes.Unimplemented, "task events are not enabled")
+	}
+	userID, err := auth.UserID(stream.Context())
+	if err != nil {
+		return status.Errorf(codes.Unauthenticated, "%v", err)
+	}
+
+	sub, missed, err := s.events.Subscribe(req.AfterSequence)
+	switch {
+	case errors.Is(err, events.ErrSequenceExpired):
+		s.logger.WarnContext(stream.Context(), "gRPC: Task events expired for watcher", "after_sequence", req.AfterSequence)
+		return status.Errorf(codes.OutOfRange, "events after sequence %d are no longer available", req.AfterSequence)
+	case errors.Is(err, events.ErrClosed):
+		return status.Errorf(codes.Unavailable, "server is shutting down")
+	case err != nil:
+		return status.Errorf(codes.Internal, "failed to watch tasks: %v", err)
+	}
+	defer sub.Close()
+	s.logger.InfoContext(stream.Context(), "gRPC: Task watcher connected", "after_sequence", req.AfterSequence, "missed", len(missed))
+
+	last := req.AfterSequence
+	for _, event := range missed {
+		if event.Task.OwnerID != userID {
+			continue
+		}
+		if err := stream.Send(converters.EventToProto(event)); err != nil {
+			return err
+		}
+		last = event.Sequence
+	}
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.InfoContext(stream.Context(), "gRPC: Task watcher disconnected", "last_sequence", last)
+			return status.FromContextError(ctx.Err()).Err()
+		case event, ok := <-sub.Events():
+			if !ok {
+				if sub.Lagged() {
+					s.logger.WarnContext(stream.Context(), "gRPC: Task watcher fell behind", "last_sequence", last)
+					return status.Errorf(codes.ResourceExhausted, "watcher fell behind; resume after sequence %d", last)
+				}
+				return status.Errorf(codes.Unavailable, "server is shutting down")
+			}
+			if event.Task.OwnerID != userID {
+				continue
+			}
+			if err := stream.Send(converters.EventToProto(event)); err != nil {
+				return err
+			}
+			last = event.Sequence
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/sahidhossen/todo/proto/task_service"
+	"github.com/sahidhossen/todo/storage-service/internal/auth"
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+	"github.com/sahidhossen/todo/storage-service/internal/events"
+	"github.com/sahidhossen/todo/storage-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeWatchStream records the events sent on a WatchTasks stream.
+type fakeWatchStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *pb.TaskEvent
+}
+
+func (f *fakeWatchStream) Context() context.Context { return f.ctx }
+
+func (f *fakeWatchStream) Send(event *pb.TaskEvent) error {
+	f.sent <- event
+	return nil
+}
+
+func TestWatchTasks_ReplaysAndStreams(t *testing.T) {
+	broker := events.NewBroker(10, NewNopLogger())
+	service := NewTaskServiceServer(new(mocks.MockStore), NewNopLogger()).WithEvents(broker)
+
+	broker.Publish(events.TypeCreated, &domain.Task{ID: "task-1", OwnerID: "user-1"})
+	seen := broker.LastSequence()
+	broker.Publish(events.TypeCompleted, &domain.Task{ID: "task-1", OwnerID: "user-1", Completed: true})
+
+	ctx, cancel := context.WithCancel(auth.WithUserID(context.Background(), "user-1"))
+	stream := &fakeWatchStream{ctx: ctx, sent: make(chan *pb.TaskEvent, 10)}
+	done := make(chan error)
+	go func() { done <- service.WatchTasks(&pb.WatchTasksRequest{AfterSequence: seen}, stream) }()
+
+	replayed := <-stream.sent
+	assert.Equal(t, pb.TaskEvent_TYPE_COMPLETED, replayed.Type)
+	assert.Equal(t, seen+1, replayed.Sequence)
+
+	// Wait until the watcher has subscribed before publishing a live event.
+	assert.Eventually(t, func() bool {
+		broker.Publish(events.TypeDeleted, &domain.Task{ID: "task-1", OwnerID: "user-1"})
+		select {
+		case live := <-stream.sent:
+			return live.Type == pb.TaskEvent_TYPE_DELETED
+		case <-time.After(10 * time.Millisecond):
+			return false
+		}
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	assert.Equal(t, codes.Canceled, status.Code(<-done))
+}
+
+func TestWatchTasks_ExpiredSequence(t *testing.T) {
+	broker := events.NewBroker(10, NewNopLogger())
+	service := NewTaskServiceServer(new(mocks.MockStore), NewNopLogger()).WithEvents(broker)
+
+	stream := &fakeWatchStream{ctx: auth.WithUserID(context.Background(), "user-1"), sent: make(chan *pb.TaskEvent, 1)}
+	err := service.WatchTasks(&pb.WatchTasksRequest{AfterSequence: 1}, stream)
+
+	assert.Equal(t, codes.OutOfRange, status.Code(err))
+}
+
+func TestWatchTasks_EndsOnShutdown(t *testing.T) {
+	broker := events.NewBroker(10, NewNopLogger())
+	service := NewTaskServiceServer(new(mocks.MockStore), NewNopLogger()).WithEvents(broker)
+
+	stream := &fakeWatchStream{ctx: auth.WithUserID(context.Background(), "user-1"), sent: make(chan *pb.TaskEvent, 1)}
+	done := make(chan error)
+	go func() { done <- service.WatchTasks(&pb.WatchTasksRequest{}, stream) }()
+
+	assert.Eventually(t, func() bool {
+		broker.Close()
+		select {
+		case err := <-done:
+			return status.Code(err) == codes.Unavailable
+		case <-time.After(10 * time.Millisecond):
+			return false
+		}
+	}, time.Second, time.Millisecond)
+}
+
+func TestWatchTasks_OnlyCallersTasks(t *testing.T) {
+	broker := events.NewBroker(10, NewNopLogger())
+	service := NewTaskServiceServer(new(mocks.MockStore), NewNopLogger()).WithEvents(broker)
+
+	ctx, cancel := context.WithCancel(auth.WithUserID(context.Background(), "user-1"))
+	stream := &fakeWatchStream{ctx: ctx, sent: make(chan *pb.TaskEvent, 100)}
+	done := make(chan error)
+	go func() { done <- service.WatchTasks(&pb.WatchTasksRequest{}, stream) }()
+
+	assert.Eventually(t, func() bool {
+		broker.Publish(events.TypeCreated, &domain.Task{ID: "task-2", OwnerID: "user-2"})
+		broker.Publish(events.TypeCreated, &domain.Task{ID: "task-1", OwnerID: "user-1"})
+		select {
+		case live := <-stream.sent:
+			return assert.Equal(t, "task-1", live.Task.Id)
+		case <-time.After(10 * time.Millisecond):
+			return false
+		}
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	<-done
+	close(stream.sent)
+	for event := range stream.sent {
+		assert.Equal(t, "task-1", event.Task.Id)
+	}
+}
+
+func TestWatchTasks_Unauthenticated(t *testing.T) {
+	broker := events.NewBroker(10, NewNopLogger())
+	service := NewTaskServiceServer(new(mocks.MockStore), NewNopLogger()).WithEvents(broker)
+
+	stream := &fakeWatchStream{ctx: context.Background(), sent: make(chan *pb.TaskEvent, 1)}
+	err := service.WatchTasks(&pb.WatchTasksRequest{}, stream)
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
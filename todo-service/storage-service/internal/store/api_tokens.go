@@ -0,0 +1,125 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sahidhossen/todo/storage-service/internal/auth"
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+)
+
+// lastUsedResolution is how stale an API token's last-used time may get
+// before UseApiToken writes it again, sparing a write on every request.
+const lastUsedResolution = time.Minute
+
+// apiTokenColumns lists the api_tokens columns scanApiToken reads.
+const apiTokenColumns = `id, owner_id, name, scopes, created_at, expires_at, last_used_at`
+
+// CreateApiToken adds an API token for the caller, stored by its hash.
+func (s *SQLiteStore) CreateApiToken(ctx context.Context, token *domain.ApiToken, hash string) error {
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return err
+	}
+	token.ID = uuid.New().String()
+	token.OwnerID = ownerID
+	token.CreatedAt = time.Now()
+	query := `INSERT INTO api_tokens (id, owner_id, name, token_hash, scopes, created_at, expires_at) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	if _, err := s.db.ExecContext(ctx, query, token.ID, ownerID, token.Name, hash, strings.Join(token.Scopes, ","), token.CreatedAt, token.ExpiresAt); err != nil {
+		return fmt.Errorf("failed to insert API token: %w", err)
+	}
+	s.logger.DebugContext(ctx, "API token inserted", "id", token.ID)
+	return nil
+}
+
+// ListApiTokens retrieves the caller's API tokens, newest first.
+func (s *SQLiteStore) ListApiTokens(ctx context.Context) ([]*domain.ApiToken, error) {
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + apiTokenColumns + ` FROM api_tokens WHERE owner_id = ? ORDER BY created_at DESC`
+	rows, err := s.db.QueryContext(ctx, query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []*domain.ApiToken
+	for rows.Next() {
+		token, err := scanApiToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan API token row: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+	return tokens, nil
+}
+
+// RevokeApiToken deletes one of the caller's API tokens.
+func (s *SQLiteStore) RevokeApiToken(ctx context.Context, id string) error {
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := s.db.ExecContext(ctx, `DELETE FROM api_tokens WHERE id = ? AND owner_id = ?`, id, ownerID)
+	if err != nil {
+		return fmt.Errorf("failed to delete API token: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("API token with ID %s not found: %w", id, domain.ErrNotFound)
+	}
+	s.logger.DebugContext(ctx, "API token deleted", "id", id)
+	return nil
+}
+
+// UseApiToken retrieves the API token with the given hash, whoever owns it,
+// and records that it was used at now. Expired tokens are returned as well,
+// without recording their use; callers check ApiToken.Expired.
+func (s *SQLiteStore) UseApiToken(ctx context.Context, hash string, now time.Time) (*domain.ApiToken, error) {
+	query := `SELECT ` + apiTokenColumns + ` FROM api_tokens WHERE token_hash = ?`
+	token, err := scanApiToken(s.db.QueryRowContext(ctx, query, hash))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("API token: %w", domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API token: %w", err)
+	}
+
+	if !token.Expired(now) && (token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= lastUsedResolution) {
+		if _, err := s.db.ExecContext(ctx, `UPDATE api_tokens SET last_used_at = ? WHERE id = ?`, now, token.ID); err != nil {
+			return nil, fmt.Errorf("failed to record API token use: %w", err)
+		}
+		token.LastUsedAt = &now
+	}
+	return token, nil
+}
+
+// scanApiToken reads the apiTokenColumns of one row.
+func scanApiToken(row rowScanner) (*domain.ApiToken, error) {
+	token := &domain.ApiToken{}
+	var scopes string
+	var expiresAt, lastUsedAt sql.NullTime
+	if err := row.Scan(&token.ID, &token.OwnerID, &token.Name, &scopes, &token.CreatedAt, &expiresAt, &lastUsedAt); err != nil {
+		return nil, err
+	}
+	token.Scopes = strings.Split(scopes, ",")
+	if expiresAt.Valid {
+		token.ExpiresAt = &expiresAt.Time
+	}
+	if lastUsedAt.Valid {
+		token.LastUsedAt = &lastUsedAt.Time
+	}
+	return token, nil
+}
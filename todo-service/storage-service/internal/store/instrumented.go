@@ -0,0 +1,244 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// QueryObserver is told how long each Store method call took.
+type QueryObserver interface {
+	ObserveQuery(method string, duration time.Duration, err error)
+}
+
+// instrumentedStore is a Store tracing its calls and reporting them to a
+// QueryObserver.
+type instrumentedStore struct {
+	store    Store
+	observer QueryObserver
+	tracer   trace.Tracer
+}
+
+// Instrument returns a Store passing calls on to store. Each call runs in a
+// child span of the caller's, from the global tracer provider, and its
+// method, duration and error are reported to observer.
+func Instrument(store Store, observer QueryObserver) Store {
+	return &instrumentedStore{
+		store:    store,
+		observer: observer,
+		tracer:   otel.Tracer("github.com/sahidhossen/todo/storage-service/internal/store"),
+	}
+}
+
+// begin starts the span for a call to method. The returned function ends it
+// and reports the call; it is deferred with a pointer to the call's error.
+func (s *instrumentedStore) begin(ctx context.Context, method string) (context.Context, func(*error)) {
+	start := time.Now()
+	ctx, span := s.tracer.Start(ctx, "store."+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system.name", "sqlite"),
+			attribute.String("db.operation.name", method),
+		))
+	return ctx, func(err *error) {
+		// A missing row is an answer, not a failed query.
+		if *err != nil && !errors.Is(*err, domain.ErrNotFound) {
+			span.RecordError(*err)
+			span.SetStatus(codes.Error, (*err).Error())
+		}
+		span.End()
+		s.observer.ObserveQuery(method, time.Since(start), *err)
+	}
+}
+
+func (s *instrumentedStore) SaveTask(ctx context.Context, task *domain.Task) (err error) {
+	ctx, end := s.begin(ctx, "SaveTask")
+	defer end(&err)
+	return s.store.SaveTask(ctx, task)
+}
+
+func (s *instrumentedStore) UpdateTasks(ctx context.Context, tasks []*domain.Task) (err error) {
+	ctx, end := s.begin(ctx, "UpdateTasks")
+	defer end(&err)
+	return s.store.UpdateTasks(ctx, tasks)
+}
+
+func (s *instrumentedStore) GetTask(ctx context.Context, id string) (_ *domain.Task, err error) {
+	ctx, end := s.begin(ctx, "GetTask")
+	defer end(&err)
+	return s.store.GetTask(ctx, id)
+}
+
+func (s *instrumentedStore) ListTasks(ctx context.Context, opts domain.TaskListOptions) (_ *domain.TaskPage, err error) {
+	ctx, end := s.begin(ctx, "ListTasks")
+	defer end(&err)
+	return s.store.ListTasks(ctx, opts)
+}
+
+func (s *instrumentedStore) SearchTasks(ctx context.Context, opts domain.TaskSearchOptions) (_ *domain.TaskSearchPage, err error) {
+	ctx, end := s.begin(ctx, "SearchTasks")
+	defer end(&err)
+	return s.store.SearchTasks(ctx, opts)
+}
+
+func (s *instrumentedStore) ToggleTaskCompletion(ctx context.Context, id string) (_ *domain.Task, err error) {
+	ctx, end := s.begin(ctx, "ToggleTaskCompletion")
+	defer end(&err)
+	return s.store.ToggleTaskCompletion(ctx, id)
+}
+
+func (s *instrumentedStore) GetTaskStats(ctx context.Context, opts domain.TaskStatsOptions) (_ *domain.TaskStats, err error) {
+	ctx, end := s.begin(ctx, "GetTaskStats")
+	defer end(&err)
+	return s.store.GetTaskStats(ctx, opts)
+}
+
+func (s *instrumentedStore) DeleteTask(ctx context.Context, id string) (_ *domain.Task, err error) {
+	ctx, end := s.begin(ctx, "DeleteTask")
+	defer end(&err)
+	return s.store.DeleteTask(ctx, id)
+}
+
+func (s *instrumentedStore) RestoreTask(ctx context.Context, id string) (_ *domain.Task, err error) {
+	ctx, end := s.begin(ctx, "RestoreTask")
+	defer end(&err)
+	return s.store.RestoreTask(ctx, id)
+}
+
+func (s *instrumentedStore) PurgeTask(ctx context.Context, id string) (err error) {
+	ctx, end := s.begin(ctx, "PurgeTask")
+	defer end(&err)
+	return s.store.PurgeTask(ctx, id)
+}
+
+func (s *instrumentedStore) ListDeletedTasks(ctx context.Context) (_ []*domain.Task, err error) {
+	ctx, end := s.begin(ctx, "ListDeletedTasks")
+	defer end(&err)
+	return s.store.ListDeletedTasks(ctx)
+}
+
+func (s *instrumentedStore) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (_ int64, err error) {
+	ctx, end := s.begin(ctx, "PurgeDeletedBefore")
+	defer end(&err)
+	return s.store.PurgeDeletedBefore(ctx, cutoff)
+}
+
+func (s *instrumentedStore) ListSubtasks(ctx context.Context, parentID string) (_ []*domain.Task, err error) {
+	ctx, end := s.begin(ctx, "ListSubtasks")
+	defer end(&err)
+	return s.store.ListSubtasks(ctx, parentID)
+}
+
+func (s *instrumentedStore) GetTaskTree(ctx context.Context, id string) (_ *domain.Task, err error) {
+	ctx, end := s.begin(ctx, "GetTaskTree")
+	defer end(&err)
+	return s.store.GetTaskTree(ctx, id)
+}
+
+func (s *instrumentedStore) ListSeries(ctx context.Context, seriesID string) (_ []*domain.Task, err error) {
+	ctx, end := s.begin(ctx, "ListSeries")
+	defer end(&err)
+	return s.store.ListSeries(ctx, seriesID)
+}
+
+func (s *instrumentedStore) CreateLabel(ctx context.Context, name string) (_ *domain.Label, err error) {
+	ctx, end := s.begin(ctx, "CreateLabel")
+	defer end(&err)
+	return s.store.CreateLabel(ctx, name)
+}
+
+func (s *instrumentedStore) ListLabels(ctx context.Context) (_ []*domain.Label, err error) {
+	ctx, end := s.begin(ctx, "ListLabels")
+	defer end(&err)
+	return s.store.ListLabels(ctx)
+}
+
+func (s *instrumentedStore) RenameLabel(ctx context.Context, id, name string) (_ *domain.Label, err error) {
+	ctx, end := s.begin(ctx, "RenameLabel")
+	defer end(&err)
+	return s.store.RenameLabel(ctx, id, name)
+}
+
+func (s *instrumentedStore) DeleteLabel(ctx context.Context, id string) (err error) {
+	ctx, end := s.begin(ctx, "DeleteLabel")
+	defer end(&err)
+	return s.store.DeleteLabel(ctx, id)
+}
+
+func (s *instrumentedStore) AddLabels(ctx context.Context, taskID string, names []string) (_ *domain.Task, err error) {
+	ctx, end := s.begin(ctx, "AddLabels")
+	defer end(&err)
+	return s.store.AddLabels(ctx, taskID, names)
+}
+
+func (s *instrumentedStore) RemoveLabels(ctx context.Context, taskID string, names []string) (_ *domain.Task, err error) {
+	ctx, end := s.begin(ctx, "RemoveLabels")
+	defer end(&err)
+	return s.store.RemoveLabels(ctx, taskID, names)
+}
+
+func (s *instrumentedStore) SaveProject(ctx context.Context, project *domain.Project) (err error) {
+	ctx, end := s.begin(ctx, "SaveProject")
+	defer end(&err)
+	return s.store.SaveProject(ctx, project)
+}
+
+func (s *instrumentedStore) GetProject(ctx context.Context, id string) (_ *domain.Project, err error) {
+	ctx, end := s.begin(ctx, "GetProject")
+	defer end(&err)
+	return s.store.GetProject(ctx, id)
+}
+
+func (s *instrumentedStore) ListProjects(ctx context.Context, includeArchived bool) (_ []*domain.Project, err error) {
+	ctx, end := s.begin(ctx, "ListProjects")
+	defer end(&err)
+	return s.store.ListProjects(ctx, includeArchived)
+}
+
+func (s *instrumentedStore) DeleteProject(ctx context.Context, id string) (err error) {
+	ctx, end := s.begin(ctx, "DeleteProject")
+	defer end(&err)
+	return s.store.DeleteProject(ctx, id)
+}
+
+func (s *instrumentedStore) CreateUser(ctx context.Context, user *domain.User) (err error) {
+	ctx, end := s.begin(ctx, "CreateUser")
+	defer end(&err)
+	return s.store.CreateUser(ctx, user)
+}
+
+func (s *instrumentedStore) GetUserByEmail(ctx context.Context, email string) (_ *domain.User, err error) {
+	ctx, end := s.begin(ctx, "GetUserByEmail")
+	defer end(&err)
+	return s.store.GetUserByEmail(ctx, email)
+}
+
+func (s *instrumentedStore) CreateApiToken(ctx context.Context, token *domain.ApiToken, hash string) (err error) {
+	ctx, end := s.begin(ctx, "CreateApiToken")
+	defer end(&err)
+	return s.store.CreateApiToken(ctx, token, hash)
+}
+
+func (s *instrumentedStore) ListApiTokens(ctx context.Context) (_ []*domain.ApiToken, err error) {
+	ctx, end := s.begin(ctx, "ListApiTokens")
+	defer end(&err)
+	return s.store.ListApiTokens(ctx)
+}
+
+func (s *instrumentedStore) RevokeApiToken(ctx context.Context, id string) (err error) {
+	ctx, end := s.begin(ctx, "RevokeApiToken")
+	defer end(&err)
+	return s.store.RevokeApiToken(ctx, id)
+}
+
+func (s *instrumentedStore) UseApiToken(ctx context.Context, hash string, now time.Time) (_ *domain.ApiToken, err error) {
+	ctx, end := s.begin(ctx, "UseApiToken")
+	defer end(&err)
+	return s.store.UseApiToken(ctx, hash, now)
+}
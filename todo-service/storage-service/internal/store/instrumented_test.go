@@ -0,0 +1,76 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// fakeStore answers GetTask with the task "t1" and ErrNotFound otherwise,
+// and fails ListTasks. Other methods are not implemented.
+type fakeStore struct {
+	Store
+	spans []trace.SpanContext
+}
+
+func (f *fakeStore) GetTask(ctx context.Context, id string) (*domain.Task, error) {
+	f.spans = append(f.spans, trace.SpanContextFromContext(ctx))
+	if id != "t1" {
+		return nil, domain.ErrNotFound
+	}
+	return &domain.Task{ID: id}, nil
+}
+
+func (f *fakeStore) ListTasks(ctx context.Context, opts domain.TaskListOptions) (*domain.TaskPage, error) {
+	return nil, assert.AnError
+}
+
+type recordingObserver struct {
+	methods []string
+	errs    []error
+}
+
+func (o *recordingObserver) ObserveQuery(method string, duration time.Duration, err error) {
+	o.methods = append(o.methods, method)
+	o.errs = append(o.errs, err)
+}
+
+func TestInstrument(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	fake := &fakeStore{}
+	observer := &recordingObserver{}
+	s := Instrument(fake, observer)
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "rpc")
+	task, err := s.GetTask(ctx, "t1")
+	assert.NoError(t, err)
+	assert.Equal(t, "t1", task.ID)
+	_, err = s.GetTask(ctx, "missing")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	_, err = s.ListTasks(ctx, domain.TaskListOptions{})
+	assert.ErrorIs(t, err, assert.AnError)
+	parent.End()
+
+	assert.Equal(t, []string{"GetTask", "GetTask", "ListTasks"}, observer.methods)
+	assert.Equal(t, []error{nil, domain.ErrNotFound, assert.AnError}, observer.errs)
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 4) {
+		for i, name := range []string{"store.GetTask", "store.GetTask", "store.ListTasks"} {
+			assert.Equal(t, name, spans[i].Name())
+			assert.Equal(t, parent.SpanContext().SpanID(), spans[i].Parent().SpanID(), "store spans are children of the caller's")
+		}
+		assert.Equal(t, spans[0].SpanContext(), fake.spans[0], "the store runs inside its span")
+		assert.Equal(t, codes.Unset, spans[1].Status().Code, "not found is not an error")
+		assert.Equal(t, codes.Error, spans[2].Status().Code)
+	}
+}
@@ -0,0 +1,268 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mattn/go-sqlite3"
+	"github.com/sahidhossen/todo/storage-service/internal/auth"
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+	"github.com/sahidhossen/todo/storage-service/internal/events"
+)
+
+// CreateLabel adds a label. Each user's label names are unique, ignoring case.
+func (s *SQLiteStore) CreateLabel(ctx context.Context, name string) (*domain.Label, error) {
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	label := &domain.Label{ID: uuid.New().String(), Name: name, CreatedAt: time.Now()}
+	query := `INSERT INTO labels (id, owner_id, name, created_at) VALUES (?, ?, ?, ?)`
+	if _, err := s.db.ExecContext(ctx, query, label.ID, ownerID, label.Name, label.CreatedAt); err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("label %q: %w", name, domain.ErrAlreadyExists)
+		}
+		return nil, fmt.Errorf("failed to insert label: %w", err)
+	}
+	s.logger.DebugContext(ctx, "Label inserted", "id", label.ID, "name", label.Name)
+	return label, nil
+}
+
+// ListLabels retrieves all labels in alphabetical order, with the number of
+// tasks outside the trash that carry each one.
+func (s *SQLiteStore) ListLabels(ctx context.Context) ([]*domain.Label, error) {
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + labelColumns + ` FROM labels l WHERE l.owner_id = ? ORDER BY l.name`
+	rows, err := s.db.QueryContext(ctx, query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+	defer rows.Close()
+
+	var labels []*domain.Label
+	for rows.Next() {
+		label, err := scanLabel(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan label row: %w", err)
+		}
+		labels = append(labels, label)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+	return labels, nil
+}
+
+// RenameLabel changes the name of a label. Tasks keep the label under its new name.
+func (s *SQLiteStore) RenameLabel(ctx context.Context, id, name string) (*domain.Label, error) {
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.db.ExecContext(ctx, `UPDATE labels SET name = ? WHERE id = ? AND owner_id = ?`, name, id, ownerID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("label %q: %w", name, domain.ErrAlreadyExists)
+		}
+		return nil, fmt.Errorf("failed to rename label: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return nil, fmt.Errorf("label with ID %s not found: %w", id, domain.ErrNotFound)
+	}
+	s.logger.DebugContext(ctx, "Label renamed", "id", id, "name", name)
+
+	return s.getLabel(ctx, id)
+}
+
+// DeleteLabel removes a label. The schema cascades the delete to task_labels,
+// so the label also disappears from every task that carried it.
+func (s *SQLiteStore) DeleteLabel(ctx context.Context, id string) error {
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := s.db.ExecContext(ctx, `DELETE FROM labels WHERE id = ? AND owner_id = ?`, id, ownerID)
+	if err != nil {
+		return fmt.Errorf("failed to delete label: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("label with ID %s not found: %w", id, domain.ErrNotFound)
+	}
+	s.logger.DebugContext(ctx, "Label deleted", "id", id)
+	return nil
+}
+
+// AddLabels attaches labels to a task by name, creating the labels that do not exist yet.
+func (s *SQLiteStore) AddLabels(ctx context.Context, taskID string, names []string) (*domain.Task, error) {
+	defer s.lockPublish()()
+	err := s.changeLabels(ctx, taskID, func(tx *sql.Tx, ownerID string) error {
+		for _, name := range names {
+			_, err := tx.ExecContext(ctx,
+				`INSERT INTO labels (id, owner_id, name, created_at) VALUES (?, ?, ?, ?) ON CONFLICT (owner_id, name) DO NOTHING`,
+				uuid.New().String(), ownerID, name, time.Now())
+			if err != nil {
+				return fmt.Errorf("failed to insert label: %w", err)
+			}
+			_, err = tx.ExecContext(ctx,
+				`INSERT OR IGNORE INTO task_labels (task_id, label_id) SELECT ?, id FROM labels WHERE owner_id = ? AND name = ?`,
+				taskID, ownerID, name)
+			if err != nil {
+				return fmt.Errorf("failed to attach label: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.logger.DebugContext(ctx, "Labels added to task", "id", taskID, "labels", names)
+	return s.getChangedTask(ctx, taskID)
+}
+
+// RemoveLabels detaches labels from a task by name. Names the task does not carry are ignored.
+func (s *SQLiteStore) RemoveLabels(ctx context.Context, taskID string, names []string) (*domain.Task, error) {
+	defer s.lockPublish()()
+	err := s.changeLabels(ctx, taskID, func(tx *sql.Tx, ownerID string) error {
+		for _, name := range names {
+			_, err := tx.ExecContext(ctx,
+				`DELETE FROM task_labels WHERE task_id = ? AND label_id IN (SELECT id FROM labels WHERE owner_id = ? AND name = ?)`,
+				taskID, ownerID, name)
+			if err != nil {
+				return fmt.Errorf("failed to detach label: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.logger.DebugContext(ctx, "Labels removed from task", "id", taskID, "labels", names)
+	return s.getChangedTask(ctx, taskID)
+}
+
+// getChangedTask retrieves a task after its labels changed and publishes the update.
+func (s *SQLiteStore) getChangedTask(ctx context.Context, taskID string) (*domain.Task, error) {
+	task, err := s.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	s.publish(events.TypeUpdated, task)
+	return task, nil
+}
+
+// changeLabels runs fn in a transaction after touching the task's updated_at,
+// which also checks that the task exists, belongs to the caller and is not in
+// the trash. fn is passed the caller's user ID.
+func (s *SQLiteStore) changeLabels(ctx context.Context, taskID string, fn func(tx *sql.Tx, ownerID string) error) error {
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `UPDATE tasks SET updated_at = ? WHERE id = ? AND owner_id = ? AND deleted_at IS NULL`, time.Now(), taskID, ownerID)
+	if err != nil {
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("task with ID %s not found: %w", taskID, domain.ErrNotFound)
+	}
+
+	if err := fn(tx, ownerID); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit label changes: %w", err)
+	}
+	return nil
+}
+
+// labelColumns is the column list label queries select from labels aliased as l, in the order scanLabel expects.
+const labelColumns = `l.id, l.name, l.created_at,
+	(SELECT COUNT(*) FROM task_labels tl JOIN tasks t ON t.id = tl.task_id
+		WHERE tl.label_id = l.id AND t.deleted_at IS NULL)`
+
+func scanLabel(row rowScanner) (*domain.Label, error) {
+	label := &domain.Label{}
+	if err := row.Scan(&label.ID, &label.Name, &label.CreatedAt, &label.TaskCount); err != nil {
+		return nil, err
+	}
+	return label, nil
+}
+
+func (s *SQLiteStore) getLabel(ctx context.Context, id string) (*domain.Label, error) {
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + labelColumns + ` FROM labels l WHERE l.id = ? AND l.owner_id = ?`
+	label, err := scanLabel(s.db.QueryRowContext(ctx, query, id, ownerID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("label with ID %s not found: %w", id, domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get label: %w", err)
+	}
+	return label, nil
+}
+
+// loadLabels fills in the label names of tasks with a single query.
+func (s *SQLiteStore) loadLabels(ctx context.Context, tasks ...*domain.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*domain.Task, len(tasks))
+	placeholders := make([]string, len(tasks))
+	args := make([]any, len(tasks))
+	for i, task := range tasks {
+		byID[task.ID] = task
+		placeholders[i] = "?"
+		args[i] = task.ID
+	}
+
+	query := `SELECT tl.task_id, l.name FROM task_labels tl JOIN labels l ON l.id = tl.label_id
+		WHERE tl.task_id IN (` + strings.Join(placeholders, ", ") + `) ORDER BY l.name`
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to load task labels: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID, name string
+		if err := rows.Scan(&taskID, &name); err != nil {
+			return fmt.Errorf("failed to scan task label: %w", err)
+		}
+		if task, ok := byID[taskID]; ok {
+			task.Labels = append(task.Labels, name)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error during rows iteration: %w", err)
+	}
+	return nil
+}
+
+// isUniqueViolation reports whether err comes from a UNIQUE constraint.
+func isUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+}
@@ -0,0 +1,354 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+)
+
+const (
+	// DefaultPageSize is used when a listing does not ask for a page size.
+	DefaultPageSize = 50
+	// MaxPageSize caps the page size a caller may ask for.
+	MaxPageSize = 1000
+
+	defaultOrderBy = "created_at desc"
+)
+
+// sortKind tells the cursor codec how to decode a sort key value.
+type sortKind int
+
+const (
+	sortTime sortKind = iota
+	sortString
+	sortFloat
+	sortInt
+	sortNullableTime // a time that may be NULL; NULLs sort as noDueDate
+)
+
+// noDueDate stands in for a NULL due_at when sorting, so tasks without a due
+// date sort after every dated task in ascending order.
+const noDueDate = "9999-12-31"
+
+// sortField is a field callers may sort a task listing by.
+type sortField struct {
+	expr  string // SQL expression, also used in keyset comparisons
+	kind  sortKind
+	value func(t *domain.Task) any
+}
+
+var sortFields = map[string]sortField{
+	"created_at": {expr: "created_at", kind: sortTime, value: func(t *domain.Task) any { return t.CreatedAt }},
+	"updated_at": {expr: "updated_at", kind: sortTime, value: func(t *domain.Task) any { return t.UpdatedAt }},
+	"title":      {expr: "title", kind: sortString, value: func(t *domain.Task) any { return t.Title }},
+	"priority":   {expr: "priority", kind: sortInt, value: func(t *domain.Task) any { return int64(t.Priority) }},
+	"due_at": {expr: "IFNULL(due_at, '" + noDueDate + "')", kind: sortNullableTime, value: func(t *domain.Task) any {
+		if t.DueAt == nil {
+			return nil
+		}
+		return *t.DueAt
+	}},
+}
+
+// sortKey is one parsed entry of an order_by clause.
+type sortKey struct {
+	name  string
+	field sortField
+	desc  bool
+}
+
+// parseOrderBy parses a comma-separated order_by clause such as "updated_at desc, title".
+// It returns the keys and the normalized clause used to tie page tokens to a sort order.
+func parseOrderBy(orderBy string) ([]sortKey, string, error) {
+	if strings.TrimSpace(orderBy) == "" {
+		orderBy = defaultOrderBy
+	}
+
+	var keys []sortKey
+	var normalized []string
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(orderBy, ",") {
+		tokens := strings.Fields(part)
+		if len(tokens) == 0 || len(tokens) > 2 {
+			return nil, "", fmt.Errorf("invalid order_by clause %q: %w", part, domain.ErrInvalidInput)
+		}
+
+		name := strings.ToLower(tokens[0])
+		field, ok := sortFields[name]
+		if !ok {
+			return nil, "", fmt.Errorf("cannot order by %q: %w", tokens[0], domain.ErrInvalidInput)
+		}
+		if seen[name] {
+			return nil, "", fmt.Errorf("order_by names %q more than once: %w", name, domain.ErrInvalidInput)
+		}
+		seen[name] = true
+
+		key := sortKey{name: name, field: field}
+		if len(tokens) == 2 {
+			switch strings.ToLower(tokens[1]) {
+			case "asc":
+			case "desc":
+				key.desc = true
+			default:
+				return nil, "", fmt.Errorf("invalid sort direction %q: %w", tokens[1], domain.ErrInvalidInput)
+			}
+		}
+		keys = append(keys, key)
+		if key.desc {
+			normalized = append(normalized, name+" desc")
+		} else {
+			normalized = append(normalized, name)
+		}
+	}
+	return keys, strings.Join(normalized, ", "), nil
+}
+
+// pageCursor is the decoded form of a page token. It records the sort key
+// values and ID of the last task on the previous page.
+type pageCursor struct {
+	OrderBy string `json:"o"`
+	Values  []any  `json:"v"`
+	ID      string `json:"id"`
+}
+
+// taskSortValues returns the values of the sort keys for task, in key order.
+func taskSortValues(keys []sortKey, task *domain.Task) []any {
+	values := make([]any, len(keys))
+	for i, key := range keys {
+		values[i] = key.field.value(task)
+	}
+	return values
+}
+
+// encodeCursor builds the opaque page token that resumes a listing after the
+// row with the given sort key values and ID.
+func encodeCursor(orderBy string, values []any, id string) (string, error) {
+	c := pageCursor{OrderBy: orderBy, ID: id}
+	for _, v := range values {
+		if t, ok := v.(time.Time); ok {
+			v = t.Format(time.RFC3339Nano)
+		}
+		c.Values = append(c.Values, v)
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode page token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// decodeCursor parses a page token and converts its values into query arguments.
+func decodeCursor(token string, keys []sortKey, orderBy string) ([]any, string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, "", fmt.Errorf("malformed page token: %w", domain.ErrInvalidInput)
+	}
+	var c pageCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, "", fmt.Errorf("malformed page token: %w", domain.ErrInvalidInput)
+	}
+	if c.OrderBy != orderBy || len(c.Values) != len(keys) || c.ID == "" {
+		return nil, "", fmt.Errorf("page token does not match order_by %q: %w", orderBy, domain.ErrInvalidInput)
+	}
+
+	args := make([]any, len(keys))
+	for i, key := range keys {
+		switch key.field.kind {
+		case sortTime:
+			s, ok := c.Values[i].(string)
+			if !ok {
+				return nil, "", fmt.Errorf("malformed page token: %w", domain.ErrInvalidInput)
+			}
+			t, err := time.Parse(time.RFC3339Nano, s)
+			if err != nil {
+				return nil, "", fmt.Errorf("malformed page token: %w", domain.ErrInvalidInput)
+			}
+			args[i] = t
+		case sortNullableTime:
+			if c.Values[i] == nil {
+				args[i] = noDueDate
+				continue
+			}
+			s, ok := c.Values[i].(string)
+			if !ok {
+				return nil, "", fmt.Errorf("malformed page token: %w", domain.ErrInvalidInput)
+			}
+			t, err := time.Parse(time.RFC3339Nano, s)
+			if err != nil {
+				return nil, "", fmt.Errorf("malformed page token: %w", domain.ErrInvalidInput)
+			}
+			args[i] = t
+		case sortString:
+			s, ok := c.Values[i].(string)
+			if !ok {
+				return nil, "", fmt.Errorf("malformed page token: %w", domain.ErrInvalidInput)
+			}
+			args[i] = s
+		case sortFloat:
+			f, ok := c.Values[i].(float64)
+			if !ok {
+				return nil, "", fmt.Errorf("malformed page token: %w", domain.ErrInvalidInput)
+			}
+			args[i] = f
+		case sortInt:
+			f, ok := c.Values[i].(float64) // JSON numbers decode as float64
+			if !ok || f != float64(int64(f)) {
+				return nil, "", fmt.Errorf("malformed page token: %w", domain.ErrInvalidInput)
+			}
+			args[i] = int64(f)
+		}
+	}
+	return args, c.ID, nil
+}
+
+// keysetCondition returns a WHERE fragment selecting the rows that sort after
+// the cursor position, with id as the final tie-breaker.
+func keysetCondition(keys []sortKey, values []any, id string) (string, []any) {
+	exprs := make([]string, 0, len(keys)+1)
+	ops := make([]string, 0, len(keys)+1)
+	for _, key := range keys {
+		exprs = append(exprs, key.field.expr)
+		ops = append(ops, comparison(key.desc))
+	}
+	exprs = append(exprs, "id")
+	ops = append(ops, comparison(keys[len(keys)-1].desc))
+	values = append(values, id)
+
+	var clauses []string
+	var args []any
+	for i := range exprs {
+		var parts []string
+		for j := 0; j < i; j++ {
+			parts = append(parts, exprs[j]+" = ?")
+			args = append(args, values[j])
+		}
+		parts = append(parts, exprs[i]+" "+ops[i]+" ?")
+		args = append(args, values[i])
+		clauses = append(clauses, "("+strings.Join(parts, " AND ")+")")
+	}
+	return "(" + strings.Join(clauses, " OR ") + ")", args
+}
+
+// orderByClause renders keys as a SQL ORDER BY list with id as tie-breaker.
+func orderByClause(keys []sortKey) string {
+	parts := make([]string, 0, len(keys)+1)
+	for _, key := range keys {
+		parts = append(parts, key.field.expr+" "+direction(key.desc))
+	}
+	parts = append(parts, "id "+direction(keys[len(keys)-1].desc))
+	return strings.Join(parts, ", ")
+}
+
+func comparison(desc bool) string {
+	if desc {
+		return "<"
+	}
+	return ">"
+}
+
+func direction(desc bool) string {
+	if desc {
+		return "DESC"
+	}
+	return "ASC"
+}
+
+// taskFilter collects WHERE conditions and their arguments for a task listing.
+type taskFilter struct {
+	conditions []string
+	args       []any
+}
+
+func (f *taskFilter) add(condition string, args ...any) {
+	f.conditions = append(f.conditions, condition)
+	f.args = append(f.args, args...)
+}
+
+func (f *taskFilter) where() string {
+	if len(f.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(f.conditions, " AND ")
+}
+
+// newTaskFilter builds the filter for the live (not deleted) tasks of ownerID
+// matching opts. now is the reference time for the overdue filter.
+func newTaskFilter(ownerID string, opts domain.TaskListOptions, now time.Time) *taskFilter {
+	f := &taskFilter{}
+	f.add("owner_id = ?", ownerID)
+	f.add("deleted_at IS NULL")
+	if opts.Completed != nil {
+		f.add("completed = ?", *opts.Completed)
+	}
+	if opts.CreatedAfter != nil {
+		f.add("created_at >= ?", dbTime(*opts.CreatedAfter))
+	}
+	if opts.CreatedBefore != nil {
+		f.add("created_at < ?", dbTime(*opts.CreatedBefore))
+	}
+	if opts.UpdatedAfter != nil {
+		f.add("updated_at >= ?", dbTime(*opts.UpdatedAfter))
+	}
+	if opts.UpdatedBefore != nil {
+		f.add("updated_at < ?", dbTime(*opts.UpdatedBefore))
+	}
+	if opts.Overdue != nil {
+		condition, args := overdueCondition(now)
+		if !*opts.Overdue {
+			condition = "NOT " + condition
+		}
+		f.add(condition, args...)
+	}
+	if opts.DueAfter != nil {
+		f.add("due_at >= ?", dbTime(*opts.DueAfter))
+	}
+	if opts.DueBefore != nil {
+		f.add("due_at < ?", dbTime(*opts.DueBefore))
+	}
+	if opts.ProjectID != "" {
+		f.add("project_id = ?", opts.ProjectID)
+	}
+	for _, label := range opts.Labels {
+		f.add("id IN (SELECT tl.task_id FROM task_labels tl JOIN labels l ON l.id = tl.label_id WHERE l.name = ?)", label)
+	}
+	return f
+}
+
+// overdueCondition matches open tasks past their due date at now. It mirrors
+// domain.Task.IsOverdue.
+func overdueCondition(now time.Time) (string, []any) {
+	return "(NOT completed AND due_at IS NOT NULL AND due_at < CASE WHEN all_day THEN ? ELSE ? END)",
+		[]any{dbTime(domain.DateOf(now)), dbTime(now)}
+}
+
+// dueTodayCondition matches open tasks due on the calendar day of now.
+func dueTodayCondition(now time.Time) (string, []any) {
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	end := start.AddDate(0, 0, 1)
+	return "(NOT completed AND CASE WHEN all_day THEN due_at = ? ELSE due_at >= ? AND due_at < ? END)",
+		[]any{dbTime(domain.DateOf(now)), dbTime(start), dbTime(end)}
+}
+
+// dbDueAt returns the value stored in due_at for task.
+func dbDueAt(task *domain.Task) any {
+	if task.DueAt == nil {
+		return nil
+	}
+	return dbTime(*task.DueAt)
+}
+
+// nullString stores an empty string as NULL.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// dbTime converts t to the local zone the store writes timestamps in, so that
+// the text comparisons SQLite performs on DATETIME columns stay correct.
+func dbTime(t time.Time) time.Time {
+	return t.In(time.Local)
+}
@@ -0,0 +1,94 @@
+package store
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOrderBy(t *testing.T) {
+	keys, normalized, err := parseOrderBy("Title,  updated_at DESC")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "title, updated_at desc", normalized)
+	assert.Len(t, keys, 2)
+	assert.False(t, keys[0].desc)
+	assert.True(t, keys[1].desc)
+
+	_, normalized, err = parseOrderBy("")
+	assert.NoError(t, err)
+	assert.Equal(t, defaultOrderBy, normalized)
+
+	for _, bad := range []string{"color", "title sideways", "title, title", "title desc extra"} {
+		_, _, err := parseOrderBy(bad)
+		assert.True(t, errors.Is(err, domain.ErrInvalidInput), bad)
+	}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	keys, orderBy, _ := parseOrderBy("created_at desc, title")
+	created := time.Date(2025, 7, 1, 9, 30, 0, 123456789, time.FixedZone("", 2*60*60))
+	task := &domain.Task{ID: "task-1", Title: "Buy milk", CreatedAt: created}
+
+	token, err := encodeCursor(orderBy, taskSortValues(keys, task), task.ID)
+	assert.NoError(t, err)
+
+	values, id, err := decodeCursor(token, keys, orderBy)
+	assert.NoError(t, err)
+	assert.Equal(t, "task-1", id)
+	assert.True(t, created.Equal(values[0].(time.Time)))
+	assert.Equal(t, "Buy milk", values[1])
+
+	_, _, err = decodeCursor(token, keys[:1], "created_at desc")
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+}
+
+func TestCursorRoundTrip_NoDueDate(t *testing.T) {
+	keys, orderBy, _ := parseOrderBy("due_at")
+	due := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	token, err := encodeCursor(orderBy, taskSortValues(keys, &domain.Task{ID: "dated", DueAt: &due}), "dated")
+	assert.NoError(t, err)
+	values, _, err := decodeCursor(token, keys, orderBy)
+	assert.NoError(t, err)
+	assert.True(t, due.Equal(values[0].(time.Time)))
+
+	token, err = encodeCursor(orderBy, taskSortValues(keys, &domain.Task{ID: "undated"}), "undated")
+	assert.NoError(t, err)
+	values, _, err = decodeCursor(token, keys, orderBy)
+	assert.NoError(t, err)
+	assert.Equal(t, noDueDate, values[0])
+}
+
+func TestCursorRoundTrip_Priority(t *testing.T) {
+	keys, orderBy, _ := parseOrderBy("priority desc, due_at")
+	task := &domain.Task{ID: "task-1", Priority: domain.PriorityHigh}
+
+	token, err := encodeCursor(orderBy, taskSortValues(keys, task), task.ID)
+	assert.NoError(t, err)
+
+	values, _, err := decodeCursor(token, keys, orderBy)
+	assert.NoError(t, err)
+	assert.Equal(t, []any{int64(domain.PriorityHigh), noDueDate}, values)
+}
+
+func TestOverdueCondition(t *testing.T) {
+	now := time.Date(2025, 7, 1, 15, 0, 0, 0, time.Local)
+
+	condition, args := overdueCondition(now)
+
+	assert.Contains(t, condition, "NOT completed")
+	assert.Equal(t, []any{time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC).In(time.Local), now}, args)
+}
+
+func TestKeysetCondition(t *testing.T) {
+	keys, _, _ := parseOrderBy("title desc")
+
+	condition, args := keysetCondition(keys, []any{"b"}, "id-1")
+
+	assert.Equal(t, "((title < ?) OR (title = ? AND id < ?))", condition)
+	assert.Equal(t, []any{"b", "b", "id-1"}, args)
+}
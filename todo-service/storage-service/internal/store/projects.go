@@ -0,0 +1,126 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sahidhossen/todo/storage-service/internal/auth"
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+)
+
+// projectColumns is the column list project queries select, in the order scanProject expects.
+const projectColumns = `id, name, color, archived, sort_order, created_at, updated_at`
+
+// SaveProject creates a new project or updates an existing one.
+func (s *SQLiteStore) SaveProject(ctx context.Context, project *domain.Project) error {
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return err
+	}
+	if project.ID == "" {
+		project.ID = uuid.New().String()
+		project.CreatedAt = time.Now()
+		project.UpdatedAt = time.Now()
+		query := `INSERT INTO projects (` + projectColumns + `, owner_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+		_, err := s.db.ExecContext(ctx, query, project.ID, project.Name, project.Color, project.Archived, project.SortOrder, project.CreatedAt, project.UpdatedAt, ownerID)
+		if err != nil {
+			return fmt.Errorf("failed to insert project: %w", err)
+		}
+		s.logger.DebugContext(ctx, "Project inserted", "id", project.ID)
+	} else {
+		project.UpdatedAt = time.Now()
+		query := `UPDATE projects SET name = ?, color = ?, archived = ?, sort_order = ?, updated_at = ? WHERE id = ? AND owner_id = ?`
+		result, err := s.db.ExecContext(ctx, query, project.Name, project.Color, project.Archived, project.SortOrder, project.UpdatedAt, project.ID, ownerID)
+		if err != nil {
+			return fmt.Errorf("failed to update project: %w", err)
+		}
+		rowsAffected, _ := result.RowsAffected()
+		if rowsAffected == 0 {
+			return fmt.Errorf("project with ID %s not found for update: %w", project.ID, domain.ErrNotFound)
+		}
+		s.logger.DebugContext(ctx, "Project updated", "id", project.ID)
+	}
+	return nil
+}
+
+// GetProject retrieves a project by its ID, archived or not.
+func (s *SQLiteStore) GetProject(ctx context.Context, id string) (*domain.Project, error) {
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE id = ? AND owner_id = ?`
+	project, err := scanProject(s.db.QueryRowContext(ctx, query, id, ownerID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("project with ID %s not found: %w", id, domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	return project, nil
+}
+
+// ListProjects retrieves projects by sort order, then name. Archived projects
+// are left out unless includeArchived is set.
+func (s *SQLiteStore) ListProjects(ctx context.Context, includeArchived bool) ([]*domain.Project, error) {
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE owner_id = ?`
+	if !includeArchived {
+		query += ` AND NOT archived`
+	}
+	query += ` ORDER BY sort_order, name`
+
+	rows, err := s.db.QueryContext(ctx, query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	defer rows.Close()
+
+	var projects []*domain.Project
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan project row: %w", err)
+		}
+		projects = append(projects, project)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+	return projects, nil
+}
+
+// DeleteProject removes a project. Its tasks, including those in the trash,
+// are kept and no longer belong to any project: the foreign key on
+// tasks.project_id clears it.
+func (s *SQLiteStore) DeleteProject(ctx context.Context, id string) error {
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := s.db.ExecContext(ctx, `DELETE FROM projects WHERE id = ? AND owner_id = ?`, id, ownerID)
+	if err != nil {
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("project with ID %s not found: %w", id, domain.ErrNotFound)
+	}
+	s.logger.DebugContext(ctx, "Project deleted", "id", id)
+	return nil
+}
+
+func scanProject(row rowScanner) (*domain.Project, error) {
+	project := &domain.Project{}
+	err := row.Scan(&project.ID, &project.Name, &project.Color, &project.Archived, &project.SortOrder, &project.CreatedAt, &project.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
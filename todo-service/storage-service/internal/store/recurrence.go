@@ -0,0 +1,38 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sahidhossen/todo/storage-service/internal/auth"
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+)
+
+// ListSeries retrieves the occurrences of a recurring task series by due
+// date, including those in the trash.
+func (s *SQLiteStore) ListSeries(ctx context.Context, seriesID string) ([]*domain.Task, error) {
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE series_id = ? AND owner_id = ? ORDER BY due_at, created_at, id`
+	rows, err := s.db.QueryContext(ctx, query, seriesID, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list series: %w", err)
+	}
+	defer rows.Close()
+
+	tasks, err := s.scanTasks(rows)
+	if err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("series with ID %s not found: %w", seriesID, domain.ErrNotFound)
+	}
+	if err := s.loadLabels(ctx, tasks...); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
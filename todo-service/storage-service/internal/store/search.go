@@ -0,0 +1,48 @@
+package store
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// searchSortKeys orders search results by bm25 score; lower scores rank first.
+var searchSortKeys = []sortKey{{name: "score", field: sortField{expr: "score", kind: sortFloat}}}
+
+// Highlight markers passed to the FTS5 highlight and snippet functions. They
+// cannot appear in HTML-escaped text, so renderHighlight can safely turn them into tags.
+const (
+	highlightStart = "\x02"
+	highlightEnd   = "\x03"
+)
+
+var highlightReplacer = strings.NewReplacer(highlightStart, "<mark>", highlightEnd, "</mark>")
+
+// matchExpression turns free text into an FTS5 query in which every word must
+// match as a prefix. Words are quoted so FTS5 syntax in user input is treated
+// as plain text. It returns "" when the text has no searchable words.
+func matchExpression(query string) string {
+	var terms []string
+	for _, word := range strings.Fields(query) {
+		if !strings.ContainsFunc(word, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) {
+			continue
+		}
+		terms = append(terms, `"`+strings.ReplaceAll(word, `"`, `""`)+`"*`)
+	}
+	return strings.Join(terms, " ")
+}
+
+// renderHighlight HTML-escapes text returned by highlight or snippet and wraps
+// the matched terms in <mark> tags.
+func renderHighlight(s string) string {
+	return highlightReplacer.Replace(html.EscapeString(s))
+}
+
+// qualifiedTaskColumns returns taskColumns prefixed with a table alias.
+func qualifiedTaskColumns(alias string) string {
+	columns := strings.Split(taskColumns, ", ")
+	for i, column := range columns {
+		columns[i] = alias + "." + column
+	}
+	return strings.Join(columns, ", ")
+}
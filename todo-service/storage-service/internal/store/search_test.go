@@ -0,0 +1,20 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchExpression(t *testing.T) {
+	assert.Equal(t, `"buy"* "milk"*`, matchExpression("  buy milk "))
+	assert.Equal(t, `"say"* """hi"""*`, matchExpression(`say "hi"`))
+	assert.Equal(t, `"cat"*`, matchExpression(`cat - ""`))
+	assert.Equal(t, "", matchExpression(" -- "))
+}
+
+func TestRenderHighlight(t *testing.T) {
+	raw := "Fix <b>" + highlightStart + "bug" + highlightEnd + "</b>"
+
+	assert.Equal(t, "Fix &lt;b&gt;<mark>bug</mark>&lt;/b&gt;", renderHighlight(raw))
+}
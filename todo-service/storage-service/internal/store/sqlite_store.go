@@ -5,19 +5,63 @@ import (
 	"database/sql"
 	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/sahidhossen/todo/storage-service/internal/auth"
 	"github.com/sahidhossen/todo/storage-service/internal/domain"
+	"github.com/sahidhossen/todo/storage-service/internal/events"
 )
 
 // ensure SQLiteStore implements the Store interface
 var _ Store = (*SQLiteStore)(nil)
 
+// taskColumns is the column list every task query selects, in the order scanTask expects.
+const taskColumns = `id, title, description, completed, created_at, updated_at, deleted_at, due_at, all_day, priority, project_id, parent_id, auto_complete, recurrence, series_id, owner_id`
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanTask reads a row selected with taskColumns into a domain.Task. Columns
+// selected after taskColumns are scanned into extra.
+func scanTask(row rowScanner, extra ...any) (*domain.Task, error) {
+	task := &domain.Task{}
+	var description, projectID, parentID, recurrence, seriesID, ownerID sql.NullString
+	var deletedAt, dueAt sql.NullTime
+	dest := []any{&task.ID, &task.Title, &description, &task.Completed, &task.CreatedAt, &task.UpdatedAt, &deletedAt, &dueAt, &task.AllDay, &task.Priority, &projectID, &parentID, &task.AutoComplete, &recurrence, &seriesID, &ownerID}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	task.Description = description.String
+	task.ProjectID = projectID.String
+	task.ParentID = parentID.String
+	task.Recurrence = recurrence.String
+	task.SeriesID = seriesID.String
+	task.OwnerID = ownerID.String
+	if deletedAt.Valid {
+		task.DeletedAt = &deletedAt.Time
+	}
+	if dueAt.Valid {
+		task.DueAt = &dueAt.Time
+	}
+	return task, nil
+}
+
 // SQLiteStore is an implementation of the Store interface using SQLite.
+//
+// Tasks, labels and projects belong to users. Every method reads the
+// caller's user ID from the context with auth.UserID and only sees and
+// changes that user's rows; other users' rows are reported as not found.
 type SQLiteStore struct {
 	db     *sql.DB
 	logger *slog.Logger
+	events *events.Broker // nil when task events are not published
+	// publishMu is held by task writes from before they commit until their
+	// events are published, see lockPublish.
+	publishMu sync.Mutex
 }
 
 // NewSQLiteStore creates a new SQLiteStore instance.
@@ -28,64 +72,352 @@ func NewSQLiteStore(db *sql.DB, logger *slog.Logger) *SQLiteStore {
 	}
 }
 
-// SaveTask save or update a task to the database.
+// WithEvents makes the store publish an event to broker for every task it
+// creates, changes or moves to or from the trash.
+func (s *SQLiteStore) WithEvents(broker *events.Broker) *SQLiteStore {
+	s.events = broker
+	return s
+}
+
+// publish sends an event for each task to the broker, if there is one.
+func (s *SQLiteStore) publish(typ events.Type, tasks ...*domain.Task) {
+	if s.events == nil {
+		return
+	}
+	for _, task := range tasks {
+		s.events.Publish(typ, task)
+	}
+}
+
+// lockPublish serializes the task writes that publish events, so that the
+// broker numbers the events in the order the changes were committed rather
+// than in the order the writers reached publish. It returns the function that
+// releases the lock. Without a broker there is nothing to order.
+func (s *SQLiteStore) lockPublish() func() {
+	if s.events == nil {
+		return func() {}
+	}
+	s.publishMu.Lock()
+	return s.publishMu.Unlock
+}
+
+// completionEvent returns the event type of a change to a task that was
+// completed before the change if wasCompleted is set.
+func completionEvent(wasCompleted bool, task *domain.Task) events.Type {
+	if task.Completed && !wasCompleted {
+		return events.TypeCompleted
+	}
+	return events.TypeUpdated
+}
+
+// SaveTask save or update a task to the database. A task that is created
+// under a parent, or moved to another one, has the move validated in the same
+// transaction, so concurrent moves cannot build a cycle or nest subtasks
+// deeper than domain.MaxTaskDepth; see validateParent.
 func (s *SQLiteStore) SaveTask(ctx context.Context, task *domain.Task) error {
+	defer s.lockPublish()()
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	task.OwnerID = ownerID
 	if task.ID == "" {
+		if task.ParentID != "" {
+			if err := validateParent(ctx, tx, ownerID, "", task.ParentID); err != nil {
+				return err
+			}
+		}
 		task.ID = uuid.New().String()
 		task.CreatedAt = time.Now()
 		task.UpdatedAt = time.Now()
-		query := `INSERT INTO tasks (id, title, description, completed, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)`
-		_, err := s.db.ExecContext(ctx, query, task.ID, task.Title, task.Description, task.Completed, task.CreatedAt, task.UpdatedAt)
+		// A recurring task without a series starts one.
+		if task.Recurrence != "" && task.SeriesID == "" {
+			task.SeriesID = task.ID
+		}
+		query := `INSERT INTO tasks (id, title, description, completed, created_at, updated_at, due_at, all_day, priority, project_id, parent_id, auto_complete, recurrence, series_id, owner_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		_, err := tx.ExecContext(ctx, query, task.ID, task.Title, task.Description, task.Completed, task.CreatedAt, task.UpdatedAt, dbDueAt(task), task.AllDay, task.Priority, nullString(task.ProjectID), nullString(task.ParentID), task.AutoComplete, nullString(task.Recurrence), nullString(task.SeriesID), ownerID)
 		if err != nil {
 			return fmt.Errorf("failed to insert task: %w", err)
 		}
-		s.logger.Debug("Task inserted", "id", task.ID)
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit task insert: %w", err)
+		}
+		s.logger.DebugContext(ctx, "Task inserted", "id", task.ID)
+		s.publish(events.TypeCreated, task)
 	} else {
-		task.UpdatedAt = time.Now()
-		query := `UPDATE tasks SET title = ?, description = ?, completed = ?, updated_at = ? WHERE id = ?`
-		result, err := s.db.ExecContext(ctx, query, task.Title, task.Description, task.Completed, task.UpdatedAt, task.ID)
+		typ, err := updateTask(ctx, tx, ownerID, task)
 		if err != nil {
-			return fmt.Errorf("failed to update task: %w", err)
+			return err
 		}
-		rowsAffected, _ := result.RowsAffected()
-		if rowsAffected == 0 {
-			return fmt.Errorf("task with ID %s not found for update", task.ID)
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit task update: %w", err)
 		}
-		s.logger.Debug("Task updated", "id", task.ID)
+		s.logger.DebugContext(ctx, "Task updated", "id", task.ID)
+		s.publish(typ, task)
 	}
 	return nil
 }
 
-// GetTask retrieves a task by its ID.
+// UpdateTasks saves changes to several existing tasks in one transaction:
+// either all of them are updated or, if one fails, none is. Events are
+// published once the transaction has committed.
+func (s *SQLiteStore) UpdateTasks(ctx context.Context, tasks []*domain.Task) error {
+	defer s.lockPublish()()
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	types := make([]events.Type, len(tasks))
+	for i, task := range tasks {
+		task.OwnerID = ownerID
+		if types[i], err = updateTask(ctx, tx, ownerID, task); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit task updates: %w", err)
+	}
+	for i, task := range tasks {
+		s.publish(types[i], task)
+	}
+	s.logger.DebugContext(ctx, "Tasks updated", "count", len(tasks))
+	return nil
+}
+
+// updateTask writes the fields of an existing task within tx and returns the
+// type of the event the change publishes.
+func updateTask(ctx context.Context, tx *sql.Tx, ownerID string, task *domain.Task) (events.Type, error) {
+	// The previous completion tells updates and completions apart.
+	var wasCompleted bool
+	var parentID sql.NullString
+	err := tx.QueryRowContext(ctx, `SELECT completed, parent_id FROM tasks WHERE id = ? AND owner_id = ? AND deleted_at IS NULL`, task.ID, ownerID).Scan(&wasCompleted, &parentID)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("task with ID %s not found for update: %w", task.ID, domain.ErrNotFound)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get task: %w", err)
+	}
+	if task.ParentID != "" && task.ParentID != parentID.String {
+		if err := validateParent(ctx, tx, ownerID, task.ID, task.ParentID); err != nil {
+			return 0, err
+		}
+	}
+
+	task.UpdatedAt = time.Now()
+	if task.Recurrence != "" && task.SeriesID == "" {
+		task.SeriesID = task.ID
+	}
+	query := `UPDATE tasks SET title = ?, description = ?, completed = ?, updated_at = ?, due_at = ?, all_day = ?, priority = ?, project_id = ?, parent_id = ?, auto_complete = ?, recurrence = ?, series_id = ? WHERE id = ? AND owner_id = ?`
+	_, err = tx.ExecContext(ctx, query, task.Title, task.Description, task.Completed, task.UpdatedAt, dbDueAt(task), task.AllDay, task.Priority, nullString(task.ProjectID), nullString(task.ParentID), task.AutoComplete, nullString(task.Recurrence), nullString(task.SeriesID), task.ID, ownerID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update task: %w", err)
+	}
+	return completionEvent(wasCompleted, task), nil
+}
+
+// GetTask retrieves a task by its ID. Tasks in the trash are not returned.
 func (s *SQLiteStore) GetTask(ctx context.Context, id string) (*domain.Task, error) {
-	query := `SELECT id, title, description, completed, created_at, updated_at FROM tasks WHERE id = ?`
-	task := &domain.Task{}
-	err := s.db.QueryRowContext(ctx, query, id).Scan(&task.ID, &task.Title, &task.Description, &task.Completed, &task.CreatedAt, &task.UpdatedAt)
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE id = ? AND owner_id = ? AND deleted_at IS NULL`
+	task, err := scanTask(s.db.QueryRowContext(ctx, query, id, ownerID))
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("task with ID %s not found: %w", id, err)
+		return nil, fmt.Errorf("task with ID %s not found: %w", id, domain.ErrNotFound)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get task: %w", err)
 	}
+	if err := s.loadLabels(ctx, task); err != nil {
+		return nil, err
+	}
 	return task, nil
 }
 
-// ListTasks retrieves all tasks.
-func (s *SQLiteStore) ListTasks(ctx context.Context) ([]*domain.Task, error) {
-	query := `SELECT * FROM tasks ORDER BY created_at DESC`
-	rows, err := s.db.QueryContext(ctx, query)
+// ListTasks retrieves one page of the tasks that are not in the trash, filtered
+// and sorted according to opts. Pages are addressed with keyset cursors so that
+// deep pages cost the same as the first one.
+func (s *SQLiteStore) ListTasks(ctx context.Context, opts domain.TaskListOptions) (*domain.TaskPage, error) {
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keys, orderBy, err := parseOrderBy(opts.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	filter := newTaskFilter(ownerID, opts, time.Now())
+
+	page := &domain.TaskPage{}
+	countQuery := `SELECT COUNT(*) FROM tasks` + filter.where()
+	if err := s.db.QueryRowContext(ctx, countQuery, filter.args...).Scan(&page.TotalSize); err != nil {
+		return nil, fmt.Errorf("failed to count tasks: %w", err)
+	}
+
+	if opts.PageToken != "" {
+		values, id, err := decodeCursor(opts.PageToken, keys, orderBy)
+		if err != nil {
+			return nil, err
+		}
+		condition, args := keysetCondition(keys, values, id)
+		filter.add(condition, args...)
+	}
+
+	query := `SELECT ` + taskColumns + ` FROM tasks` + filter.where() +
+		` ORDER BY ` + orderByClause(keys) + ` LIMIT ?`
+	rows, err := s.db.QueryContext(ctx, query, append(filter.args, pageSize+1)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list tasks: %w", err)
 	}
 	defer rows.Close()
 
+	tasks, err := s.scanTasks(rows)
+	if err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	// One extra row was requested to learn whether another page follows.
+	if len(tasks) > pageSize {
+		tasks = tasks[:pageSize]
+		last := tasks[pageSize-1]
+		page.NextPageToken, err = encodeCursor(orderBy, taskSortValues(keys, last), last.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := s.loadLabels(ctx, tasks...); err != nil {
+		return nil, err
+	}
+	page.Tasks = tasks
+
+	s.logger.DebugContext(ctx, "Listed tasks", "count", len(tasks), "total", page.TotalSize, "order_by", orderBy)
+	return page, nil
+}
+
+// SearchTasks runs a full-text search over the titles and descriptions of the
+// tasks that are not in the trash. Results are ranked by bm25, best first.
+func (s *SQLiteStore) SearchTasks(ctx context.Context, opts domain.TaskSearchOptions) (*domain.TaskSearchPage, error) {
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	match := matchExpression(opts.Query)
+	if match == "" {
+		return nil, fmt.Errorf("search query has no searchable words: %w", domain.ErrInvalidInput)
+	}
+
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	page := &domain.TaskSearchPage{}
+	countQuery := `SELECT COUNT(*) FROM tasks_fts JOIN tasks t ON t.seq = tasks_fts.rowid
+		WHERE tasks_fts MATCH ? AND t.owner_id = ? AND t.deleted_at IS NULL`
+	if err := s.db.QueryRowContext(ctx, countQuery, match, ownerID).Scan(&page.TotalSize); err != nil {
+		return nil, fmt.Errorf("failed to count search results: %w", err)
+	}
+
+	// The page token is tied to the query so it cannot be replayed against another search.
+	orderBy := "score:" + match
+	filter := &taskFilter{}
+	if opts.PageToken != "" {
+		values, id, err := decodeCursor(opts.PageToken, searchSortKeys, orderBy)
+		if err != nil {
+			return nil, err
+		}
+		condition, args := keysetCondition(searchSortKeys, values, id)
+		filter.add(condition, args...)
+	}
+
+	query := `SELECT ` + taskColumns + `, score, title_highlight, description_snippet FROM (
+		SELECT ` + qualifiedTaskColumns("t") + `,
+			bm25(tasks_fts) AS score,
+			highlight(tasks_fts, 0, char(2), char(3)) AS title_highlight,
+			snippet(tasks_fts, 1, char(2), char(3), '…', 16) AS description_snippet
+		FROM tasks_fts JOIN tasks t ON t.seq = tasks_fts.rowid
+		WHERE tasks_fts MATCH ? AND t.owner_id = ? AND t.deleted_at IS NULL
+	)` + filter.where() + ` ORDER BY ` + orderByClause(searchSortKeys) + ` LIMIT ?`
+	args := append([]any{match, ownerID}, filter.args...)
+	rows, err := s.db.QueryContext(ctx, query, append(args, pageSize+1)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tasks: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*domain.TaskSearchResult
+	for rows.Next() {
+		result := &domain.TaskSearchResult{}
+		var snippet sql.NullString
+		task, err := scanTask(rows, &result.Score, &result.TitleHighlight, &snippet)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		result.Task = task
+		result.TitleHighlight = renderHighlight(result.TitleHighlight)
+		result.DescriptionSnippet = renderHighlight(snippet.String)
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+	rows.Close()
+
+	if len(results) > pageSize {
+		results = results[:pageSize]
+		last := results[pageSize-1]
+		page.NextPageToken, err = encodeCursor(orderBy, []any{last.Score}, last.Task.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	tasks := make([]*domain.Task, len(results))
+	for i, result := range results {
+		tasks[i] = result.Task
+	}
+	if err := s.loadLabels(ctx, tasks...); err != nil {
+		return nil, err
+	}
+	page.Results = results
+
+	s.logger.DebugContext(ctx, "Searched tasks", "query", opts.Query, "count", len(results), "total", page.TotalSize)
+	return page, nil
+}
+
+// scanTasks reads all remaining rows selected with taskColumns.
+func (s *SQLiteStore) scanTasks(rows *sql.Rows) ([]*domain.Task, error) {
 	var tasks []*domain.Task
 	for rows.Next() {
-		task := &domain.Task{}
-		if err := rows.Scan(&task.ID, &task.Title, &task.Description, &task.Completed, &task.CreatedAt, &task.UpdatedAt); err != nil {
+		task, err := scanTask(rows)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan task row: %w", err)
 		}
-		s.logger.Info("Task retrieved", "Completed", task.Completed)
 		tasks = append(tasks, task)
 	}
 	if err := rows.Err(); err != nil {
@@ -96,36 +428,249 @@ func (s *SQLiteStore) ListTasks(ctx context.Context) ([]*domain.Task, error) {
 
 // CompleteTask marks a task as completed.
 func (s *SQLiteStore) ToggleTaskCompletion(ctx context.Context, id string) (*domain.Task, error) {
-	query := `UPDATE tasks SET completed = NOT completed, updated_at = ? WHERE id = ?`
-	result, err := s.db.ExecContext(ctx, query, time.Now(), id)
+	defer s.lockPublish()()
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := `UPDATE tasks SET completed = NOT completed, updated_at = ? WHERE id = ? AND owner_id = ? AND deleted_at IS NULL`
+	result, err := s.db.ExecContext(ctx, query, time.Now(), id, ownerID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to complete task: %w", err)
 	}
 
 	rowsAffected, _ := result.RowsAffected()
 	if rowsAffected == 0 {
-		return nil, fmt.Errorf("task with ID %s not found for completion", id)
+		return nil, fmt.Errorf("task with ID %s not found for completion: %w", id, domain.ErrNotFound)
 	}
 
-	return s.GetTask(ctx, id)
+	task, err := s.GetTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.publish(completionEvent(!task.Completed, task), task)
+	return task, nil
 }
 
-// GetTaskStats retrieves the total, completed, and remaining task counts.
-func (s *SQLiteStore) GetTaskStats(ctx context.Context) (*domain.TaskStats, error) {
+// GetTaskStats retrieves the total, completed, remaining, overdue and due today
+// counts of the caller's tasks selected by opts.
+func (s *SQLiteStore) GetTaskStats(ctx context.Context, opts domain.TaskStatsOptions) (*domain.TaskStats, error) {
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	overdue, overdueArgs := overdueCondition(now)
+	dueToday, dueTodayArgs := dueTodayCondition(now)
+	filter := newTaskFilter(ownerID, domain.TaskListOptions{ProjectID: opts.ProjectID}, now)
+	if opts.LeavesOnly {
+		filter.add("NOT EXISTS (SELECT 1 FROM tasks c WHERE c.parent_id = tasks.id AND c.deleted_at IS NULL)")
+	}
 	query := `
 		SELECT
 			COUNT(*) AS total,
-			COALESCE(SUM(CASE WHEN completed THEN 1 ELSE 0 END), 0) AS completed
-		FROM tasks;
-	`
+			COALESCE(SUM(CASE WHEN completed THEN 1 ELSE 0 END), 0) AS completed,
+			COALESCE(SUM(CASE WHEN ` + overdue + ` THEN 1 ELSE 0 END), 0) AS overdue,
+			COALESCE(SUM(CASE WHEN ` + dueToday + ` THEN 1 ELSE 0 END), 0) AS due_today
+		FROM tasks` + filter.where()
+	args := append(append(overdueArgs, dueTodayArgs...), filter.args...)
 	stats := &domain.TaskStats{}
-	err := s.db.QueryRowContext(ctx, query).Scan(&stats.Total, &stats.Completed)
+	err = s.db.QueryRowContext(ctx, query, args...).Scan(&stats.Total, &stats.Completed, &stats.Overdue, &stats.DueToday)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve task stats: %w", err)
 	}
 
 	stats.Pending = stats.Total - stats.Completed // Calculate pending tasks
 
-	s.logger.Debug("Retrieved task stats", "total", stats.Total, "completed", stats.Completed, "pending", stats.Pending)
+	s.logger.DebugContext(ctx, "Retrieved task stats", "total", stats.Total, "completed", stats.Completed, "pending", stats.Pending, "overdue", stats.Overdue, "due_today", stats.DueToday)
 	return stats, nil
 }
+
+// DeleteTask moves a task and its subtasks to the trash by setting their
+// deleted_at timestamp. They share the timestamp so that RestoreTask can
+// bring them back together.
+func (s *SQLiteStore) DeleteTask(ctx context.Context, id string) (*domain.Task, error) {
+	defer s.lockPublish()()
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	// Subtasks always have the owner of their parent.
+	query := `WITH RECURSIVE subtree(id) AS (
+			SELECT id FROM tasks WHERE id = ? AND owner_id = ? AND deleted_at IS NULL
+			UNION
+			SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at IS NULL
+		)
+		UPDATE tasks SET deleted_at = ?, updated_at = ? WHERE id IN subtree
+		RETURNING ` + taskColumns
+	rows, err := s.db.QueryContext(ctx, query, id, ownerID, now, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete task: %w", err)
+	}
+	defer rows.Close()
+
+	trashed, err := s.scanTasks(rows)
+	if err != nil {
+		return nil, err
+	}
+	rows.Close()
+	if len(trashed) == 0 {
+		return nil, fmt.Errorf("task with ID %s not found for deletion: %w", id, domain.ErrNotFound)
+	}
+	s.logger.DebugContext(ctx, "Task moved to trash", "id", id, "count", len(trashed))
+	s.publish(events.TypeDeleted, trashed...)
+
+	return s.getTaskInTrash(ctx, id)
+}
+
+// RestoreTask moves a task out of the trash, with the subtasks that were
+// trashed along with it. If the task's parent is not restored too, the task
+// becomes a top-level task.
+func (s *SQLiteStore) RestoreTask(ctx context.Context, id string) (*domain.Task, error) {
+	defer s.lockPublish()()
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `WITH RECURSIVE subtree(id) AS (
+			SELECT id FROM tasks WHERE id = ? AND owner_id = ? AND deleted_at IS NOT NULL
+			UNION
+			SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id
+				WHERE t.deleted_at = (SELECT deleted_at FROM tasks WHERE id = ?)
+		)
+		UPDATE tasks SET deleted_at = NULL, updated_at = ? WHERE id IN subtree
+		RETURNING ` + taskColumns
+	rows, err := tx.QueryContext(ctx, query, id, ownerID, id, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore task: %w", err)
+	}
+	defer rows.Close()
+
+	restored, err := s.scanTasks(rows)
+	if err != nil {
+		return nil, err
+	}
+	rows.Close()
+	if len(restored) == 0 {
+		return nil, fmt.Errorf("task with ID %s not found in trash: %w", id, domain.ErrNotFound)
+	}
+
+	query = `UPDATE tasks SET parent_id = NULL WHERE id = ? AND parent_id IS NOT NULL
+		AND parent_id NOT IN (SELECT id FROM tasks WHERE deleted_at IS NULL)`
+	if _, err := tx.ExecContext(ctx, query, id); err != nil {
+		return nil, fmt.Errorf("failed to detach restored task: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit task restore: %w", err)
+	}
+	s.logger.DebugContext(ctx, "Task restored from trash", "id", id, "count", len(restored))
+
+	task, err := s.GetTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Restored tasks reappear as updates, the root with its final parent.
+	if err := s.loadLabels(ctx, restored...); err != nil {
+		return nil, err
+	}
+	for i, t := range restored {
+		if t.ID == id {
+			restored[i] = task
+		}
+	}
+	s.publish(events.TypeUpdated, restored...)
+	return task, nil
+}
+
+// PurgeTask permanently removes a task and its subtasks. Only tasks in the
+// trash can be purged.
+func (s *SQLiteStore) PurgeTask(ctx context.Context, id string) error {
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return err
+	}
+	query := `WITH RECURSIVE subtree(id) AS (
+			SELECT id FROM tasks WHERE id = ? AND owner_id = ? AND deleted_at IS NOT NULL
+			UNION
+			SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at IS NOT NULL
+		)
+		DELETE FROM tasks WHERE id IN subtree`
+	result, err := s.db.ExecContext(ctx, query, id, ownerID)
+	if err != nil {
+		return fmt.Errorf("failed to purge task: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("task with ID %s not found in trash: %w", id, domain.ErrNotFound)
+	}
+	s.logger.DebugContext(ctx, "Task purged", "id", id, "count", rowsAffected)
+	return nil
+}
+
+// ListDeletedTasks retrieves the tasks in the trash, most recently deleted first.
+func (s *SQLiteStore) ListDeletedTasks(ctx context.Context) ([]*domain.Task, error) {
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE owner_id = ? AND deleted_at IS NOT NULL ORDER BY deleted_at DESC`
+	rows, err := s.db.QueryContext(ctx, query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted tasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks, err := s.scanTasks(rows)
+	if err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if err := s.loadLabels(ctx, tasks...); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// PurgeDeletedBefore permanently removes tasks that were moved to the trash before cutoff.
+// It returns the number of purged tasks. As the retention job of the whole
+// store it is the one method that is not scoped to a user.
+func (s *SQLiteStore) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	query := `DELETE FROM tasks WHERE deleted_at IS NOT NULL AND deleted_at < ?`
+	result, err := s.db.ExecContext(ctx, query, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted tasks: %w", err)
+	}
+
+	purged, _ := result.RowsAffected()
+	s.logger.DebugContext(ctx, "Purged deleted tasks", "count", purged, "cutoff", cutoff)
+	return purged, nil
+}
+
+// getTaskInTrash retrieves a task by its ID only if it is in the trash.
+func (s *SQLiteStore) getTaskInTrash(ctx context.Context, id string) (*domain.Task, error) {
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE id = ? AND owner_id = ? AND deleted_at IS NOT NULL`
+	task, err := scanTask(s.db.QueryRowContext(ctx, query, id, ownerID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("task with ID %s not found in trash: %w", id, domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+	if err := s.loadLabels(ctx, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
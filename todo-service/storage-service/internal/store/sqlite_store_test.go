@@ -0,0 +1,263 @@
+//go:build sqlite_fts5
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sahidhossen/todo/storage-service/internal/auth"
+	"github.com/sahidhossen/todo/storage-service/internal/db"
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+	"github.com/sahidhossen/todo/storage-service/internal/events"
+	"github.com/sahidhossen/todo/storage-service/internal/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestStore returns a store over a fresh, fully migrated database.
+func newTestStore(t *testing.T) (*SQLiteStore, *sql.DB) {
+	t.Helper()
+	logger := slog.New(slog.DiscardHandler)
+	database, err := db.NewConnection(filepath.Join(t.TempDir(), "test.db"), logger)
+	require.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+
+	migrator, err := migrations.New(database, logger)
+	require.NoError(t, err)
+	require.NoError(t, migrator.Up(context.Background()))
+	return NewSQLiteStore(database, logger), database
+}
+
+func TestSearchTasks_AfterVacuum(t *testing.T) {
+	s, database := newTestStore(t)
+	ctx := auth.WithUserID(context.Background(), "user-1")
+	first := &domain.Task{Title: "Buy bread"}
+	second := &domain.Task{Title: "Walk the dog"}
+	third := &domain.Task{Title: "Buy milk", Description: "semi-skimmed"}
+	for _, task := range []*domain.Task{first, second, third} {
+		require.NoError(t, s.SaveTask(ctx, task))
+	}
+	// Purging the first task leaves a gap in the rowids, which VACUUM is
+	// free to close by renumbering them.
+	_, err := s.DeleteTask(ctx, first.ID)
+	require.NoError(t, err)
+	require.NoError(t, s.PurgeTask(ctx, first.ID))
+
+	_, err = database.Exec(`VACUUM`)
+	require.NoError(t, err)
+
+	page, err := s.SearchTasks(ctx, domain.TaskSearchOptions{Query: "milk"})
+	require.NoError(t, err)
+	require.Len(t, page.Results, 1)
+	assert.Equal(t, third.ID, page.Results[0].Task.ID)
+	assert.Equal(t, "Buy <mark>milk</mark>", page.Results[0].TitleHighlight)
+}
+
+func TestAdoptUnowned(t *testing.T) {
+	s, database := newTestStore(t)
+	ctx := context.Background()
+	_, err := database.Exec(`INSERT INTO tasks (id, title) VALUES ('legacy-task', 'Legacy');
+		INSERT INTO labels (id, name) VALUES ('legacy-work', 'Work'), ('legacy-home', 'Home');
+		INSERT INTO task_labels (task_id, label_id) VALUES ('legacy-task', 'legacy-work'), ('legacy-task', 'legacy-home');
+		INSERT INTO projects (id, name) VALUES ('legacy-project', 'Legacy')`)
+	require.NoError(t, err)
+
+	first := &domain.User{Email: "first@example.com", PasswordHash: "hash"}
+	require.NoError(t, s.CreateUser(ctx, first))
+	owner := &domain.User{Email: "owner@example.com", PasswordHash: "hash"}
+	require.NoError(t, s.CreateUser(ctx, owner))
+	ownerCtx := auth.WithUserID(ctx, owner.ID)
+	work, err := s.CreateLabel(ownerCtx, "work")
+	require.NoError(t, err)
+
+	// Registering does not hand the legacy rows to anyone.
+	_, err = s.GetTask(auth.WithUserID(ctx, first.ID), "legacy-task")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	adopted, err := s.AdoptUnowned(ctx, "OWNER@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"tasks": 1, "labels": 1, "projects": 1}, adopted)
+
+	task, err := s.GetTask(ownerCtx, "legacy-task")
+	require.NoError(t, err)
+	assert.Equal(t, owner.ID, task.OwnerID)
+	labels, err := s.ListLabels(ownerCtx)
+	require.NoError(t, err)
+	names := make([]string, len(labels))
+	for i, label := range labels {
+		names[i] = label.Name
+	}
+	assert.ElementsMatch(t, []string{"Home", "work"}, names)
+	var merged bool
+	require.NoError(t, database.QueryRow(`SELECT EXISTS (SELECT 1 FROM task_labels WHERE task_id = 'legacy-task' AND label_id = ?)`, work.ID).Scan(&merged))
+	assert.True(t, merged)
+
+	_, err = s.AdoptUnowned(ctx, "nobody@example.com")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestDeleteProject_DetachesTasks(t *testing.T) {
+	s, _ := newTestStore(t)
+	ctx := auth.WithUserID(context.Background(), "user-1")
+	project := &domain.Project{Name: "Home"}
+	require.NoError(t, s.SaveProject(ctx, project))
+	open := &domain.Task{Title: "Paint the fence", ProjectID: project.ID}
+	trashed := &domain.Task{Title: "Fix the gate", ProjectID: project.ID}
+	for _, task := range []*domain.Task{open, trashed} {
+		require.NoError(t, s.SaveTask(ctx, task))
+	}
+	_, err := s.DeleteTask(ctx, trashed.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, s.DeleteProject(ctx, project.ID))
+
+	got, err := s.GetTask(ctx, open.ID)
+	require.NoError(t, err)
+	assert.Empty(t, got.ProjectID)
+	deleted, err := s.ListDeletedTasks(ctx)
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assert.Empty(t, deleted[0].ProjectID)
+
+	// A task cannot point at a project that does not exist.
+	assert.Error(t, s.SaveTask(ctx, &domain.Task{Title: "Orphan", ProjectID: project.ID}))
+}
+
+func TestSaveTask_ValidatesParent(t *testing.T) {
+	s, _ := newTestStore(t)
+	ctx := auth.WithUserID(context.Background(), "user-1")
+	root := &domain.Task{Title: "Root"}
+	require.NoError(t, s.SaveTask(ctx, root))
+	child := &domain.Task{Title: "Child", ParentID: root.ID}
+	require.NoError(t, s.SaveTask(ctx, child))
+
+	root.ParentID = child.ID
+	err := s.SaveTask(ctx, root)
+	var perr *domain.ParentError
+	require.ErrorAs(t, err, &perr)
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	assert.Equal(t, child.ID, perr.ParentID)
+
+	err = s.SaveTask(ctx, &domain.Task{Title: "Orphan", ParentID: "missing"})
+	require.ErrorAs(t, err, &perr)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+// Two tasks moved under each other at the same time must not both succeed.
+func TestSaveTask_ConcurrentMovesCannotCycle(t *testing.T) {
+	s, _ := newTestStore(t)
+	ctx := auth.WithUserID(context.Background(), "user-1")
+	for range 20 {
+		a := &domain.Task{Title: "A"}
+		b := &domain.Task{Title: "B"}
+		require.NoError(t, s.SaveTask(ctx, a))
+		require.NoError(t, s.SaveTask(ctx, b))
+
+		var wg sync.WaitGroup
+		for _, move := range [][2]*domain.Task{{a, b}, {b, a}} {
+			wg.Add(1)
+			go func(task, parent *domain.Task) {
+				defer wg.Done()
+				moved := *task
+				moved.ParentID = parent.ID
+				_ = s.SaveTask(ctx, &moved)
+			}(move[0], move[1])
+		}
+		wg.Wait()
+
+		gotA, err := s.GetTask(ctx, a.ID)
+		require.NoError(t, err)
+		gotB, err := s.GetTask(ctx, b.ID)
+		require.NoError(t, err)
+		assert.False(t, gotA.ParentID == b.ID && gotB.ParentID == a.ID, "tasks are each other's parent")
+	}
+}
+
+func TestUpdateTasks_AllOrNothing(t *testing.T) {
+	s, _ := newTestStore(t)
+	ctx := auth.WithUserID(context.Background(), "user-1")
+	first := &domain.Task{Title: "Water plants"}
+	second := &domain.Task{Title: "Feed cat"}
+	for _, task := range []*domain.Task{first, second} {
+		require.NoError(t, s.SaveTask(ctx, task))
+	}
+
+	first.Title = "Water all plants"
+	missing := &domain.Task{ID: "missing", Title: "Gone"}
+	err := s.UpdateTasks(ctx, []*domain.Task{first, missing})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	got, err := s.GetTask(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Water plants", got.Title, "the first update is rolled back")
+
+	second.Title = "Feed the cat"
+	require.NoError(t, s.UpdateTasks(ctx, []*domain.Task{first, second}))
+	got, err = s.GetTask(ctx, second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Feed the cat", got.Title)
+}
+
+// The last event for a task carries the state that was committed last.
+func TestSaveTask_EventsFollowCommitOrder(t *testing.T) {
+	s, _ := newTestStore(t)
+	// Updates log between committing and publishing; pausing there lets
+	// other writers commit in between.
+	s.logger = slog.New(pauseHandler{message: "Task updated"})
+	broker := events.NewBroker(0, slog.New(slog.DiscardHandler))
+	s.WithEvents(broker)
+	ctx := auth.WithUserID(context.Background(), "user-1")
+	task := &domain.Task{Title: "Draft"}
+	require.NoError(t, s.SaveTask(ctx, task))
+
+	for range 20 {
+		sub, _, err := broker.Subscribe(0)
+		require.NoError(t, err)
+		var wg sync.WaitGroup
+		for i := range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				update := *task
+				update.Title = fmt.Sprintf("Title %d", i)
+				_ = s.SaveTask(ctx, &update)
+			}()
+		}
+		wg.Wait()
+		sub.Close()
+
+		var last events.Event
+		for event := range sub.Events() {
+			last = event
+		}
+		got, err := s.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		require.NotNil(t, last.Task)
+		assert.Equal(t, got.Title, last.Task.Title)
+	}
+}
+
+// pauseHandler is a slog.Handler that sleeps briefly on records with message.
+type pauseHandler struct {
+	message string
+}
+
+func (h pauseHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h pauseHandler) Handle(_ context.Context, r slog.Record) error {
+	if r.Message == h.message {
+		time.Sleep(time.Duration(rand.IntN(10)) * time.Millisecond)
+	}
+	return nil
+}
+
+func (h pauseHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+
+func (h pauseHandler) WithGroup(string) slog.Handler { return h }
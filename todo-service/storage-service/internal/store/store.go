@@ -2,15 +2,50 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/sahidhossen/todo/storage-service/internal/domain"
 )
 
-// Store interface with method signature
+// Store interface with method signature. Apart from the user methods,
+// UseApiToken and PurgeDeletedBefore, methods act for the user whose ID the
+// context carries, see auth.WithUserID.
 type Store interface {
 	SaveTask(ctx context.Context, task *domain.Task) error
+	UpdateTasks(ctx context.Context, tasks []*domain.Task) error
 	GetTask(ctx context.Context, id string) (*domain.Task, error)
-	ListTasks(ctx context.Context) ([]*domain.Task, error)
+	ListTasks(ctx context.Context, opts domain.TaskListOptions) (*domain.TaskPage, error)
+	SearchTasks(ctx context.Context, opts domain.TaskSearchOptions) (*domain.TaskSearchPage, error)
 	ToggleTaskCompletion(ctx context.Context, id string) (*domain.Task, error)
-	GetTaskStats(ctx context.Context) (*domain.TaskStats, error)
+	GetTaskStats(ctx context.Context, opts domain.TaskStatsOptions) (*domain.TaskStats, error)
+	DeleteTask(ctx context.Context, id string) (*domain.Task, error)
+	RestoreTask(ctx context.Context, id string) (*domain.Task, error)
+	PurgeTask(ctx context.Context, id string) error
+	ListDeletedTasks(ctx context.Context) ([]*domain.Task, error)
+	PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error)
+
+	ListSubtasks(ctx context.Context, parentID string) ([]*domain.Task, error)
+	GetTaskTree(ctx context.Context, id string) (*domain.Task, error)
+
+	ListSeries(ctx context.Context, seriesID string) ([]*domain.Task, error)
+
+	CreateLabel(ctx context.Context, name string) (*domain.Label, error)
+	ListLabels(ctx context.Context) ([]*domain.Label, error)
+	RenameLabel(ctx context.Context, id, name string) (*domain.Label, error)
+	DeleteLabel(ctx context.Context, id string) error
+	AddLabels(ctx context.Context, taskID string, names []string) (*domain.Task, error)
+	RemoveLabels(ctx context.Context, taskID string, names []string) (*domain.Task, error)
+
+	SaveProject(ctx context.Context, project *domain.Project) error
+	GetProject(ctx context.Context, id string) (*domain.Project, error)
+	ListProjects(ctx context.Context, includeArchived bool) ([]*domain.Project, error)
+	DeleteProject(ctx context.Context, id string) error
+
+	CreateUser(ctx context.Context, user *domain.User) error
+	GetUserByEmail(ctx context.Context, email string) (*domain.User, error)
+
+	CreateApiToken(ctx context.Context, token *domain.ApiToken, hash string) error
+	ListApiTokens(ctx context.Context) ([]*domain.ApiToken, error)
+	RevokeApiToken(ctx context.Context, id string) error
+	UseApiToken(ctx context.Context, hash string, now time.Time) (*domain.ApiToken, error)
 }
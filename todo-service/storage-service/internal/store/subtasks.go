@@ -0,0 +1,163 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/sahidhossen/todo/storage-service/internal/auth"
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+)
+
+// ListSubtasks retrieves the direct subtasks of a task, oldest first.
+func (s *SQLiteStore) ListSubtasks(ctx context.Context, parentID string) ([]*domain.Task, error) {
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkTaskExists(ctx, parentID); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE parent_id = ? AND owner_id = ? AND deleted_at IS NULL ORDER BY created_at, id`
+	rows, err := s.db.QueryContext(ctx, query, parentID, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subtasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks, err := s.scanTasks(rows)
+	if err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if err := s.loadLabels(ctx, tasks...); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// GetTaskTree retrieves a task with its subtasks, and theirs, filled in as Children.
+func (s *SQLiteStore) GetTaskTree(ctx context.Context, id string) (*domain.Task, error) {
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := `WITH RECURSIVE subtree(id, level) AS (
+			SELECT id, 1 FROM tasks WHERE id = ? AND owner_id = ? AND deleted_at IS NULL
+			UNION ALL
+			SELECT t.id, s.level + 1 FROM tasks t JOIN subtree s ON t.parent_id = s.id
+				WHERE t.deleted_at IS NULL AND s.level < ?
+		)
+		SELECT ` + taskColumns + ` FROM tasks WHERE id IN (SELECT id FROM subtree) ORDER BY created_at, id`
+	rows, err := s.db.QueryContext(ctx, query, id, ownerID, domain.MaxTaskDepth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task tree: %w", err)
+	}
+	defer rows.Close()
+
+	tasks, err := s.scanTasks(rows)
+	if err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if err := s.loadLabels(ctx, tasks...); err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*domain.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+	root, ok := byID[id]
+	if !ok {
+		return nil, fmt.Errorf("task with ID %s not found: %w", id, domain.ErrNotFound)
+	}
+	for _, task := range tasks {
+		if parent, ok := byID[task.ParentID]; ok && task != root {
+			parent.Children = append(parent.Children, task)
+		}
+	}
+	return root, nil
+}
+
+// validateParent checks, within the transaction that saves the task, that
+// the task with ID taskID can become a subtask of parentID: the parent must
+// exist, must not be the task or one of its subtasks, and the task's subtree
+// must still fit within domain.MaxTaskDepth below it. taskID is empty for
+// tasks that are not created yet. Tasks of other users cannot be parents, so
+// subtasks always share their parent's owner. Failures are returned as a
+// *domain.ParentError.
+func validateParent(ctx context.Context, tx *sql.Tx, ownerID, taskID, parentID string) error {
+	// Walk up from the parent. The walk stops one level past the limit, which
+	// is enough to reject the move, and also ends on corrupt cyclic data.
+	query := `WITH RECURSIVE ancestors(id, parent_id, level) AS (
+			SELECT id, parent_id, 1 FROM tasks WHERE id = ? AND owner_id = ? AND deleted_at IS NULL
+			UNION ALL
+			SELECT t.id, t.parent_id, a.level + 1 FROM tasks t JOIN ancestors a ON t.id = a.parent_id
+				WHERE a.level <= ?
+		)
+		SELECT id FROM ancestors`
+	rows, err := tx.QueryContext(ctx, query, parentID, ownerID, domain.MaxTaskDepth)
+	if err != nil {
+		return fmt.Errorf("failed to load parent task: %w", err)
+	}
+	defer rows.Close()
+
+	var parentDepth int
+	for rows.Next() {
+		var ancestorID string
+		if err := rows.Scan(&ancestorID); err != nil {
+			return fmt.Errorf("failed to scan parent task: %w", err)
+		}
+		if taskID != "" && ancestorID == taskID {
+			return &domain.ParentError{ParentID: parentID, Err: fmt.Errorf("a task cannot be moved under itself or one of its subtasks: %w", domain.ErrInvalidInput)}
+		}
+		parentDepth++
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error during rows iteration: %w", err)
+	}
+	rows.Close()
+	if parentDepth == 0 {
+		return &domain.ParentError{ParentID: parentID, Err: fmt.Errorf("parent task with ID %s not found: %w", parentID, domain.ErrNotFound)}
+	}
+
+	height := 1
+	if taskID != "" {
+		query = `WITH RECURSIVE subtree(id, level) AS (
+				SELECT ?, 1
+				UNION ALL
+				SELECT t.id, s.level + 1 FROM tasks t JOIN subtree s ON t.parent_id = s.id
+					WHERE t.deleted_at IS NULL AND s.level <= ?
+			)
+			SELECT MAX(level) FROM subtree`
+		if err := tx.QueryRowContext(ctx, query, taskID, domain.MaxTaskDepth).Scan(&height); err != nil {
+			return fmt.Errorf("failed to measure subtasks: %w", err)
+		}
+	}
+
+	if parentDepth+height > domain.MaxTaskDepth {
+		return &domain.ParentError{ParentID: parentID, Err: fmt.Errorf("subtasks cannot be nested more than %d levels deep: %w", domain.MaxTaskDepth, domain.ErrInvalidInput)}
+	}
+	return nil
+}
+
+// checkTaskExists returns an error wrapping domain.ErrNotFound unless the task exists outside the trash.
+func (s *SQLiteStore) checkTaskExists(ctx context.Context, id string) error {
+	ownerID, err := auth.UserID(ctx)
+	if err != nil {
+		return err
+	}
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM tasks WHERE id = ? AND owner_id = ? AND deleted_at IS NULL)`
+	if err := s.db.QueryRowContext(ctx, query, id, ownerID).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check task: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("task with ID %s not found: %w", id, domain.ErrNotFound)
+	}
+	return nil
+}
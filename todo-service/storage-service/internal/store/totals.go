@@ -0,0 +1,26 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+)
+
+// Totals counts the tasks and users of all users. Unlike the Store methods
+// it is not scoped to the caller; it feeds the service metrics.
+func (s *SQLiteStore) Totals(ctx context.Context) (*domain.Totals, error) {
+	query := `
+		SELECT
+			COALESCE(SUM(CASE WHEN deleted_at IS NULL AND NOT completed THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN deleted_at IS NULL AND completed THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN deleted_at IS NOT NULL THEN 1 ELSE 0 END), 0),
+			(SELECT COUNT(*) FROM users)
+		FROM tasks`
+	totals := &domain.Totals{}
+	err := s.db.QueryRowContext(ctx, query).Scan(&totals.OpenTasks, &totals.CompletedTasks, &totals.TrashedTasks, &totals.Users)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count totals: %w", err)
+	}
+	return totals, nil
+}
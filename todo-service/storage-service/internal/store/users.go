@@ -0,0 +1,87 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sahidhossen/todo/storage-service/internal/domain"
+)
+
+// CreateUser adds a user. Email addresses are unique, ignoring case.
+func (s *SQLiteStore) CreateUser(ctx context.Context, user *domain.User) error {
+	user.ID = uuid.New().String()
+	user.CreatedAt = time.Now()
+	query := `INSERT INTO users (id, email, password_hash, created_at) VALUES (?, ?, ?, ?)`
+	if _, err := s.db.ExecContext(ctx, query, user.ID, user.Email, user.PasswordHash, user.CreatedAt); err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("user %q: %w", user.Email, domain.ErrAlreadyExists)
+		}
+		return fmt.Errorf("failed to insert user: %w", err)
+	}
+	s.logger.DebugContext(ctx, "User inserted", "id", user.ID)
+	return nil
+}
+
+// AdoptUnowned gives the tasks, labels and projects created before there
+// were user accounts to the user with the given email address. Until then
+// they belong to nobody and no user can see them. A legacy label is merged
+// into the user's label of the same name. It returns the number of rows
+// adopted per table.
+func (s *SQLiteStore) AdoptUnowned(ctx context.Context, email string) (map[string]int64, error) {
+	user, err := s.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	merge := []string{
+		`INSERT OR IGNORE INTO task_labels (task_id, label_id)
+			SELECT tl.task_id, mine.id FROM task_labels tl
+			JOIN labels legacy ON legacy.id = tl.label_id
+			JOIN labels mine ON mine.owner_id = ? AND mine.name = legacy.name
+			WHERE legacy.owner_id IS NULL`,
+		`DELETE FROM labels WHERE owner_id IS NULL AND name IN (SELECT name FROM labels WHERE owner_id = ?)`,
+	}
+	for _, query := range merge {
+		if _, err := tx.ExecContext(ctx, query, user.ID); err != nil {
+			return nil, fmt.Errorf("failed to merge unowned labels: %w", err)
+		}
+	}
+
+	adopted := make(map[string]int64)
+	for _, table := range []string{"tasks", "labels", "projects"} {
+		result, err := tx.ExecContext(ctx, `UPDATE `+table+` SET owner_id = ? WHERE owner_id IS NULL`, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to adopt unowned %s: %w", table, err)
+		}
+		adopted[table], _ = result.RowsAffected()
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit adoption: %w", err)
+	}
+	s.logger.InfoContext(ctx, "Adopted unowned rows", "user_id", user.ID, "tasks", adopted["tasks"], "labels", adopted["labels"], "projects", adopted["projects"])
+	return adopted, nil
+}
+
+// GetUserByEmail retrieves a user by email address, ignoring case.
+func (s *SQLiteStore) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	user := &domain.User{}
+	query := `SELECT id, email, password_hash, created_at FROM users WHERE email = ?`
+	err := s.db.QueryRowContext(ctx, query, email).Scan(&user.ID, &user.Email, &user.PasswordHash, &user.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("user %q not found: %w", email, domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, nil
+}
@@ -0,0 +1,135 @@
+package tlsconfig
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sahidhossen/todo/proto/tlsreload"
+	"github.com/sahidhossen/todo/storage-service/internal/devcerts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func generate(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, devcerts.Generate(dir, devcerts.DefaultHosts, time.Hour))
+	return dir
+}
+
+func serverConfig(dir string) ServerConfig {
+	return ServerConfig{
+		CertFile:     filepath.Join(dir, devcerts.ServerCertFile),
+		KeyFile:      filepath.Join(dir, devcerts.ServerKeyFile),
+		ClientCAFile: filepath.Join(dir, devcerts.CAFile),
+	}
+}
+
+// serve starts a gRPC server with creds and returns its address.
+func serve(t *testing.T, creds credentials.TransportCredentials) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer(grpc.Creds(creds))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+	return lis.Addr().String()
+}
+
+// check calls the health service at addr with a client trusting the CA in dir.
+func check(t *testing.T, addr, dir string, withCert bool) error {
+	t.Helper()
+	caPEM, err := os.ReadFile(filepath.Join(dir, devcerts.CAFile))
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM(caPEM))
+	config := &tls.Config{RootCAs: pool, ServerName: "localhost"}
+	if withCert {
+		cert, err := tls.LoadX509KeyPair(filepath.Join(dir, devcerts.ClientCertFile), filepath.Join(dir, devcerts.ClientKeyFile))
+		require.NoError(t, err)
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(credentials.NewTLS(config)))
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
+
+func TestServerCredentials_MutualTLS(t *testing.T) {
+	dir := generate(t)
+	creds, err := NewServerCredentials(serverConfig(dir), slog.New(slog.DiscardHandler))
+	require.NoError(t, err)
+	addr := serve(t, creds)
+
+	assert.NoError(t, check(t, addr, dir, true))
+	assert.Error(t, check(t, addr, dir, false), "clients need a certificate")
+}
+
+func TestServerCredentials_ServerOnly(t *testing.T) {
+	dir := generate(t)
+	cfg := serverConfig(dir)
+	cfg.ClientCAFile = ""
+	creds, err := NewServerCredentials(cfg, slog.New(slog.DiscardHandler))
+	require.NoError(t, err)
+
+	assert.NoError(t, check(t, serve(t, creds), dir, false))
+}
+
+func TestServerCredentials_MissingFiles(t *testing.T) {
+	_, err := NewServerCredentials(ServerConfig{CertFile: "cert.pem"}, nil)
+	assert.Error(t, err)
+
+	_, err = NewServerCredentials(ServerConfig{CertFile: "missing.pem", KeyFile: "missing-key.pem"}, nil)
+	assert.Error(t, err)
+}
+
+func TestServerCredentials_Reload(t *testing.T) {
+	dir := generate(t)
+	cfg := serverConfig(dir)
+	creds, err := tlsreload.New([]string{cfg.CertFile, cfg.KeyFile, cfg.ClientCAFile}, cfg.load, slog.New(slog.DiscardHandler))
+	require.NoError(t, err)
+	before, err := creds.Current()
+	require.NoError(t, err)
+
+	// A half-written key pair keeps the loaded one
+	require.NoError(t, os.WriteFile(cfg.CertFile, []byte("not a certificate"), 0o644))
+	touch(t, cfg.CertFile)
+	kept, err := creds.Current()
+	require.NoError(t, err)
+	assert.Same(t, before, kept)
+
+	require.NoError(t, devcerts.Generate(dir, devcerts.DefaultHosts, time.Hour))
+	touch(t, cfg.CertFile)
+	after, err := creds.Current()
+	require.NoError(t, err)
+	assert.False(t, bytes.Equal(before.Certificates[0].Certificate[0], after.Certificates[0].Certificate[0]), "the new certificate is loaded")
+
+	assert.NoError(t, check(t, serve(t, creds), dir, true), "clients of the new CA are accepted")
+}
+
+// touch moves the modification time of file forward, for file systems with
+// coarse timestamps.
+func touch(t *testing.T, file string) {
+	t.Helper()
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+	later := info.ModTime().Add(time.Second)
+	require.NoError(t, os.Chtimes(file, later, later))
+}
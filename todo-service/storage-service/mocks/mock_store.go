@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/sahidhossen/todo/storage-service/internal/domain"
 	"github.com/stretchr/testify/mock"
@@ -18,6 +19,10 @@ func (m *MockStore) SaveTask(ctx context.Context, task *domain.Task) error {
 	args := m.Called(ctx, task)
 	return args.Error(0)
 }
+func (m *MockStore) UpdateTasks(ctx context.Context, tasks []*domain.Task) error {
+	args := m.Called(ctx, tasks)
+	return args.Error(0)
+}
 func (m *MockStore) GetTask(ctx context.Context, id string) (*domain.Task, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -25,24 +30,181 @@ func (m *MockStore) GetTask(ctx context.Context, id string) (*domain.Task, error
 	}
 	return args.Get(0).(*domain.Task), args.Error(1)
 }
-func (m *MockStore) ListTasks(ctx context.Context) ([]*domain.Task, error) {
+func (m *MockStore) ListTasks(ctx context.Context, opts domain.TaskListOptions) (*domain.TaskPage, error) {
+	args := m.Called(ctx, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TaskPage), args.Error(1)
+}
+func (m *MockStore) SearchTasks(ctx context.Context, opts domain.TaskSearchOptions) (*domain.TaskSearchPage, error) {
+	args := m.Called(ctx, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TaskSearchPage), args.Error(1)
+}
+func (m *MockStore) ToggleTaskCompletion(ctx context.Context, id string) (*domain.Task, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Task), args.Error(1)
+}
+func (m *MockStore) GetTaskStats(ctx context.Context, opts domain.TaskStatsOptions) (*domain.TaskStats, error) {
+	args := m.Called(ctx, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TaskStats), args.Error(1)
+}
+func (m *MockStore) DeleteTask(ctx context.Context, id string) (*domain.Task, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Task), args.Error(1)
+}
+func (m *MockStore) RestoreTask(ctx context.Context, id string) (*domain.Task, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Task), args.Error(1)
+}
+func (m *MockStore) PurgeTask(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+func (m *MockStore) ListDeletedTasks(ctx context.Context) ([]*domain.Task, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*domain.Task), args.Error(1)
 }
-func (m *MockStore) ToggleTaskCompletion(ctx context.Context, id string) (*domain.Task, error) {
+func (m *MockStore) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	args := m.Called(ctx, cutoff)
+	return args.Get(0).(int64), args.Error(1)
+}
+func (m *MockStore) ListSubtasks(ctx context.Context, parentID string) ([]*domain.Task, error) {
+	args := m.Called(ctx, parentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Task), args.Error(1)
+}
+func (m *MockStore) GetTaskTree(ctx context.Context, id string) (*domain.Task, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.Task), args.Error(1)
 }
-func (m *MockStore) GetTaskStats(ctx context.Context) (*domain.TaskStats, error) {
+func (m *MockStore) ListSeries(ctx context.Context, seriesID string) ([]*domain.Task, error) {
+	args := m.Called(ctx, seriesID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Task), args.Error(1)
+}
+func (m *MockStore) CreateLabel(ctx context.Context, name string) (*domain.Label, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Label), args.Error(1)
+}
+func (m *MockStore) ListLabels(ctx context.Context) ([]*domain.Label, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*domain.TaskStats), args.Error(1)
+	return args.Get(0).([]*domain.Label), args.Error(1)
+}
+func (m *MockStore) RenameLabel(ctx context.Context, id, name string) (*domain.Label, error) {
+	args := m.Called(ctx, id, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Label), args.Error(1)
+}
+func (m *MockStore) DeleteLabel(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+func (m *MockStore) AddLabels(ctx context.Context, taskID string, names []string) (*domain.Task, error) {
+	args := m.Called(ctx, taskID, names)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Task), args.Error(1)
+}
+func (m *MockStore) RemoveLabels(ctx context.Context, taskID string, names []string) (*domain.Task, error) {
+	args := m.Called(ctx, taskID, names)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Task), args.Error(1)
+}
+func (m *MockStore) SaveProject(ctx context.Context, project *domain.Project) error {
+	args := m.Called(ctx, project)
+	return args.Error(0)
+}
+func (m *MockStore) GetProject(ctx context.Context, id string) (*domain.Project, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Project), args.Error(1)
+}
+func (m *MockStore) ListProjects(ctx context.Context, includeArchived bool) ([]*domain.Project, error) {
+	args := m.Called(ctx, includeArchived)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Project), args.Error(1)
+}
+func (m *MockStore) DeleteProject(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockStore) CreateUser(ctx context.Context, user *domain.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockStore) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockStore) CreateApiToken(ctx context.Context, token *domain.ApiToken, hash string) error {
+	args := m.Called(ctx, token, hash)
+	return args.Error(0)
+}
+
+func (m *MockStore) ListApiTokens(ctx context.Context) ([]*domain.ApiToken, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ApiToken), args.Error(1)
+}
+
+func (m *MockStore) RevokeApiToken(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockStore) UseApiToken(ctx context.Context, hash string, now time.Time) (*domain.ApiToken, error) {
+	args := m.Called(ctx, hash, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ApiToken), args.Error(1)
 }
@@ -18,10 +18,11 @@ describe("App", () => {
 
 	beforeEach(() => {
 		vi.clearAllMocks();
+		localStorage.setItem("todo.token", "test-token");
 		vi.spyOn(apiHook, "useApi").mockImplementation((url: string) => {
 			if (url === "/tasks") {
 				return {
-					data: mockTasks,
+					data: { tasks: mockTasks, next_page_token: "", total_size: mockTasks.length },
 					error: null,
 					isLoading: false,
 					isSuccess: true,
@@ -68,4 +69,20 @@ describe("App", () => {
 			expect(mockRefetch).toHaveBeenCalled();
 		});
 	});
+
+	it("shows the login form when signed out", () => {
+		localStorage.removeItem("todo.token");
+		render(<App />);
+
+		expect(screen.getByRole("heading", { name: "Sign in" })).toBeInTheDocument();
+		expect(screen.queryByText("Mock TodoForm")).not.toBeInTheDocument();
+	});
+
+	it("returns to the login form on sign out", () => {
+		render(<App />);
+		fireEvent.click(screen.getByText("Sign out"));
+
+		expect(screen.getByRole("heading", { name: "Sign in" })).toBeInTheDocument();
+		expect(localStorage.getItem("todo.token")).toBeNull();
+	});
 });
@@ -1,18 +1,35 @@
-import { Header, Stats, TodoForm, TodoList } from "./components";
+import { LogOut } from "lucide-react";
+import { Header, LoginForm, Stats, TodoForm, TodoList } from "./components";
+import { useAuth } from "./hooks/useAuth";
 import { useTodos } from "./hooks/useTodos";
 
 export default function App() {
-	const { tasks, isLoading, toggleTodo, refetchTodos } = useTodos();
+	const { isSignedIn, signOut } = useAuth();
 
 	return (
 		<div className="min-h-screen bg-gradient-to-br from-purple-50 via-blue-50 to-indigo-100">
 			<Header />
-			<div className="max-w-2xl mx-auto p-6 bg-white">
-				<TodoForm refetchTodo={refetchTodos} />
-				<TodoList tasks={tasks ?? []} isLoading={isLoading} toggleTodo={toggleTodo} />
+			<div className="max-w-2xl mx-auto p-6 bg-white">{isSignedIn ? <Todos signOut={signOut} /> : <LoginForm />}</div>
+		</div>
+	);
+}
 
-				<Stats isFetching={isLoading} />
+// Todos loads the task list only once the user has signed in.
+function Todos({ signOut }: { signOut: () => void }) {
+	const { tasks, isLoading, toggleTodo, refetchTodos } = useTodos();
+
+	return (
+		<>
+			<div className="flex justify-end mb-4">
+				<button onClick={signOut} className="flex items-center gap-2 text-sm text-gray-500 hover:text-gray-800">
+					<LogOut size={14} />
+					Sign out
+				</button>
 			</div>
-		</div>
+			<TodoForm refetchTodo={refetchTodos} />
+			<TodoList tasks={tasks ?? []} isLoading={isLoading} toggleTodo={toggleTodo} />
+
+			<Stats isFetching={isLoading} />
+		</>
 	);
 }
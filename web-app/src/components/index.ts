@@ -2,5 +2,6 @@ import { TodoList } from "./todoList/TodoList";
 import { TodoForm } from "./todoForm/TodoForm";
 import { Stats } from "./stats/Stats";
 import { Header } from "./header/Header";
+import { LoginForm } from "./login/LoginForm";
 
-export { TodoList, TodoForm, Stats, Header };
+export { TodoList, TodoForm, Stats, Header, LoginForm };
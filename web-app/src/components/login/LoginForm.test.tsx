@@ -0,0 +1,60 @@
+import { render, screen, fireEvent, waitFor } from "@testing-library/react";
+import { describe, vi, it, expect, beforeEach } from "vitest";
+import * as api from "../../lib/apiClient";
+import { getToken } from "../../lib/auth";
+import { LoginForm } from "./LoginForm";
+
+vi.mock("../../lib/apiClient");
+
+describe("LoginForm", () => {
+	const mockApiClient = vi.mocked(api.apiClient);
+
+	beforeEach(() => {
+		vi.resetAllMocks();
+		localStorage.clear();
+	});
+
+	const fillIn = () => {
+		fireEvent.change(screen.getByPlaceholderText("Email"), { target: { value: "ada@example.com" } });
+		fireEvent.change(screen.getByPlaceholderText("Password"), { target: { value: "secret-password" } });
+	};
+
+	it("stores the token on sign in", async () => {
+		mockApiClient.mockResolvedValue({ token: "abc" });
+		render(<LoginForm />);
+		fillIn();
+		fireEvent.click(screen.getByRole("button", { name: "Sign in" }));
+
+		await waitFor(() => {
+			expect(getToken()).toBe("abc");
+		});
+		expect(mockApiClient).toHaveBeenCalledWith("/auth/login", {
+			method: "POST",
+			body: { email: "ada@example.com", password: "secret-password" },
+		});
+	});
+
+	it("registers before signing in", async () => {
+		mockApiClient.mockResolvedValueOnce({ id: "u1" }).mockResolvedValueOnce({ token: "abc" });
+		render(<LoginForm />);
+		fireEvent.click(screen.getByRole("button", { name: "Create an account" }));
+		fillIn();
+		fireEvent.click(screen.getByRole("button", { name: "Create account" }));
+
+		await waitFor(() => {
+			expect(getToken()).toBe("abc");
+		});
+		expect(mockApiClient).toHaveBeenNthCalledWith(1, "/auth/register", expect.objectContaining({ method: "POST" }));
+		expect(mockApiClient).toHaveBeenNthCalledWith(2, "/auth/login", expect.objectContaining({ method: "POST" }));
+	});
+
+	it("shows the error of a failed sign in", async () => {
+		mockApiClient.mockRejectedValue({ status: 401, message: "invalid email or password" });
+		render(<LoginForm />);
+		fillIn();
+		fireEvent.click(screen.getByRole("button", { name: "Sign in" }));
+
+		expect(await screen.findByRole("alert")).toHaveTextContent("invalid email or password");
+		expect(getToken()).toBeNull();
+	});
+});
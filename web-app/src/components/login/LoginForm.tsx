@@ -0,0 +1,90 @@
+import { useState, type FormEvent } from "react";
+import { LogIn } from "lucide-react";
+import { apiClient } from "../../lib/apiClient";
+import { setToken } from "../../lib/auth";
+
+type LoginResponse = {
+	token?: string;
+};
+
+/**
+ * Signs the user in, or registers a new account and then signs in. The token
+ * is stored with setToken, which switches the app to the task list.
+ */
+export const LoginForm = () => {
+	const [isRegistering, setIsRegistering] = useState(false);
+	const [email, setEmail] = useState("");
+	const [password, setPassword] = useState("");
+	const [error, setError] = useState<string | null>(null);
+	const [isSubmitting, setIsSubmitting] = useState(false);
+
+	const submit = async (event: FormEvent<HTMLFormElement>) => {
+		event.preventDefault();
+		setError(null);
+		setIsSubmitting(true);
+		try {
+			const credentials = { email: email.trim(), password };
+			if (isRegistering) {
+				await apiClient("/auth/register", { method: "POST", body: credentials });
+			}
+			const { token } = await apiClient<LoginResponse>("/auth/login", { method: "POST", body: credentials });
+			if (!token) {
+				setError("The server did not issue an access token.");
+				return;
+			}
+			setToken(token);
+		} catch (err: any) {
+			setError(err?.message || "Sign in failed. Please try again.");
+		} finally {
+			setIsSubmitting(false);
+		}
+	};
+
+	return (
+		<form onSubmit={submit} className="bg-gradient-to-r from-purple-50 to-blue-50 p-6 rounded-xl shadow-inner">
+			<h2 className="text-xl font-semibold text-gray-800 mb-4">{isRegistering ? "Create an account" : "Sign in"}</h2>
+			<input
+				type="email"
+				placeholder="Email"
+				value={email}
+				onChange={(e) => setEmail(e.target.value)}
+				className="w-full p-3 border border-gray-300 rounded-lg mb-3 focus:outline-none focus:ring-2 focus:ring-blue-500"
+				autoFocus
+				required
+			/>
+			<input
+				type="password"
+				placeholder="Password"
+				value={password}
+				onChange={(e) => setPassword(e.target.value)}
+				className="w-full p-3 border border-gray-300 rounded-lg mb-3 focus:outline-none focus:ring-2 focus:ring-blue-500"
+				required
+			/>
+			{error && (
+				<p role="alert" className="text-sm text-red-600 mb-3">
+					{error}
+				</p>
+			)}
+			<div className="flex items-center gap-4">
+				<button
+					type="submit"
+					disabled={isSubmitting}
+					className="flex items-center gap-2 bg-gradient-to-r from-purple-500 to-blue-500 hover:from-purple-600 hover:to-blue-600 text-white px-6 py-3 rounded-xl transition-all duration-200 shadow-lg disabled:opacity-50"
+				>
+					<LogIn size={16} />
+					{isRegistering ? "Create account" : "Sign in"}
+				</button>
+				<button
+					type="button"
+					onClick={() => {
+						setIsRegistering(!isRegistering);
+						setError(null);
+					}}
+					className="text-sm text-purple-600 hover:underline"
+				>
+					{isRegistering ? "I already have an account" : "Create an account"}
+				</button>
+			</div>
+		</form>
+	);
+};
@@ -74,4 +74,42 @@ describe("TodoForm", () => {
 			expect(mockMutate).not.toHaveBeenCalled();
 		});
 	});
+
+	it("shows field errors under their inputs", async () => {
+		mockMutate.mockImplementation(async ({ onError }) => {
+			onError?.({
+				status: 400,
+				message: "title must be at most 200 characters",
+				errors: [{ field: "title", detail: "title must be at most 200 characters" }],
+				success: false,
+				data: null,
+			});
+		});
+
+		render(<TodoForm refetchTodo={mockRefetch} />);
+		fireEvent.click(screen.getByText("Add New Todo"));
+		fireEvent.change(screen.getByPlaceholderText("Enter todo title..."), {
+			target: { value: "Too long" },
+		});
+		fireEvent.click(screen.getByText("Add Todo"));
+
+		expect(await screen.findByText("title must be at most 200 characters")).toBeInTheDocument();
+		expect(screen.getByPlaceholderText("Enter todo title...")).toHaveAttribute("aria-invalid", "true");
+		expect(mockRefetch).not.toHaveBeenCalled();
+	});
+
+	it("shows errors without a field above the buttons", async () => {
+		mockMutate.mockImplementation(async ({ onError }) => {
+			onError?.({ status: 404, message: "project with ID p1 not found", errors: [], success: false, data: null });
+		});
+
+		render(<TodoForm refetchTodo={mockRefetch} />);
+		fireEvent.click(screen.getByText("Add New Todo"));
+		fireEvent.change(screen.getByPlaceholderText("Enter todo title..."), {
+			target: { value: "New Task" },
+		});
+		fireEvent.click(screen.getByText("Add Todo"));
+
+		expect(await screen.findByRole("alert")).toHaveTextContent("project with ID p1 not found");
+	});
 });
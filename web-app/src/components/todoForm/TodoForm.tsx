@@ -1,6 +1,7 @@
 import { useState } from "react";
 import { Check, X, Plus } from "lucide-react";
 import { useApi } from "../../hooks/useApi";
+import type { ApiError } from "../../lib/apiClient";
 
 type IProps = {
 	refetchTodo: () => void;
@@ -10,12 +11,16 @@ export const TodoForm = ({ refetchTodo }: IProps) => {
 	const [isAdding, setIsAdding] = useState(false);
 	const [title, setTitle] = useState("");
 	const [description, setDescription] = useState("");
+	const [fieldErrors, setFieldErrors] = useState<Record<string, string>>({});
+	const [formError, setFormError] = useState<string | null>(null);
 
 	const { mutate: createTodo } = useApi("/tasks", { skip: true });
 
 	const addTodo = async (event: React.MouseEvent<HTMLButtonElement>) => {
 		event.preventDefault();
 		if (title.trim()) {
+			setFieldErrors({});
+			setFormError(null);
 			const newTodo = {
 				title: title.trim(),
 				description: description.trim(),
@@ -30,8 +35,18 @@ export const TodoForm = ({ refetchTodo }: IProps) => {
 					setTitle("");
 					setDescription("");
 				},
-				onError: (err) => {
-					console.error(err?.message || "Todo creation failed. Please try again.");
+				onError: (err: ApiError) => {
+					// Field errors show under their inputs; any others above the buttons.
+					const errors: Record<string, string> = {};
+					for (const { field, detail } of err?.errors ?? []) {
+						if (field === "title" || field === "description") {
+							errors[field] = detail;
+						}
+					}
+					setFieldErrors(errors);
+					if (Object.keys(errors).length === 0) {
+						setFormError(err?.message || "Todo creation failed. Please try again.");
+					}
 				},
 			});
 		}
@@ -41,6 +56,8 @@ export const TodoForm = ({ refetchTodo }: IProps) => {
 		setIsAdding(false);
 		setTitle("");
 		setDescription("");
+		setFieldErrors({});
+		setFormError(null);
 	};
 
 	return (
@@ -62,13 +79,22 @@ export const TodoForm = ({ refetchTodo }: IProps) => {
 						onChange={(e) => setTitle(e.target.value)}
 						className="w-full p-3 border border-gray-300 rounded-lg mb-3 focus:outline-none focus:ring-2 focus:ring-blue-500"
 						autoFocus
+						aria-invalid={!!fieldErrors.title}
 					/>
+					{fieldErrors.title && <p className="text-sm text-red-600 -mt-2 mb-3">{fieldErrors.title}</p>}
 					<textarea
 						placeholder="Enter description (optional)..."
 						value={description}
 						onChange={(e) => setDescription(e.target.value)}
 						className="w-full p-4 border border-purple-200 rounded-xl mb-4 h-24 resize-none focus:outline-none focus:ring-2 focus:ring-purple-500 focus:border-transparent transition-all duration-200"
+						aria-invalid={!!fieldErrors.description}
 					/>
+					{fieldErrors.description && <p className="text-sm text-red-600 -mt-3 mb-4">{fieldErrors.description}</p>}
+					{formError && (
+						<p role="alert" className="text-sm text-red-600 mb-3">
+							{formError}
+						</p>
+					)}
 					<div className="flex gap-2">
 						<button
 							onClick={addTodo}
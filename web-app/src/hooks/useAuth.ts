@@ -0,0 +1,11 @@
+import { useSyncExternalStore } from "react";
+import { clearToken, getToken, subscribe } from "../lib/auth";
+
+/**
+ * Tracks the signed-in user's access token. The token is cleared by signOut
+ * and by any request the API answers with 401.
+ */
+export function useAuth() {
+	const token = useSyncExternalStore(subscribe, getToken);
+	return { token, isSignedIn: token !== null, signOut: clearToken };
+}
@@ -20,7 +20,7 @@ describe("useTodos hook", () => {
 		vi.spyOn(apiHook, "useApi").mockImplementation((url) => {
 			if (url === "/tasks") {
 				return {
-					data: mockTasks,
+					data: { tasks: mockTasks, next_page_token: "", total_size: mockTasks.length },
 					error: null,
 					isLoading: false,
 					isSuccess: true,
@@ -1,9 +1,10 @@
 import { useCallback } from "react";
 import toast from "react-hot-toast";
 import { useApi } from "./useApi";
+import type { ITaskPage } from "../types/task";
 
 export function useTodos() {
-	const { data: tasks, refetch, isLoading } = useApi("/tasks");
+	const { data, refetch, isLoading } = useApi<ITaskPage>("/tasks");
 	const { mutate: completeTask } = useApi("/tasks/complete", { skip: true });
 
 	const toggleTodo = useCallback(
@@ -27,7 +28,7 @@ export function useTodos() {
 	}, [refetch]);
 
 	return {
-		tasks,
+		tasks: data?.tasks ?? null,
 		isLoading,
 		toggleTodo,
 		refetchTodos,
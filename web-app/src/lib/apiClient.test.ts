@@ -0,0 +1,62 @@
+import { describe, vi, it, expect, beforeEach, afterEach } from "vitest";
+import { apiClient } from "./apiClient";
+import { getToken, setToken } from "./auth";
+
+describe("apiClient", () => {
+	const respond = (status: number, body: unknown, contentType = "application/json") =>
+		vi.spyOn(globalThis, "fetch").mockResolvedValue(
+			new Response(JSON.stringify(body), { status, headers: { "Content-Type": contentType } })
+		);
+
+	beforeEach(() => {
+		localStorage.clear();
+	});
+
+	afterEach(() => {
+		vi.restoreAllMocks();
+	});
+
+	it("sends the stored token", async () => {
+		const fetchMock = respond(200, { tasks: [] });
+		setToken("abc");
+
+		await apiClient("/tasks");
+
+		const headers = fetchMock.mock.calls[0][1]?.headers as Record<string, string>;
+		expect(headers.Authorization).toBe("Bearer abc");
+	});
+
+	it("reads the detail and field errors of a problem", async () => {
+		respond(
+			400,
+			{
+				type: "about:blank",
+				title: "Bad Request",
+				status: 400,
+				detail: "title cannot be empty",
+				errors: [{ field: "title", detail: "title cannot be empty" }],
+			},
+			"application/problem+json"
+		);
+
+		await expect(apiClient("/tasks", { method: "POST", body: {} })).rejects.toMatchObject({
+			status: 400,
+			message: "title cannot be empty",
+			errors: [{ field: "title", detail: "title cannot be empty" }],
+		});
+	});
+
+	it("falls back to the problem title", async () => {
+		respond(502, { title: "Bad Gateway", status: 502 }, "application/problem+json");
+
+		await expect(apiClient("/tasks")).rejects.toMatchObject({ message: "Bad Gateway", errors: [] });
+	});
+
+	it("signs out on 401", async () => {
+		respond(401, { title: "Unauthorized", status: 401, detail: "token expired" }, "application/problem+json");
+		setToken("abc");
+
+		await expect(apiClient("/tasks")).rejects.toMatchObject({ status: 401 });
+		expect(getToken()).toBeNull();
+	});
+});
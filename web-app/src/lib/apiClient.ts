@@ -1,3 +1,5 @@
+import { clearToken, getToken } from "./auth";
+
 export type Method = "GET" | "POST" | "PUT" | "PATCH" | "DELETE";
 
 interface RequestOptions {
@@ -6,30 +8,60 @@ interface RequestOptions {
 	headers?: HeadersInit;
 }
 
+/**
+ * A field of the request that the API refused, from the errors member of an
+ * application/problem+json response.
+ */
+export interface FieldError {
+	field: string;
+	detail: string;
+}
+
+/**
+ * The error apiClient throws for a failed request. message is the problem's
+ * detail, falling back to its title.
+ */
+export interface ApiError {
+	status: number;
+	message: string;
+	errors: FieldError[];
+	success: false;
+	data: unknown;
+}
+
 const BASE_URL = import.meta.env.VITE_API_URL || "";
 
 /**
- * Central API client to handle all requests
+ * Central API client to handle all requests. Requests carry the signed-in
+ * user's token; a 401 response signs the user out, which shows the login form.
  */
 export async function apiClient<T>(url: string, options: RequestOptions = {}): Promise<T> {
+	const token = getToken();
 	const res = await fetch(`${BASE_URL}${url}`, {
 		method: options.method || "GET",
 		headers: {
 			"Content-Type": "application/json",
+			...(token ? { Authorization: `Bearer ${token}` } : {}),
 			...(options.headers || {}),
 		},
 		body: options.body ? JSON.stringify(options.body) : undefined,
 	});
 
-	const json = await res.json();
+	if (res.status === 401 && token) {
+		clearToken();
+	}
+
+	const json = res.status === 204 ? null : await res.json().catch(() => null);
 
-	if (!res.ok || json.success === false) {
-		throw {
+	if (!res.ok || json?.success === false) {
+		const error: ApiError = {
 			status: res.status,
-			message: json.error || "API Error",
+			message: json?.detail || json?.message || json?.title || "API Error",
+			errors: json?.errors ?? [],
 			success: false,
-			data: json || null,
+			data: json,
 		};
+		throw error;
 	}
 
 	return json;
@@ -0,0 +1,36 @@
+const TOKEN_KEY = "todo.token";
+
+type Listener = () => void;
+
+const listeners = new Set<Listener>();
+
+/**
+ * Returns the access token of the signed-in user, or null when signed out.
+ */
+export function getToken(): string | null {
+	return localStorage.getItem(TOKEN_KEY);
+}
+
+/**
+ * Stores the access token returned by POST /auth/login.
+ */
+export function setToken(token: string) {
+	localStorage.setItem(TOKEN_KEY, token);
+	listeners.forEach((listener) => listener());
+}
+
+/**
+ * Forgets the access token, which sends the app back to the login form.
+ */
+export function clearToken() {
+	localStorage.removeItem(TOKEN_KEY);
+	listeners.forEach((listener) => listener());
+}
+
+/**
+ * Calls listener whenever the token changes. Returns the unsubscribe function.
+ */
+export function subscribe(listener: Listener): () => void {
+	listeners.add(listener);
+	return () => listeners.delete(listener);
+}
@@ -5,4 +5,30 @@ export type ITask = {
 	completed?: boolean;
 	created_at?: any;
 	updated_at?: any;
+	due_at?: any;
+	all_day?: boolean;
+	priority?: number; // 0 none, 1 low, 2 medium, 3 high, 4 urgent
+	labels?: string[];
+	project_id?: string;
+	parent_id?: string;
+	auto_complete?: boolean;
+	children?: ITask[];
+	recurrence?: string; // RRULE, e.g. "FREQ=WEEKLY;BYDAY=MO"
+	series_id?: string;
+};
+
+export type ITaskPage = {
+	tasks: ITask[];
+	next_page_token: string;
+	total_size: number;
+};
+
+export type IProject = {
+	id: string;
+	name: string;
+	color?: string;
+	archived?: boolean;
+	sort_order?: number;
+	created_at?: any;
+	updated_at?: any;
 };